@@ -0,0 +1,152 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func batchSetWithdrawalCreds(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check for Atlas
+	atlasResponse, err := rp.IsAtlasDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if Atlas has been deployed: %w", err)
+	}
+	if !atlasResponse.IsAtlasDeployed {
+		fmt.Println("You cannot change a solo validator's withdrawal credentials to a minipool address until Atlas has been deployed.")
+		return nil
+	}
+
+	fmt.Println("This will convert the withdrawal credentials for each of the given validators from the old 0x00 (BLS) value to its minipool address. This is meant for solo validator conversion **only**.")
+	fmt.Println()
+
+	// Get the signed changes
+	var changes []api.WithdrawalCredsChange
+	if c.String("file") != "" {
+		changes, err = verifyWithdrawalCredsChangesFile(rp, c.String("file"))
+		if err != nil {
+			return err
+		}
+	} else {
+		validatorIndices, err := cliutils.ValidateUints("indices", c.String("indices"))
+		if err != nil {
+			return err
+		}
+
+		// Get the mnemonic
+		mnemonic := ""
+		if c.IsSet("mnemonic") {
+			mnemonic = c.String("mnemonic")
+		} else {
+			mnemonic = wallet.PromptMnemonic()
+		}
+
+		fmt.Printf("Signing withdrawal credential changes for %d validators...\n", len(validatorIndices))
+		response, err := rp.SignWithdrawalCredsChanges(mnemonic, validatorIndices)
+		if err != nil {
+			return err
+		}
+		changes = response.Changes
+	}
+
+	// Print the verification results
+	validChanges := []api.WithdrawalCredsChange{}
+	fmt.Println()
+	for _, change := range changes {
+		if change.Error != "" {
+			fmt.Printf("%sValidator %d: %s%s\n", colorRed, change.ValidatorIndex, change.Error, colorReset)
+			continue
+		}
+		fmt.Printf("Validator %d (%s): %s -> minipool %s\n", change.ValidatorIndex, change.ValidatorPubkey.Hex(), change.FromBLSPubkey.Hex(), change.ToExecutionAddress.Hex())
+		validChanges = append(validChanges, change)
+	}
+	fmt.Println()
+	if len(validChanges) == 0 {
+		fmt.Println("None of the withdrawal credential changes can be submitted.")
+		return nil
+	}
+	if len(validChanges) < len(changes) {
+		fmt.Printf("%s%d of the %d changes failed verification and will be skipped.%s\n\n", colorYellow, len(changes)-len(validChanges), len(changes), colorReset)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to submit %d withdrawal credential changes? This cannot be undone.", len(validChanges)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit the changes
+	submitted := 0
+	for i, change := range validChanges {
+		fmt.Printf("[%d/%d] Submitting the change for validator %d... ", i+1, len(validChanges), change.ValidatorIndex)
+		if _, err := rp.SubmitWithdrawalCredsChange(change.ValidatorIndex, change.FromBLSPubkey, change.ToExecutionAddress, change.Signature); err != nil {
+			fmt.Printf("%sfailed: %s%s\n", colorRed, err.Error(), colorReset)
+			continue
+		}
+		fmt.Println("done.")
+		submitted++
+	}
+
+	// Log & return
+	fmt.Println()
+	fmt.Printf("Successfully submitted %d of %d withdrawal credential changes.\n", submitted, len(validChanges))
+	if submitted > 0 {
+		fmt.Println("The Beacon Chain will process them over the next few epochs; you can follow their progress with a block explorer or `rocketpool minipool status`.")
+	}
+	return nil
+
+}
+
+// Load a file of offline-signed changes and verify each of them against the Beacon Chain and the node's minipools
+func verifyWithdrawalCredsChangesFile(rp *rocketpool.Client, path string) ([]api.WithdrawalCredsChange, error) {
+
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding file path: %w", err)
+	}
+	signedChanges, err := validator.LoadBLSToExecutionChanges(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Verifying %d withdrawal credential changes from %s...\n", len(signedChanges), path)
+	changes := make([]api.WithdrawalCredsChange, len(signedChanges))
+	for i, signedChange := range signedChanges {
+		change := api.WithdrawalCredsChange{
+			ValidatorIndex:     signedChange.Message.ValidatorIndex,
+			FromBLSPubkey:      signedChange.GetFromBLSPubkey(),
+			ToExecutionAddress: signedChange.Message.ToExecutionAddress,
+			Signature:          signedChange.GetSignature(),
+		}
+		response, err := rp.CanSubmitWithdrawalCredsChange(change.ValidatorIndex, change.FromBLSPubkey, change.ToExecutionAddress, change.Signature)
+		if err != nil {
+			change.Error = err.Error()
+		} else {
+			change.ValidatorPubkey = response.ValidatorPubkey
+		}
+		changes[i] = change
+	}
+	return changes, nil
+
+}
@@ -0,0 +1,353 @@
+package minipool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Config
+const (
+	batchPlanVersion  uint        = 1
+	batchPlanFileMode os.FileMode = 0600
+)
+
+// The state of a single minipool in a batch plan
+type batchItemStatus string
+
+const (
+	batchItemStatus_Pending   batchItemStatus = "pending"
+	batchItemStatus_Submitted batchItemStatus = "submitted"
+	batchItemStatus_Succeeded batchItemStatus = "succeeded"
+	batchItemStatus_Failed    batchItemStatus = "failed"
+	batchItemStatus_Scheduled batchItemStatus = "scheduled"
+	batchItemStatus_Exported  batchItemStatus = "exported"
+	batchItemStatus_Skipped   batchItemStatus = "skipped"
+)
+
+// A minipool in a batch plan
+type batchItem struct {
+	Minipool common.Address  `json:"minipool"`
+	Status   batchItemStatus `json:"status"`
+	TxHash   common.Hash     `json:"txHash,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// A batch operation on a set of minipools, saved to disk after every step (if a plan file was given) so an interrupted
+// or partially failed batch can be picked up again with --plan
+type batchPlan struct {
+	Version     uint         `json:"version"`
+	Operation   string       `json:"operation"`
+	Selector    string       `json:"selector,omitempty"`
+	CreatedTime time.Time    `json:"createdTime"`
+	Items       []*batchItem `json:"items"`
+
+	path string
+}
+
+// Select the minipools a batch operation will run on from the ones that are eligible for it, and create the plan for it.
+// If --plan points to an existing plan, it's resumed instead; otherwise the minipools come from --select, --minipool, or a prompt.
+// Returns the indices of the selected minipools in the eligible list; if none are returned, there's nothing left to do.
+func selectBatchMinipools(c *cli.Context, rp *rocketpool.Client, operation string, eligible []common.Address, options []string, prompt string, unavailableFormat string) ([]int, *batchPlan, error) {
+
+	// Index the eligible minipools
+	eligibleIndices := map[common.Address]int{}
+	for i, address := range eligible {
+		eligibleIndices[address] = i
+	}
+
+	// Load the existing plan if there is one
+	planPath := ""
+	if c.String("plan") != "" {
+		var err error
+		planPath, err = homedir.Expand(c.String("plan"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("error expanding plan path: %w", err)
+		}
+		plan, err := loadBatchPlan(planPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if plan != nil {
+			if plan.Operation != operation {
+				return nil, nil, fmt.Errorf("The plan in %s is for the %s operation, not %s.", planPath, plan.Operation, operation)
+			}
+			if plan.Selector != "" {
+				fmt.Printf("Resuming the plan in %s (created %s from selector '%s').\n", planPath, plan.CreatedTime.Format(TimeFormat), plan.Selector)
+			} else {
+				fmt.Printf("Resuming the plan in %s (created %s).\n", planPath, plan.CreatedTime.Format(TimeFormat))
+			}
+
+			// Finish waiting for the transactions that were submitted last time
+			plan.waitForSubmitted(rp)
+
+			// Retry the pending and failed minipools that are still eligible
+			selected := []int{}
+			for _, item := range plan.Items {
+				if item.Status != batchItemStatus_Pending && item.Status != batchItemStatus_Failed {
+					continue
+				}
+				index, exists := eligibleIndices[item.Minipool]
+				if !exists {
+					item.Status = batchItemStatus_Skipped
+					item.Error = fmt.Sprintf("no longer eligible to %s", operation)
+					continue
+				}
+				selected = append(selected, index)
+			}
+			if err := plan.save(); err != nil {
+				return nil, nil, err
+			}
+			if len(selected) == 0 {
+				plan.printSummary()
+				fmt.Println("There are no minipools left to process in this plan.")
+			}
+			return selected, plan, nil
+		}
+	}
+
+	// Get the selected minipools
+	var selected []int
+	if c.String("select") != "" {
+
+		// Resolve the selector, then keep the matches that are eligible
+		response, err := rp.SelectMinipools(c.String("select"))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, address := range response.Minipools {
+			if index, exists := eligibleIndices[address]; exists {
+				selected = append(selected, index)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil, fmt.Errorf("None of the %d minipools matching '%s' are eligible to %s.", len(response.Minipools), c.String("select"), operation)
+		}
+		fmt.Printf("The selector matched %d minipools, %d of which are eligible to %s.\n\n", len(response.Minipools), len(selected), operation)
+
+	} else if c.String("minipool") == "" {
+
+		// Prompt for minipool selection
+		promptOptions := make([]string, len(options)+1)
+		promptOptions[0] = "All available minipools"
+		copy(promptOptions[1:], options)
+		selection, _ := cliutils.Select(prompt, promptOptions)
+
+		// Get minipools
+		if selection == 0 {
+			for i := range eligible {
+				selected = append(selected, i)
+			}
+		} else {
+			selected = []int{selection - 1}
+		}
+
+	} else if c.String("minipool") == "all" {
+		for i := range eligible {
+			selected = append(selected, i)
+		}
+	} else {
+		selectedAddress := common.HexToAddress(c.String("minipool"))
+		index, exists := eligibleIndices[selectedAddress]
+		if !exists {
+			return nil, nil, fmt.Errorf(unavailableFormat, selectedAddress.Hex())
+		}
+		selected = []int{index}
+	}
+
+	// Create the plan
+	plan := &batchPlan{
+		Version:     batchPlanVersion,
+		Operation:   operation,
+		Selector:    c.String("select"),
+		CreatedTime: time.Now(),
+		Items:       make([]*batchItem, len(selected)),
+		path:        planPath,
+	}
+	for i, index := range selected {
+		plan.Items[i] = &batchItem{
+			Minipool: eligible[index],
+			Status:   batchItemStatus_Pending,
+		}
+	}
+	return selected, plan, nil
+
+}
+
+// Run a batch operation on the pending and failed minipools in a plan.
+// Every transaction is submitted before any of them are waited on, so they go out with sequential nonces; the submit
+// function should return an empty hash if the operation doesn't involve a transaction.
+func runBatch(c *cli.Context, rp *rocketpool.Client, plan *batchPlan, action string, submit func(address common.Address) (common.Hash, error)) error {
+
+	// Get the minipools to process
+	items := []*batchItem{}
+	for _, item := range plan.Items {
+		if item.Status == batchItemStatus_Pending || item.Status == batchItemStatus_Failed {
+			items = append(items, item)
+		}
+	}
+	if err := plan.save(); err != nil {
+		return err
+	}
+
+	// Submit the transactions
+	for i, item := range items {
+		fmt.Printf("[%d/%d] %s minipool %s... ", i+1, len(items), action, item.Minipool.Hex())
+		hash, err := submit(item.Minipool)
+		item.Error = ""
+		if err != nil {
+			item.Status = batchItemStatus_Failed
+			item.Error = err.Error()
+			fmt.Printf("%sfailed: %s%s\n", colorRed, err.Error(), colorReset)
+		} else if files := rp.TakeExportedTransactions(); len(files) > 0 {
+			item.Status = batchItemStatus_Exported
+			fmt.Printf("exported to %s.\n", files[0])
+		} else if rp.IsSchedulingTransactions() {
+			item.Status = batchItemStatus_Scheduled
+			fmt.Println("scheduled.")
+		} else if hash == (common.Hash{}) {
+			item.Status = batchItemStatus_Succeeded
+			fmt.Println("done.")
+		} else {
+			item.Status = batchItemStatus_Submitted
+			item.TxHash = hash
+			fmt.Printf("submitted as %s.\n", hash.Hex())
+		}
+
+		// If a custom nonce is set, increment it for the next transaction
+		if err == nil && item.Status != batchItemStatus_Succeeded && c.GlobalUint64("nonce") != 0 {
+			rp.IncrementCustomNonce()
+		}
+		if err := plan.save(); err != nil {
+			return err
+		}
+	}
+
+	// Wait for the transactions
+	plan.waitForSubmitted(rp)
+	if err := plan.save(); err != nil {
+		return err
+	}
+
+	// Print the results
+	plan.printSummary()
+	counts := plan.getStatusCounts()
+	if counts[batchItemStatus_Scheduled] > 0 {
+		gas.PrintScheduledTransaction()
+	}
+	if counts[batchItemStatus_Exported] > 0 {
+		fmt.Println("Sign the exported transactions on your offline machine with `rocketpool wallet sign-tx`, then submit them from this machine with `rocketpool wallet broadcast`.")
+	}
+	if counts[batchItemStatus_Failed] > 0 {
+		if plan.path != "" {
+			fmt.Printf("Run this command again with `--plan %s` to retry the failed minipools.\n", plan.path)
+		} else {
+			fmt.Println("Use the `--plan` flag next time to save the batch to a file, so failed minipools can be retried.")
+		}
+	}
+	return nil
+
+}
+
+// Wait for the transactions that have been submitted but not confirmed yet
+func (p *batchPlan) waitForSubmitted(rp *rocketpool.Client) {
+	for _, item := range p.Items {
+		if item.Status != batchItemStatus_Submitted {
+			continue
+		}
+		fmt.Printf("Waiting for transaction %s (minipool %s)... ", item.TxHash.Hex(), item.Minipool.Hex())
+		if _, err := rp.WaitForTransaction(item.TxHash); err != nil {
+			item.Status = batchItemStatus_Failed
+			item.Error = err.Error()
+			fmt.Printf("%sfailed: %s%s\n", colorRed, err.Error(), colorReset)
+		} else {
+			item.Status = batchItemStatus_Succeeded
+			fmt.Println("done.")
+		}
+		if err := p.save(); err != nil {
+			fmt.Printf("%sWARNING: %s%s\n", colorYellow, err.Error(), colorReset)
+		}
+	}
+}
+
+// Print the result of each minipool in the plan
+func (p *batchPlan) printSummary() {
+	fmt.Println()
+	for _, item := range p.Items {
+		switch item.Status {
+		case batchItemStatus_Succeeded:
+			fmt.Printf("%s: succeeded", item.Minipool.Hex())
+			if item.TxHash != (common.Hash{}) {
+				fmt.Printf(" (%s)", item.TxHash.Hex())
+			}
+			fmt.Println()
+		case batchItemStatus_Failed:
+			fmt.Printf("%s%s: failed (%s)%s\n", colorRed, item.Minipool.Hex(), item.Error, colorReset)
+		case batchItemStatus_Skipped:
+			fmt.Printf("%s%s: skipped (%s)%s\n", colorYellow, item.Minipool.Hex(), item.Error, colorReset)
+		default:
+			fmt.Printf("%s: %s\n", item.Minipool.Hex(), item.Status)
+		}
+	}
+	counts := p.getStatusCounts()
+	fmt.Printf("\n%d succeeded, %d failed, %d scheduled, %d exported, %d skipped.\n", counts[batchItemStatus_Succeeded], counts[batchItemStatus_Failed], counts[batchItemStatus_Scheduled], counts[batchItemStatus_Exported], counts[batchItemStatus_Skipped])
+}
+
+// Count the minipools in each status
+func (p *batchPlan) getStatusCounts() map[batchItemStatus]int {
+	counts := map[batchItemStatus]int{}
+	for _, item := range p.Items {
+		counts[item.Status]++
+	}
+	return counts
+}
+
+// Save the plan to its file, if it has one
+func (p *batchPlan) save() error {
+	if p.path == "" {
+		return nil
+	}
+	bytes, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing batch plan: %w", err)
+	}
+
+	// Write to a temporary file first so an interruption can't leave a partial plan behind
+	tempPath := filepath.Join(filepath.Dir(p.path), fmt.Sprintf(".%s.tmp", filepath.Base(p.path)))
+	if err := os.WriteFile(tempPath, bytes, batchPlanFileMode); err != nil {
+		return fmt.Errorf("error writing batch plan to %s: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, p.path); err != nil {
+		return fmt.Errorf("error saving batch plan to %s: %w", p.path, err)
+	}
+	return nil
+}
+
+// Load a batch plan from disk, returning nil if the file doesn't exist
+func loadBatchPlan(path string) (*batchPlan, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading batch plan from %s: %w", path, err)
+	}
+	plan := new(batchPlan)
+	if err := json.Unmarshal(bytes, plan); err != nil {
+		return nil, fmt.Errorf("error deserializing batch plan from %s: %w", path, err)
+	}
+	if plan.Version != batchPlanVersion {
+		return nil, fmt.Errorf("the batch plan in %s has unsupported version %d", path, plan.Version)
+	}
+	plan.path = path
+	return plan, nil
+}
@@ -0,0 +1,103 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/services/exits"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func broadcastExits(c *cli.Context) error {
+
+	// Load the archive
+	filePath, err := homedir.Expand(c.String("file"))
+	if err != nil {
+		return fmt.Errorf("error expanding file path: %w", err)
+	}
+	archive, err := exits.LoadArchive(filePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s contains %d pre-signed exit(s) for %s, created on %s.\n\n", filePath, archive.Count, archive.Network, archive.CreatedAt.Format(TimeFormat))
+
+	// Broadcast through the given Beacon Node, which doesn't need a Smartnode installation, or through the Smartnode
+	var network cfgtypes.Network
+	var broadcastExit func(exit exits.PresignedExit) error
+	if beaconUrl := c.String("beacon-url"); beaconUrl != "" {
+		// The Beacon Node rejects exits for a different chain, so the archive's network is trusted here
+		network = archive.Network
+		bc := client.NewStandardHttpClient(beaconUrl)
+		broadcastExit = func(exit exits.PresignedExit) error {
+			return bc.ExitValidator(exit.ValidatorIndex, exit.Epoch, exit.Signature)
+		}
+	} else {
+		// Get RP client
+		rp, err := rocketpool.NewClientFromCtx(c)
+		if err != nil {
+			return err
+		}
+		defer rp.Close()
+
+		// Get the config
+		cfg, isNew, err := rp.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if isNew {
+			return fmt.Errorf("The Smartnode hasn't been configured on this machine. Please use --beacon-url to broadcast the exits through a Beacon Node instead.")
+		}
+		network = cfg.Smartnode.Network.Value.(cfgtypes.Network)
+		broadcastExit = func(exit exits.PresignedExit) error {
+			_, err := rp.BroadcastExit(exit.ValidatorIndex, exit.Epoch, exit.Signature)
+			return err
+		}
+	}
+
+	// Decrypt the exits
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter the archive's password:", "^.+$", "")
+	}
+	presignedExits, err := archive.Decrypt(network, password)
+	if err != nil {
+		return err
+	}
+
+	// Print the exits
+	for _, exit := range presignedExits {
+		fmt.Printf("Minipool %s: validator %d (%s), signed at epoch %d\n", exit.MinipoolAddress.Hex(), exit.ValidatorIndex, exit.ValidatorPubkey.Hex(), exit.Epoch)
+	}
+	fmt.Println()
+
+	// Show a warning message
+	fmt.Printf("%sNOTE:\n", colorYellow)
+	fmt.Println("Broadcasting these exits will tell each validator to stop all activities on the Beacon Chain.")
+	fmt.Println("The validators must keep running until they've been processed by the exit queue; you can watch their progress on the https://beaconcha.in explorer.")
+	fmt.Printf("Once their funds have been withdrawn, the node operator can run `rocketpool minipool close` to close the minipools.\n\n%s", colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to broadcast %d exit(s)? This action cannot be undone!", len(presignedExits)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Broadcast the exits
+	for _, exit := range presignedExits {
+		if err := broadcastExit(exit); err != nil {
+			fmt.Printf("Could not broadcast the exit for minipool %s: %s.\n", exit.MinipoolAddress.Hex(), err)
+		} else {
+			fmt.Printf("Successfully broadcast the exit for minipool %s.\n", exit.MinipoolAddress.Hex())
+		}
+	}
+	fmt.Println("It may take several hours for the minipools' statuses to be reflected.")
+
+	// Return
+	return nil
+
+}
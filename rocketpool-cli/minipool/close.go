@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"bytes"
 	"fmt"
 	"math/big"
 
@@ -20,7 +19,8 @@ import (
 )
 
 const (
-	colorBlue string = "\033[36m"
+	colorBlue  string = "\033[36m"
+	colorGreen string = "\033[32m"
 )
 
 func closeMinipools(c *cli.Context) error {
@@ -112,46 +112,26 @@ func closeMinipools(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []api.MinipoolCloseDetails
-	if c.String("minipool") == "" {
-
-		// Prompt for minipool selection
-		options := make([]string, len(closableMinipools)+1)
-		options[0] = "All available minipools"
-		for mi, minipool := range closableMinipools {
-			if minipool.MinipoolStatus == types.Dissolved {
-				options[mi+1] = fmt.Sprintf("%s (%.6f ETH will be returned)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6))
-			} else {
-				options[mi+1] = fmt.Sprintf("%s (%.6f ETH available, %.6f ETH is yours plus a refund of %.6f ETH)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6), math.RoundDown(eth.WeiToEth(minipool.NodeShare), 6), math.RoundDown(eth.WeiToEth(minipool.Refund), 6))
-			}
-		}
-		selected, _ := cliutils.Select("Please select a minipool to close:", options)
-
-		// Get minipools
-		if selected == 0 {
-			selectedMinipools = closableMinipools
+	addresses := make([]common.Address, len(closableMinipools))
+	options := make([]string, len(closableMinipools))
+	for mi, minipool := range closableMinipools {
+		addresses[mi] = minipool.Address
+		if minipool.MinipoolStatus == types.Dissolved {
+			options[mi] = fmt.Sprintf("%s (%.6f ETH will be returned)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6))
 		} else {
-			selectedMinipools = []api.MinipoolCloseDetails{closableMinipools[selected-1]}
+			options[mi] = fmt.Sprintf("%s (%.6f ETH available, %.6f ETH is yours plus a refund of %.6f ETH)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6), math.RoundDown(eth.WeiToEth(minipool.NodeShare), 6), math.RoundDown(eth.WeiToEth(minipool.Refund), 6))
 		}
-
-	} else {
-
-		// Get matching minipools
-		if c.String("minipool") == "all" {
-			selectedMinipools = closableMinipools
-		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range closableMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolCloseDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s is not available for closing.", selectedAddress.Hex())
-			}
-		}
-
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "close", addresses, options, "Please select a minipool to close:", "The minipool %s is not available for closing.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	selectedMinipools := make([]api.MinipoolCloseDetails, len(selected))
+	for i, index := range selected {
+		selectedMinipools[i] = closableMinipools[index]
 	}
 
 	// Force confirmation of slashable minipools
@@ -212,21 +192,15 @@ func closeMinipools(c *cli.Context) error {
 	}
 
 	// Close minipools
-	for _, minipool := range selectedMinipools {
-
-		response, err := rp.CloseMinipool(minipool.Address)
+	err = runBatch(c, rp, plan, "Closing", func(address common.Address) (common.Hash, error) {
+		response, err := rp.CloseMinipool(address)
 		if err != nil {
-			fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-			continue
-		}
-
-		fmt.Printf("Closing minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not close minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-		} else {
-			fmt.Printf("Successfully closed minipool %s.\n", minipool.Address.Hex())
+			return common.Hash{}, err
 		}
+		return response.TxHash, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Return
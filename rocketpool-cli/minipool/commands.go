@@ -1,8 +1,11 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/gas"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -96,6 +99,120 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "migrate-solo",
+				Aliases:   []string{"ms"},
+				Usage:     "Migrate a solo validator into a minipool, walking through each step and resuming where it left off. Run without a pubkey to show the stage and blockers of every migration in progress.",
+				UsageText: "rocketpool minipool migrate-solo [pubkey] [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "amount, a",
+						Usage: "The amount of ETH to deposit (8 or 16)",
+					},
+					cli.StringFlag{
+						Name:  "max-slippage, s",
+						Usage: "The maximum acceptable slippage in node commission rate for the deposit (or 'auto'). Only relevant when the commission rate is not fixed.",
+					},
+					cli.StringFlag{
+						Name:  "mnemonic, m",
+						Usage: "The mnemonic for your validator's keys, used to change its withdrawal credentials and import its key",
+					},
+					cli.BoolFlag{
+						Name:  "import-key, i",
+						Usage: "Import the validator's key into the Smartnode's Validator Client once its withdrawal credentials have been changed",
+					},
+					cli.BoolFlag{
+						Name:  "no-restart",
+						Usage: "Don't restart the Validator Client after importing the key. Note that the key won't be loaded (and won't attest) until you restart the VC to load it.",
+					},
+					cli.BoolFlag{
+						Name:  "forget",
+						Usage: "Stop tracking the migration of the given validator",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm all interactive questions",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+					if c.NArg() == 1 {
+						if _, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0)); err != nil {
+							return err
+						}
+					}
+
+					// Validate flags
+					if c.String("amount") != "" {
+						if _, err := cliutils.ValidatePositiveEthAmount("deposit amount", c.String("amount")); err != nil {
+							return err
+						}
+					}
+					if c.String("max-slippage") != "" && c.String("max-slippage") != "auto" {
+						if _, err := cliutils.ValidatePercentage("maximum commission rate slippage", c.String("max-slippage")); err != nil {
+							return err
+						}
+					}
+					if c.IsSet("mnemonic") {
+						if _, err := cliutils.ValidateWalletMnemonic("mnemonic", c.String("mnemonic")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return migrateSolo(c)
+
+				},
+			},
+			{
+				Name:      "batch-set-withdrawal-creds",
+				Aliases:   []string{"bswc"},
+				Usage:     "Convert the withdrawal credentials for several migrated solo validators from the old 0x00 value to their minipool addresses, using either their withdrawal mnemonic or a file of offline-signed changes.",
+				UsageText: "rocketpool minipool batch-set-withdrawal-creds [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "indices, i",
+						Usage: "A comma-separated list of the validator indices to change, when signing the changes with a mnemonic",
+					},
+					cli.StringFlag{
+						Name:  "mnemonic, m",
+						Usage: "Use this flag to provide the mnemonic for your withdrawal keys instead of typing it interactively.",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path to a file of signed BLS-to-execution changes, such as one created offline by the staking deposit CLI's `generate-bls-to-execution-change` command",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm submitting the changes",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("file") != "" && (c.String("indices") != "" || c.IsSet("mnemonic")) {
+						return fmt.Errorf("The --file flag can't be used with --indices or --mnemonic.")
+					}
+					if c.String("file") == "" {
+						if _, err := cliutils.ValidateUints("indices", c.String("indices")); err != nil {
+							return fmt.Errorf("%w\nPlease provide the validator indices with --indices, or a file of signed changes with --file.", err)
+						}
+					}
+
+					// Run
+					return batchSetWithdrawalCreds(c)
+
+				},
+			},
 			{
 				Name:      "import-key",
 				Aliases:   []string{"ik"},
@@ -136,12 +253,20 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"p"},
 				Usage:     "Promote a vacant minipool after the scrub check, completing a solo validator migration.",
 				UsageText: "rocketpool minipool promote [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "minipool, m",
 						Usage: "The minipool/s to promote (address or 'all')",
 					},
-				},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
+				}, gas.ScheduleFlags...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -155,6 +280,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return promoteMinipools(c)
@@ -172,6 +302,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to refund from (address or 'all')",
 					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -186,6 +324,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return refundMinipools(c)
@@ -193,6 +336,149 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "plan-bond-reduction",
+				Aliases:   []string{"pbr"},
+				Usage:     "Simulate reducing the bonds of your 16 ETH minipools to 8 ETH, showing the effect on your collateral, commission, income and credit balance",
+				UsageText: "rocketpool minipool plan-bond-reduction [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to plan for (a comma-separated list of addresses, or 'all')",
+					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16' instead of using --minipool",
+					},
+					cli.Float64Flag{
+						Name:  "apr",
+						Usage: "The validator APR (as a percentage) to assume when estimating income",
+						Value: 4,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddresses("minipool addresses", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
+					if c.Float64("apr") <= 0 {
+						return fmt.Errorf("Invalid APR %f; it must be greater than 0.", c.Float64("apr"))
+					}
+
+					// Run
+					return planBondReduction(c)
+
+				},
+			},
+
+			{
+				Name:      "timeline",
+				Aliases:   []string{"tl"},
+				Usage:     "Show the history of a minipool: its contract events, the related node events and its validator's status changes, in order",
+				UsageText: "rocketpool minipool timeline [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to show the timeline of",
+					},
+					cli.Uint64Flag{
+						Name:  "start-block",
+						Usage: "The block to start scanning from (defaults to the block the node registered in)",
+					},
+					cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the timeline as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getTimeline(c)
+
+				},
+			},
+
+			{
+				Name:      "income-statement",
+				Aliases:   []string{"is"},
+				Usage:     "Show what your minipools earned over a time range, split into consensus rewards, execution layer rewards and Smoothing Pool rewards",
+				UsageText: "rocketpool minipool income-statement [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to report on (a comma-separated list of addresses, or 'all')",
+					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=8' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "start",
+						Usage: "The start of the time range as a UTC date (YYYY-MM-DD); defaults to 30 days before the end",
+					},
+					cli.StringFlag{
+						Name:  "end",
+						Usage: "The end of the time range as a UTC date (YYYY-MM-DD); defaults to now",
+					},
+					cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the statement as JSON",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm downloading any missing rewards tree files",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddresses("minipool addresses", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getIncomeStatement(c)
+
+				},
+			},
+
 			{
 				Name:      "begin-bond-reduction",
 				Aliases:   []string{"bbr"},
@@ -203,6 +489,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to begin the bond reduction for (address or 'all')",
 					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -217,6 +511,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return beginReduceBondAmount(c)
@@ -229,12 +528,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"rb"},
 				Usage:     "Manually completes the ETH bond reduction process for a minipool from 16 ETH down to 8 ETH once it is eligible. Please run `begin-bond-reduction` first to start this process.",
 				UsageText: "rocketpool minipool reduce-bond [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "minipool, m",
 						Usage: "The minipool/s to reduce the bond for (address or 'all')",
 					},
-				},
+				}, gas.ScheduleFlags...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -260,12 +559,20 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"d"},
 				Usage:     "Distribute a minipool's ETH balance between your withdrawal address and the rETH holders.",
 				UsageText: "rocketpool minipool distribute-balance [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "minipool, m",
 						Usage: "The minipool/s to distribute the balance of (address or 'all')",
 					},
-				},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
+				}, gas.ScheduleFlags...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -279,6 +586,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return distributeBalance(c)
@@ -333,6 +645,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to exit (address or 'all')",
 					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -347,12 +667,103 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return exitMinipools(c)
 
 				},
 			},
+			{
+				Name:      "presign-exit",
+				Usage:     "Sign voluntary exits for staking minipools without broadcasting them, and save them to an encrypted archive that can be broadcast later",
+				UsageText: "rocketpool minipool presign-exit [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool/s to pre-sign exits for (address or 'all')",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path to save the encrypted exit archive to",
+						Value: "presigned-exits.json",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password to encrypt the archive with",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm pre-signing exits and overwriting the archive",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" && c.String("minipool") != "all" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+					if c.String("password") != "" {
+						if _, err := cliutils.ValidateNodePassword("archive password", c.String("password")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return presignExits(c)
+
+				},
+			},
+			{
+				Name:      "broadcast-exit",
+				Usage:     "Broadcast the voluntary exits in an encrypted archive created by `presign-exit`",
+				UsageText: "rocketpool minipool broadcast-exit --file archive [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The path of the encrypted exit archive",
+					},
+					cli.StringFlag{
+						Name:  "beacon-url, b",
+						Usage: "Broadcast the exits directly through the Beacon Node at this URL, which works on machines without the Smartnode installed",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The archive's password",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm broadcasting the exits",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("file") == "" {
+						return fmt.Errorf("Please specify the exit archive with --file.")
+					}
+
+					// Run
+					return broadcastExits(c)
+
+				},
+			},
 
 			{
 				Name:      "close",
@@ -364,6 +775,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to close (address or 'all')",
 					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
 					cli.BoolFlag{
 						Name:  "confirm-slashing",
 						Usage: "Reserved for acknowledging situations where you've been slashed by the Beacon Chain, and closing a minipool will result in the complete loss of the ETH bond and your RPL collateral. DO NOT use this flag unless you have been explicitly instructed to do so.",
@@ -382,6 +801,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return closeMinipools(c)
@@ -399,6 +823,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "minipool, m",
 						Usage: "The minipool/s to upgrade (address or 'all')",
 					},
+					cli.StringFlag{
+						Name:  "select",
+						Usage: "Select minipools with a filter such as 'status=staking,bond=16,balance>=32.1' instead of using --minipool",
+					},
+					cli.StringFlag{
+						Name:  "plan",
+						Usage: "A file to save the progress of the batch to; if it already exists, the batch it contains is resumed",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -413,6 +845,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.String("select") != "" {
+						if _, err := cliutils.ValidateMinipoolSelector("selector", c.String("select")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return delegateUpgradeMinipools(c)
@@ -494,7 +931,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "prefix, p",
-						Usage: "The prefix of the address to search for (must start with 0x)",
+						Usage: "The prefix of the address to search for (must start with 0x), or a comma-separated list of prefixes to accept any of",
+					},
+					cli.StringFlag{
+						Name:  "suffix",
+						Usage: "The suffix of the address to search for, or a comma-separated list of suffixes to accept any of. If used with --prefix, addresses must match both.",
+					},
+					cli.BoolFlag{
+						Name:  "case-sensitive, c",
+						Usage: "Match the case of the prefixes and suffixes against the checksummed address (much slower to find)",
 					},
 					cli.StringFlag{
 						Name:  "salt, s",
@@ -512,6 +957,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "amount, a",
 						Usage: "The bond amount to be used for the minipool, in ETH (impacts vanity address generation)",
 					},
+					cli.IntFlag{
+						Name:  "count",
+						Usage: "The number of matching addresses to find before stopping",
+						Value: 1,
+					},
+					cli.StringFlag{
+						Name:  "state",
+						Usage: "A file to save the search's progress and results to. If it already exists, the search in it is resumed.",
+					},
+					cli.StringFlag{
+						Name:  "partition",
+						Usage: "Search only part of the salt space, in the form 'index/count' (such as '2/4'), so several machines can split one search",
+					},
+					cli.StringFlag{
+						Name:  "merge",
+						Usage: "Combine the results of a comma-separated list of --state files from a partitioned search instead of searching",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -521,6 +983,20 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
+					if _, err := parseVanityPatterns("prefix", c.String("prefix"), true); err != nil {
+						return err
+					}
+					if _, err := parseVanityPatterns("suffix", c.String("suffix"), false); err != nil {
+						return err
+					}
+					if _, _, err := parseVanityPartition(c.String("partition")); err != nil {
+						return err
+					}
+					if c.String("salt") != "" {
+						if _, err := cliutils.ValidateBigInt("starting salt", c.String("salt")); err != nil {
+							return err
+						}
+					}
 
 					// Run
 					return findVanitySalt(c)
@@ -52,44 +52,26 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []common.Address
-
-	if c.String("minipool") != "" && c.String("minipool") != "all" {
-		selectedAddress := common.HexToAddress(c.String("minipool"))
-		selectedMinipools = []common.Address{selectedAddress}
-	} else {
-		if c.String("minipool") == "" {
-			// Prompt for minipool selection
-			options := make([]string, len(minipools)+1)
-			options[0] = "All available minipools"
-			for mi, minipool := range minipools {
-				options[mi+1] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
-			}
-			selected, _ := cliutils.Select("Please select a minipool to upgrade:", options)
-
-			// Get minipools
-			if selected == 0 {
-				selectedMinipools = make([]common.Address, len(minipools))
-				for mi, minipool := range minipools {
-					selectedMinipools[mi] = minipool.Address
-				}
-			} else {
-				selectedMinipools = []common.Address{minipools[selected-1].Address}
-			}
-		} else {
-			// All minipools
-			selectedMinipools = make([]common.Address, len(minipools))
-			for mi, minipool := range minipools {
-				selectedMinipools[mi] = minipool.Address
-			}
-		}
+	addresses := make([]common.Address, len(minipools))
+	options := make([]string, len(minipools))
+	for mi, minipool := range minipools {
+		addresses[mi] = minipool.Address
+		options[mi] = fmt.Sprintf("%s (using delegate %s)", minipool.Address.Hex(), minipool.Delegate.Hex())
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "delegate-upgrade", addresses, options, "Please select a minipool to upgrade:", "The minipool %s is not eligible for a delegate upgrade.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
 	}
 
 	// Get the total gas limit estimate
 	var totalGas uint64 = 0
 	var totalSafeGas uint64 = 0
 	var gasInfo rocketpoolapi.GasInfo
-	for _, minipool := range selectedMinipools {
+	for _, index := range selected {
+		minipool := addresses[index]
 		canResponse, err := rp.CanDelegateUpgradeMinipool(minipool)
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for upgrade transaction (%s)\n", err)
@@ -111,26 +93,21 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to upgrade %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to upgrade %d minipools?", len(selected)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Upgrade minipools
-	for _, minipool := range selectedMinipools {
-		response, err := rp.DelegateUpgradeMinipool(minipool)
+	err = runBatch(c, rp, plan, "Upgrading", func(address common.Address) (common.Hash, error) {
+		response, err := rp.DelegateUpgradeMinipool(address)
 		if err != nil {
-			fmt.Printf("Could not upgrade minipool %s: %s.\n", minipool.Hex(), err)
-			continue
-		}
-
-		fmt.Printf("Upgrading minipool %s...\n", minipool.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not upgrade minipool %s: %s.\n", minipool.Hex(), err)
-		} else {
-			fmt.Printf("Successfully upgraded minipool %s.\n", minipool.Hex())
+			return common.Hash{}, err
 		}
+		return response.TxHash, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Return
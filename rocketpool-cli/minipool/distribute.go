@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"bytes"
 	"fmt"
 	"math/big"
 
@@ -113,84 +112,54 @@ func distributeBalance(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []api.MinipoolBalanceDistributionDetails
-	if c.String("minipool") == "" {
-
-		// Prompt for minipool selection
-		options := make([]string, len(eligibleMinipools)+1)
-		options[0] = "All available minipools"
-		for mi, minipool := range eligibleMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%.6f ETH available, %.6f ETH goes to you plus a refund of %.6f ETH)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6), math.RoundDown(eth.WeiToEth(minipool.NodeShareOfBalance), 6), math.RoundDown(eth.WeiToEth(minipool.Refund), 6))
-		}
-		selected, _ := cliutils.Select("Please select a minipool to distribute the balance of:", options)
-
-		// Get minipools
-		if selected == 0 {
-			selectedMinipools = eligibleMinipools
-		} else {
-			selectedMinipools = []api.MinipoolBalanceDistributionDetails{eligibleMinipools[selected-1]}
-		}
-
-	} else {
-
-		// Get matching minipools
-		if c.String("minipool") == "all" {
-			selectedMinipools = eligibleMinipools
-		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range eligibleMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolBalanceDistributionDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s is not available for balance distribution.", selectedAddress.Hex())
-			}
-		}
-
+	addresses := make([]common.Address, len(eligibleMinipools))
+	options := make([]string, len(eligibleMinipools))
+	for mi, minipool := range eligibleMinipools {
+		addresses[mi] = minipool.Address
+		options[mi] = fmt.Sprintf("%s (%.6f ETH available, %.6f ETH goes to you plus a refund of %.6f ETH)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Balance), 6), math.RoundDown(eth.WeiToEth(minipool.NodeShareOfBalance), 6), math.RoundDown(eth.WeiToEth(minipool.Refund), 6))
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "distribute-balance", addresses, options, "Please select a minipool to distribute the balance of:", "The minipool %s is not available for balance distribution.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
 	}
 
 	// Get the total gas limit estimate
 	var totalGas uint64 = 0
 	var totalSafeGas uint64 = 0
 	var gasInfo rocketpoolapi.GasInfo
-	for _, minipool := range selectedMinipools {
-		gasInfo = minipool.GasInfo
+	for _, index := range selected {
+		gasInfo = eligibleMinipools[index].GasInfo
 		totalGas += gasInfo.EstGasLimit
 		totalSafeGas += gasInfo.SafeGasLimit
 	}
 	gasInfo.EstGasLimit = totalGas
 	gasInfo.SafeGasLimit = totalSafeGas
 
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	// Assign max fees, or schedule the transactions if requested
+	_, err = gas.AssignMaxFeeOrSchedule(c, gasInfo, rp)
 	if err != nil {
 		return err
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to distribute the ETH balance of %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to distribute the ETH balance of %d minipools?", len(selected)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Distribute minipool balances
-	for _, minipool := range selectedMinipools {
-
-		response, err := rp.DistributeBalance(minipool.Address)
+	err = runBatch(c, rp, plan, "Distributing the balance of", func(address common.Address) (common.Hash, error) {
+		response, err := rp.DistributeBalance(address)
 		if err != nil {
-			fmt.Printf("Could not distribute the ETH balance of minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-			continue
-		}
-
-		fmt.Printf("Distributing balance of minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not distribute the ETH balance of minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-		} else {
-			fmt.Printf("Successfully distributed the ETH balance of minipool %s.\n", minipool.Address.Hex())
+			return common.Hash{}, err
 		}
+		return response.TxHash, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Return
@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -49,42 +48,18 @@ func exitMinipools(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
-
-		// Prompt for minipool selection
-		options := make([]string, len(activeMinipools)+1)
-		options[0] = "All available minipools"
-		for mi, minipool := range activeMinipools {
-			options[mi+1] = fmt.Sprintf("%s (staking since %s)", minipool.Address.Hex(), minipool.Status.StatusTime.Format(TimeFormat))
-		}
-		selected, _ := cliutils.Select("Please select a minipool to exit:", options)
-
-		// Get minipools
-		if selected == 0 {
-			selectedMinipools = activeMinipools
-		} else {
-			selectedMinipools = []api.MinipoolDetails{activeMinipools[selected-1]}
-		}
-
-	} else {
-
-		// Get matching minipools
-		if c.String("minipool") == "all" {
-			selectedMinipools = activeMinipools
-		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range activeMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s is not available for exiting.", selectedAddress.Hex())
-			}
-		}
-
+	addresses := make([]common.Address, len(activeMinipools))
+	options := make([]string, len(activeMinipools))
+	for mi, minipool := range activeMinipools {
+		addresses[mi] = minipool.Address
+		options[mi] = fmt.Sprintf("%s (staking since %s)", minipool.Address.Hex(), minipool.Status.StatusTime.Format(TimeFormat))
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "exit", addresses, options, "Please select a minipool to exit:", "The minipool %s is not available for exiting.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
 	}
 
 	if !status.IsAtlasDeployed {
@@ -95,7 +70,7 @@ func exitMinipools(c *cli.Context) error {
 		fmt.Printf("You will NOT have access to your ETH until after the ETH1-ETH2 merge, when withdrawals are implemented!\n\n%s", colorReset)
 
 		// Prompt for an 'I agree' confirmation
-		if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("%sAre you sure you want to exit %d minipool(s)? This action cannot be undone!%s", colorRed, len(selectedMinipools), colorReset))) {
+		if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("%sAre you sure you want to exit %d minipool(s)? This action cannot be undone!%s", colorRed, len(selected), colorReset))) {
 			fmt.Println("Cancelled.")
 			return nil
 		}
@@ -108,21 +83,21 @@ func exitMinipools(c *cli.Context) error {
 		fmt.Printf("Once your funds have been withdrawn, you can run `rocketpool minipool close` to distribute them to your withdrawal address and close the minipool.\n\n%s", colorReset)
 
 		// Prompt for confirmation
-		if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to exit %d minipool(s)? This action cannot be undone!", len(selectedMinipools)))) {
+		if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to exit %d minipool(s)? This action cannot be undone!", len(selected)))) {
 			fmt.Println("Cancelled.")
 			return nil
 		}
 	}
 
 	// Exit minipools
-	for _, minipool := range selectedMinipools {
-		if _, err := rp.ExitMinipool(minipool.Address); err != nil {
-			fmt.Printf("Could not exit minipool %s: %s.\n", minipool.Address.Hex(), err)
-		} else {
-			fmt.Printf("Successfully exited minipool %s.\n", minipool.Address.Hex())
-			fmt.Println("It may take several hours for your minipool's status to be reflected.")
-		}
+	err = runBatch(c, rp, plan, "Exiting", func(address common.Address) (common.Hash, error) {
+		_, err := rp.ExitMinipool(address)
+		return common.Hash{}, err
+	})
+	if err != nil {
+		return err
 	}
+	fmt.Println("It may take several hours for your minipools' statuses to be reflected.")
 
 	// Return
 	return nil
@@ -0,0 +1,187 @@
+package minipool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Config
+const (
+	incomeDateFormat   string = "2006-01-02"
+	defaultIncomeRange        = 30 * 24 * time.Hour
+)
+
+func getIncomeStatement(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the time range
+	endTime := time.Now()
+	if c.String("end") != "" {
+		endTime, err = time.Parse(incomeDateFormat, c.String("end"))
+		if err != nil {
+			return fmt.Errorf("Invalid end date '%s': %w", c.String("end"), err)
+		}
+	}
+	startTime := endTime.Add(-defaultIncomeRange)
+	if c.String("start") != "" {
+		startTime, err = time.Parse(incomeDateFormat, c.String("start"))
+		if err != nil {
+			return fmt.Errorf("Invalid start date '%s': %w", c.String("start"), err)
+		}
+	}
+
+	// Get the minipools to report on
+	var addresses []common.Address
+	if c.String("select") != "" {
+		response, err := rp.SelectMinipools(c.String("select"))
+		if err != nil {
+			return err
+		}
+		if len(response.Minipools) == 0 {
+			fmt.Printf("No minipools match '%s'.\n", c.String("select"))
+			return nil
+		}
+		addresses = response.Minipools
+	} else if c.String("minipool") != "" && c.String("minipool") != "all" {
+		addresses, err = cliutils.ValidateAddresses("minipool addresses", c.String("minipool"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Get the statement
+	if !c.Bool("json") {
+		fmt.Println("Scanning the chain for the minipools' balances and transactions, this may take a while...")
+	}
+	statement, err := rp.GetMinipoolIncomeStatement(addresses, startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	// Download any missing rewards tree files and try again
+	if len(statement.MissingIntervals) > 0 && !c.Bool("json") {
+		fmt.Printf("You are missing the rewards tree files for intervals %v, so their Smoothing Pool rewards can't be included.\n", statement.MissingIntervals)
+		if c.Bool("yes") || cliutils.Confirm("Would you like to download them now?") {
+			for _, interval := range statement.MissingIntervals {
+				fmt.Printf("Downloading interval %d file... ", interval)
+				_, err := rp.DownloadRewardsFile(interval)
+				if err != nil {
+					return fmt.Errorf("error downloading rewards file for interval %d: %w", interval, err)
+				}
+				fmt.Println("done!")
+			}
+			statement, err = rp.GetMinipoolIncomeStatement(addresses, startTime, endTime)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Export it as JSON
+	if c.Bool("json") {
+		bytes, err := json.MarshalIndent(statement, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+	if len(statement.Minipools) == 0 {
+		fmt.Println("The node doesn't have any minipools.")
+		return nil
+	}
+
+	// Print each minipool's statement
+	fmt.Printf("\nEarnings from %s to %s (blocks %d to %d):\n\n", statement.StartTime.Format(TimeFormat), statement.EndTime.Format(TimeFormat), statement.StartBlock, statement.EndBlock)
+	totalConsensus := big.NewInt(0)
+	totalExecution := big.NewInt(0)
+	totalSmoothingPool := big.NewInt(0)
+	totalPenalties := big.NewInt(0)
+	total := big.NewInt(0)
+	isEstimated := false
+	for _, mp := range statement.Minipools {
+		fmt.Printf("%sMinipool %s%s (%.2f ETH bond, %.2f%% commission)\n", colorGreen, mp.Address.Hex(), colorReset, eth.WeiToEth(mp.NodeBond), mp.NodeFee*100)
+		if !mp.Active {
+			fmt.Println("\tThe validator wasn't active during this period.")
+		} else {
+			if mp.StartTime.After(statement.StartTime) {
+				fmt.Printf("\tActive from %s.\n", mp.StartTime.Format(TimeFormat))
+			}
+			fmt.Printf("\tConsensus rewards:        %s (beacon balance %s -> %s, %s withdrawn to the minipool)\n", formatIncomeAmount(mp.ConsensusRewards), formatIncomeAmount(mp.StartBeaconBalance), formatIncomeAmount(mp.EndBeaconBalance), formatIncomeAmount(mp.Withdrawals))
+			fmt.Printf("\t  Your share:             %s (including %s commission)\n", formatIncomeAmount(mp.ConsensusNodeShare), formatIncomeAmount(mp.ConsensusCommission))
+			if mp.Penalties.Sign() > 0 {
+				fmt.Printf("\t  %sPenalties:              %s, taken from your bond%s\n", colorRed, formatIncomeAmount(mp.Penalties), colorReset)
+			}
+			fmt.Printf("\tExecution layer rewards:  %s (estimated from your fee distributor's income)\n", formatIncomeAmount(mp.ExecutionRewards))
+			fmt.Printf("\t  Your share:             %s (including %s commission)\n", formatIncomeAmount(mp.ExecutionNodeShare), formatIncomeAmount(mp.ExecutionCommission))
+			fmt.Printf("\tDistributed:              %s sent from the minipool\n", formatIncomeAmount(mp.Distributed))
+		}
+		if mp.SmoothingPoolNodeShare.Sign() > 0 {
+			if mp.SmoothingPoolEstimated {
+				fmt.Printf("\tSmoothing Pool share:     %s (estimated)\n", formatIncomeAmount(mp.SmoothingPoolNodeShare))
+				isEstimated = true
+			} else {
+				fmt.Printf("\tSmoothing Pool share:     %s\n", formatIncomeAmount(mp.SmoothingPoolNodeShare))
+			}
+		}
+		fmt.Printf("\tTotal for you:            %s\n\n", formatIncomeAmount(mp.TotalNodeShare))
+
+		totalConsensus.Add(totalConsensus, mp.ConsensusNodeShare)
+		totalExecution.Add(totalExecution, mp.ExecutionNodeShare)
+		totalSmoothingPool.Add(totalSmoothingPool, mp.SmoothingPoolNodeShare)
+		totalPenalties.Add(totalPenalties, mp.Penalties)
+		total.Add(total, mp.TotalNodeShare)
+	}
+
+	// Print the totals
+	fmt.Printf("%s=== Total ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Consensus rewards:        %s\n", formatIncomeAmount(totalConsensus))
+	fmt.Printf("Execution layer rewards:  %s\n", formatIncomeAmount(totalExecution))
+	fmt.Printf("Smoothing Pool rewards:   %s\n", formatIncomeAmount(totalSmoothingPool))
+	if totalPenalties.Sign() > 0 {
+		fmt.Printf("%sPenalties:                %s (already included above)%s\n", colorRed, formatIncomeAmount(totalPenalties), colorReset)
+	}
+	fmt.Printf("Your total earnings:      %s\n\n", formatIncomeAmount(total))
+
+	// Print the caveats
+	fmt.Println("NOTE:")
+	fmt.Println("\t- Your shares are net of the pool stakers' portion, using each minipool's current bond and commission.")
+	fmt.Printf("\t- Execution layer rewards are what your fee distributor (%s) received, split between your minipools by how long each was active.\n", statement.FeeDistributorAddress.Hex())
+	if len(statement.SmoothingPoolIntervals) > 0 {
+		fmt.Printf("\t- Smoothing Pool rewards come from rewards intervals %v.", statement.SmoothingPoolIntervals)
+		if isEstimated {
+			fmt.Print(" Some were split between your minipools by active time or prorated to this period, so they're estimates.")
+		}
+		fmt.Println()
+	}
+	fmt.Println("\t- Smoothing Pool rewards for the current interval aren't known until it ends.")
+	return nil
+
+}
+
+// Format a wei amount as ETH
+func formatIncomeAmount(amount *big.Int) string {
+	return fmt.Sprintf("%.6f ETH", math.RoundDown(eth.WeiToEth(amount), 6))
+}
@@ -0,0 +1,370 @@
+package minipool
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/migrations"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/migration"
+)
+
+// Descriptions of each migration stage
+var soloMigrationStageDescriptions = map[migrations.SoloMigrationStage]string{
+	migrations.SoloMigrationStage_CreateMinipool:        "Create the vacant minipool",
+	migrations.SoloMigrationStage_ChangeWithdrawalCreds: "Change the validator's withdrawal credentials to the minipool address",
+	migrations.SoloMigrationStage_ImportKey:             "Import the validator key into the Smartnode's Validator Client",
+	migrations.SoloMigrationStage_ScrubCheck:            "Wait for the Oracle DAO's scrub check",
+	migrations.SoloMigrationStage_Promote:               "Promote the minipool",
+	migrations.SoloMigrationStage_Complete:              "Complete",
+	migrations.SoloMigrationStage_Failed:                "Failed",
+}
+
+func migrateSolo(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check for Atlas
+	atlasResponse, err := rp.IsAtlasDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if Atlas has been deployed: %w", err)
+	}
+	if !atlasResponse.IsAtlasDeployed {
+		fmt.Println("You cannot migrate a solo validator until Atlas has been deployed.")
+		return nil
+	}
+
+	// Print the status of every migration if no validator was given
+	if c.NArg() == 0 {
+		response, err := rp.GetSoloMigrations()
+		if err != nil {
+			return err
+		}
+		if len(response.Migrations) == 0 {
+			fmt.Println("The node is not migrating any solo validators.\nYou can start a migration with `rocketpool minipool migrate-solo <pubkey>`.")
+			return nil
+		}
+		for _, details := range response.Migrations {
+			printSoloMigration(details)
+		}
+		return nil
+	}
+	pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	// Stop tracking the migration if requested
+	if c.Bool("forget") {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to stop tracking the migration of validator %s? This won't undo any of the steps that have already been completed.", pubkey.Hex()))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		if _, err := rp.ForgetSoloMigration(pubkey); err != nil {
+			return err
+		}
+		fmt.Printf("The node is no longer tracking the migration of validator %s.\n", pubkey.Hex())
+		return nil
+	}
+
+	// Start the migration if it isn't already in progress
+	details, err := getSoloMigration(rp, pubkey)
+	if err != nil {
+		return err
+	}
+	if details == nil || details.Stage.IsFinished() {
+		fmt.Printf("You are about to convert the solo staker %s into a Rocket Pool minipool.\n\nPlease thoroughly read our documentation at https://docs.rocketpool.net/guides/atlas/solo-staker-migration.html to learn about the process and its implications.\n\n", pubkey.Hex())
+		importKey := c.Bool("import-key")
+		if !importKey && !c.Bool("yes") {
+			fmt.Printf("You have the option of importing your validator's private key into the Smartnode's Validator Client instead of running your own Validator Client separately.\n\n")
+			importKey = cliutils.Confirm("Would you like to import your validator's key into the Smartnode once its withdrawal credentials have been changed?")
+		}
+		if _, err := rp.StartSoloMigration(pubkey, importKey); err != nil {
+			return err
+		}
+		fmt.Printf("The migration has been started. You can run this command again at any time to resume it.\n\n")
+	}
+
+	// Walk through the stages until one needs to wait for the network
+	mnemonic := c.String("mnemonic")
+	var lastStage migrations.SoloMigrationStage
+	for {
+		details, err := getSoloMigration(rp, pubkey)
+		if err != nil {
+			return err
+		}
+		if details == nil {
+			return fmt.Errorf("validator %s is not being migrated", pubkey.Hex())
+		}
+		printSoloMigration(*details)
+		if details.Stage == lastStage {
+			fmt.Println("The migration did not move to the next stage; please run this command again later to resume it.")
+			return nil
+		}
+		lastStage = details.Stage
+
+		switch details.Stage {
+		case migrations.SoloMigrationStage_CreateMinipool:
+			if len(details.Blockers) > 0 {
+				fmt.Println("The minipool can't be created until the problems above have been resolved.")
+				return nil
+			}
+			success, err := createSoloMigrationMinipool(c, rp, pubkey)
+			if err != nil || !success {
+				return err
+			}
+
+		case migrations.SoloMigrationStage_ChangeWithdrawalCreds:
+			if mnemonic == "" && !c.Bool("yes") {
+				fmt.Printf("The Smartnode can change your validator's withdrawal credentials for you using the mnemonic for its withdrawal key. If you'd rather do it yourself, use a tool such as `ethdo` or the staking deposit CLI and submit the signed change with `rocketpool minipool batch-set-withdrawal-creds --file`.\n\n")
+				if cliutils.Confirm("Would you like the Smartnode to change your withdrawal credentials now?") {
+					mnemonic = wallet.PromptMnemonic()
+				}
+			}
+			if mnemonic == "" {
+				fmt.Printf("Please change your validator's withdrawal credentials to the minipool address %s before %s, or the minipool will be scrubbed.\nRun this command again once the change has been processed.\n", details.MinipoolAddress.Hex(), details.CredsDeadline.Format(time.RFC1123))
+				return nil
+			}
+			if !migration.ChangeWithdrawalCreds(rp, details.MinipoolAddress, mnemonic) {
+				fmt.Println("Your withdrawal credentials cannot be automatically changed at this time.\nYou can try again later by running this command again.")
+				return nil
+			}
+			fmt.Println("The Beacon Chain will process the change within the next few epochs. Run this command again once it has been processed to continue the migration.")
+			return nil
+
+		case migrations.SoloMigrationStage_ImportKey:
+			if !migration.ImportKey(c, rp, details.MinipoolAddress, mnemonic) {
+				fmt.Println("Importing the key failed. You can try again later by running this command again.")
+				return nil
+			}
+
+		case migrations.SoloMigrationStage_ScrubCheck:
+			fmt.Printf("The minipool is in the scrub check and can be promoted after %s (%s from now).\nYour node will promote it automatically once the scrub check has passed, or you can run this command again then.\n", details.PromotionTime.Format(time.RFC1123), time.Until(details.PromotionTime).Round(time.Second))
+			return nil
+
+		case migrations.SoloMigrationStage_Promote:
+			success, err := promoteSoloMigrationMinipool(c, rp, *details)
+			if err != nil || !success {
+				return err
+			}
+
+		case migrations.SoloMigrationStage_Complete:
+			fmt.Printf("Your validator has been migrated into minipool %s.\nYou can stop tracking the migration with `rocketpool minipool migrate-solo %s --forget`.\n", details.MinipoolAddress.Hex(), pubkey.Hex())
+			return nil
+
+		case migrations.SoloMigrationStage_Failed:
+			fmt.Printf("%sThe migration has failed.%s\nYou can stop tracking it with `rocketpool minipool migrate-solo %s --forget`.\n", colorRed, colorReset, pubkey.Hex())
+			return nil
+		}
+		fmt.Println()
+	}
+
+}
+
+// Get the details of a validator's migration, or nil if it isn't being migrated
+func getSoloMigration(rp *rocketpool.Client, pubkey types.ValidatorPubkey) (*api.SoloMigrationDetails, error) {
+	response, err := rp.GetSoloMigrations()
+	if err != nil {
+		return nil, err
+	}
+	for _, details := range response.Migrations {
+		if details.Pubkey == pubkey {
+			return &details, nil
+		}
+	}
+	return nil, nil
+}
+
+// Print the details of a migration
+func printSoloMigration(details api.SoloMigrationDetails) {
+	fmt.Printf("--------------------\n\n")
+	fmt.Printf("Validator:        %s\n", details.Pubkey.Hex())
+	if details.ValidatorStatus != "" {
+		fmt.Printf("Validator index:  %d (%s)\n", details.ValidatorIndex, details.ValidatorStatus)
+	}
+	if details.MinipoolAddress != (common.Address{}) {
+		fmt.Printf("Minipool:         %s\n", details.MinipoolAddress.Hex())
+	}
+	fmt.Printf("Stage:            %s (since %s)\n", soloMigrationStageDescriptions[details.Stage], details.StageTime.Format(time.RFC1123))
+	if details.ImportKey {
+		fmt.Printf("Import key:       yes (imported: %t)\n", details.KeyImported)
+	} else {
+		fmt.Println("Import key:       no")
+	}
+	if details.Stage == migrations.SoloMigrationStage_ChangeWithdrawalCreds && !details.CredsDeadline.IsZero() {
+		fmt.Printf("Creds deadline:   %s\n", details.CredsDeadline.Format(time.RFC1123))
+	}
+	if !details.PromotionTime.IsZero() && !details.Stage.IsFinished() {
+		fmt.Printf("Promotable after: %s\n", details.PromotionTime.Format(time.RFC1123))
+	}
+	for _, blocker := range details.Blockers {
+		fmt.Printf("%sBlocker:          %s%s\n", colorYellow, blocker, colorReset)
+	}
+	fmt.Println()
+}
+
+// Create the vacant minipool for a migration
+func createSoloMigrationMinipool(c *cli.Context, rp *rocketpool.Client, pubkey types.ValidatorPubkey) (bool, error) {
+
+	// Check if the fee distributor has been initialized
+	isInitializedResponse, err := rp.IsFeeDistributorInitialized()
+	if err != nil {
+		return false, err
+	}
+	if !isInitializedResponse.IsInitialized {
+		fmt.Println("Your fee distributor has not been initialized yet so you cannot create a new minipool.\nPlease run `rocketpool node initialize-fee-distributor` to initialize it first.")
+		return false, nil
+	}
+
+	// Get deposit amount
+	var amount float64
+	if c.String("amount") != "" {
+		amount, err = strconv.ParseFloat(c.String("amount"), 64)
+		if err != nil {
+			return false, fmt.Errorf("Invalid deposit amount '%s': %w", c.String("amount"), err)
+		}
+	} else {
+		selected, _ := cliutils.Select("Please choose an amount of ETH you want to use as your deposit for the new minipool (this will become your share of the balance, and the remainder will become the pool stakers' share):", []string{"8 ETH", "16 ETH"})
+		switch selected {
+		case 0:
+			amount = 8
+		case 1:
+			amount = 16
+		}
+	}
+	amountWei := eth.EthToWei(amount)
+
+	// Get minimum node fee
+	nodeFees, err := rp.NodeFee()
+	if err != nil {
+		return false, err
+	}
+	maxNodeFeeSlippage := node.DefaultMaxNodeFeeSlippage
+	if c.String("max-slippage") != "" && c.String("max-slippage") != "auto" {
+		maxNodeFeeSlippagePerc, err := strconv.ParseFloat(c.String("max-slippage"), 64)
+		if err != nil {
+			return false, fmt.Errorf("Invalid maximum commission rate slippage '%s': %w", c.String("max-slippage"), err)
+		}
+		maxNodeFeeSlippage = maxNodeFeeSlippagePerc / 100
+	}
+	minNodeFee := nodeFees.NodeFee - maxNodeFeeSlippage
+	if minNodeFee < nodeFees.MinNodeFee {
+		minNodeFee = nodeFees.MinNodeFee
+	}
+
+	// Get a random minipool salt
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return false, fmt.Errorf("Error generating random salt: %w", err)
+	}
+	salt := big.NewInt(0).SetBytes(buffer)
+
+	// Check deposit can be made
+	canDeposit, err := rp.CanCreateVacantMinipool(amountWei, minNodeFee, salt, pubkey)
+	if err != nil {
+		return false, err
+	}
+	if !canDeposit.CanDeposit {
+		fmt.Println("Cannot create a vacant minipool for migration:")
+		if canDeposit.InsufficientRplStake {
+			fmt.Printf("The node has not staked enough RPL to collateralize a new minipool with a bond of %d ETH.\n", int(amount))
+		}
+		if canDeposit.InvalidAmount {
+			fmt.Println("The deposit amount is invalid.")
+		}
+		if canDeposit.DepositDisabled {
+			fmt.Println("Vacant minipool deposits are currently disabled.")
+		}
+		return false, nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canDeposit.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sNOTE: by creating a new minipool, your node will automatically claim and distribute any balance you have in your fee distributor contract.%s\nYou are about to create a new, vacant minipool with a %d ETH bond and a minimum possible commission rate of %f%%. Are you sure you want to do this?", colorYellow, colorReset, int(amount), minNodeFee*100))) {
+		fmt.Println("Cancelled.")
+		return false, nil
+	}
+
+	// Make deposit
+	response, err := rp.CreateVacantMinipool(amountWei, minNodeFee, salt, pubkey)
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf("Creating minipool...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return false, err
+	}
+	fmt.Printf("Your new minipool's address is %s. It will be in the scrub check for %s.\n", response.MinipoolAddress.Hex(), response.ScrubPeriod)
+	return true, nil
+
+}
+
+// Promote the minipool for a migration
+func promoteSoloMigrationMinipool(c *cli.Context, rp *rocketpool.Client, details api.SoloMigrationDetails) (bool, error) {
+
+	// Check the minipool can be promoted
+	canPromote, err := rp.CanPromoteMinipool(details.MinipoolAddress)
+	if err != nil {
+		return false, err
+	}
+	if !canPromote.CanPromote {
+		fmt.Println("The minipool cannot be promoted yet.")
+		return false, nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canPromote.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The scrub check has passed. Are you sure you want to promote minipool %s?", details.MinipoolAddress.Hex()))) {
+		fmt.Println("Cancelled.")
+		return false, nil
+	}
+
+	// Promote the minipool
+	response, err := rp.PromoteMinipool(details.MinipoolAddress)
+	if err != nil {
+		return false, err
+	}
+	fmt.Printf("Promoting minipool %s...\n", details.MinipoolAddress.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return false, err
+	}
+	fmt.Printf("Successfully promoted minipool %s.\n", details.MinipoolAddress.Hex())
+	return true, nil
+
+}
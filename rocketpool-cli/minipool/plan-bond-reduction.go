@@ -0,0 +1,181 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func planBondReduction(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the minipools to plan for
+	var addresses []common.Address
+	if c.String("select") != "" {
+		response, err := rp.SelectMinipools(c.String("select"))
+		if err != nil {
+			return err
+		}
+		if len(response.Minipools) == 0 {
+			fmt.Printf("No minipools match '%s'.\n", c.String("select"))
+			return nil
+		}
+		addresses = response.Minipools
+	} else if c.String("minipool") != "" && c.String("minipool") != "all" {
+		addresses, err = cliutils.ValidateAddresses("minipool addresses", c.String("minipool"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Run the simulation
+	plan, err := rp.PlanBondReduction(addresses)
+	if err != nil {
+		return err
+	}
+	if len(plan.Minipools) == 0 {
+		fmt.Println("The node doesn't have any 16 ETH minipools to reduce the bonds of.")
+		return nil
+	}
+	if plan.BondReductionDisabled {
+		fmt.Printf("%sNOTE: Bond reductions are currently disabled by the protocol, so none of these minipools can be reduced right now.%s\n\n", colorYellow, colorReset)
+	}
+	apr := c.Float64("apr") / 100
+	newBondEth := eth.WeiToEth(plan.NewBond)
+
+	// Sort the minipools
+	included := make([]api.BondReductionPlanMinipool, 0, len(plan.Minipools))
+	excluded := []api.BondReductionPlanMinipool{}
+	ineligible := []api.BondReductionPlanMinipool{}
+	for _, mp := range plan.Minipools {
+		if mp.Included {
+			included = append(included, mp)
+		} else if mp.ExcludedByCollateral {
+			excluded = append(excluded, mp)
+		} else {
+			ineligible = append(ineligible, mp)
+		}
+	}
+	sort.Slice(included, func(i int, j int) bool {
+		return included[i].Order < included[j].Order
+	})
+
+	// Print the recommended order
+	fmt.Printf("%s=== Minipools ===%s\n", colorGreen, colorReset)
+	if len(included) > 0 {
+		fmt.Println("These minipools can be reduced, in the recommended order:")
+		for _, mp := range included {
+			fmt.Printf("\t%d. %s: commission %.2f%% -> %.2f%%, %+.4f ETH per year\n", mp.Order, mp.Address.Hex(), mp.CurrentFee*100, mp.NewFee*100, getMinipoolIncome(newBondEth, mp.NewFee, apr)-getMinipoolIncome(eth.WeiToEth(mp.CurrentBond), mp.CurrentFee, apr))
+		}
+		fmt.Println()
+	}
+	if len(excluded) > 0 {
+		fmt.Printf("%sThese minipools could be reduced, but your RPL stake can't cover the extra ETH they would borrow after the ones above:\n", colorYellow)
+		for _, mp := range excluded {
+			fmt.Printf("\t%s: commission %.2f%% -> %.2f%%\n", mp.Address.Hex(), mp.CurrentFee*100, mp.NewFee*100)
+		}
+		fmt.Printf("Stake more RPL with `rocketpool node stake-rpl` to reduce them as well.%s\n\n", colorReset)
+	}
+	if len(ineligible) > 0 {
+		fmt.Println("These minipools can't be reduced right now:")
+		for _, mp := range ineligible {
+			if len(mp.Reasons) == 0 {
+				fmt.Printf("\t%s\n", mp.Address.Hex())
+			} else {
+				fmt.Printf("\t%s: %s\n", mp.Address.Hex(), strings.Join(mp.Reasons, "; "))
+			}
+		}
+		fmt.Println()
+	}
+	if len(included) == 0 {
+		fmt.Println("None of the selected minipools can have their bonds reduced at this time.")
+		return nil
+	}
+
+	// Print the collateral changes
+	remainingMatch := big.NewInt(0).Sub(plan.EthMatchedLimit, plan.EthMatched)
+	remainingMatch.Sub(remainingMatch, plan.PendingMatchAmount)
+	fmt.Printf("%s=== Collateral ===%s\n", colorGreen, colorReset)
+	fmt.Printf("RPL staked:            %.6f RPL (%.6f ETH at %.6f ETH per RPL)\n", math.RoundDown(eth.WeiToEth(plan.RplStake), 6), math.RoundDown(eth.WeiToEth(plan.RplStake)*eth.WeiToEth(plan.RplPrice), 6), math.RoundDown(eth.WeiToEth(plan.RplPrice), 6))
+	fmt.Printf("Borrowed ETH:          %.6f ETH -> %.6f ETH\n", math.RoundDown(eth.WeiToEth(plan.CurrentBorrowedEth), 6), math.RoundDown(eth.WeiToEth(plan.NewBorrowedEth), 6))
+	fmt.Printf("Bonded ETH:            %.6f ETH -> %.6f ETH\n", math.RoundDown(eth.WeiToEth(plan.CurrentBondedEth), 6), math.RoundDown(eth.WeiToEth(plan.NewBondedEth), 6))
+	fmt.Printf("Collateral ratio:      %.2f%% -> %.2f%% of borrowed ETH\n", plan.CurrentCollateralRatio*100, plan.NewCollateralRatio*100)
+	fmt.Printf("Effective RPL stake:   %.6f RPL -> %.6f RPL\n", math.RoundDown(eth.WeiToEth(plan.CurrentEffectiveStake), 6), math.RoundDown(eth.WeiToEth(plan.NewEffectiveStake), 6))
+	fmt.Printf("ETH left to borrow:    %.6f ETH before these reductions\n", math.RoundDown(eth.WeiToEth(remainingMatch), 6))
+	if plan.NewEffectiveStake.Cmp(plan.CurrentEffectiveStake) < 0 {
+		fmt.Printf("%sNOTE: Your effective RPL stake will go down, because the maximum effective stake depends on the ETH you have bonded.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Print the income changes
+	currentIncome := float64(0)
+	newIncome := float64(0)
+	for _, mp := range included {
+		currentIncome += getMinipoolIncome(eth.WeiToEth(mp.CurrentBond), mp.CurrentFee, apr)
+		newIncome += getMinipoolIncome(newBondEth, mp.NewFee, apr)
+	}
+	freedEth := big.NewInt(0).Sub(plan.NewCreditBalance, plan.CurrentCreditBalance)
+	fmt.Printf("%s=== ETH ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Validator income:      %.6f ETH -> %.6f ETH per year from these minipools, assuming a %.2f%% APR\n", currentIncome, newIncome, apr*100)
+	fmt.Printf("Node credit balance:   %.6f ETH -> %.6f ETH (+%.6f ETH)\n", math.RoundDown(eth.WeiToEth(plan.CurrentCreditBalance), 6), math.RoundDown(eth.WeiToEth(plan.NewCreditBalance), 6), math.RoundDown(eth.WeiToEth(freedEth), 6))
+	fmt.Printf("The freed ETH becomes credit you can use to create new minipools with `rocketpool node deposit`, which would earn %.6f ETH per year each at the network's current %.2f%% commission.\n\n", getMinipoolIncome(newBondEth, plan.NetworkNodeFee, apr), plan.NetworkNodeFee*100)
+
+	// Print the timing
+	now := time.Now()
+	fmt.Printf("%s=== Timing ===%s\n", colorGreen, colorReset)
+	fmt.Printf("After you begin a bond reduction, the Oracle DAO has %s to check it. It can then be completed during the following %s.\n", plan.WindowStart, plan.WindowLength)
+	fmt.Printf("If you begin now, the reductions can be completed between %s and %s.\n", now.Add(plan.WindowStart).Format(TimeFormat), now.Add(plan.WindowStart+plan.WindowLength).Format(TimeFormat))
+	fmt.Printf("Your node daemon completes them automatically during that window unless you've disabled it, in which case you must run `rocketpool minipool reduce-bond`.\n\n")
+
+	// Print the gas cost
+	var totalGas uint64
+	for _, mp := range included {
+		totalGas += mp.GasInfo.EstGasLimit
+	}
+	fmt.Printf("%s=== Gas ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Beginning the %d reductions will use about %d gas.\n", len(included), totalGas)
+	gasPrices, err := rp.GetFeeHistoryGasPrices()
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't get the current gas price (%s).%s\n", colorYellow, err.Error(), colorReset)
+	} else if gasPrices.Suggestion.FastWei != nil {
+		cost := big.NewInt(0).Mul(gasPrices.Suggestion.FastWei, big.NewInt(0).SetUint64(totalGas))
+		fmt.Printf("At the current fast gas price of %.2f gwei, that costs about %.6f ETH.\n", eth.WeiToGwei(gasPrices.Suggestion.FastWei), math.RoundDown(eth.WeiToEth(cost), 6))
+	}
+	fmt.Printf("Completing each reduction takes another transaction, which can't be estimated until the reduction has begun.\n\n")
+
+	// Print the next step
+	fmt.Println("To begin these reductions in the recommended order, run:")
+	for _, mp := range included {
+		fmt.Printf("\trocketpool minipool begin-bond-reduction --minipool %s\n", mp.Address.Hex())
+	}
+	return nil
+
+}
+
+// Get the node's yearly ETH income from a minipool's validator rewards
+func getMinipoolIncome(bond float64, fee float64, apr float64) float64 {
+	return apr * (bond + (32-bond)*fee)
+}
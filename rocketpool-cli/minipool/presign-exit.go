@@ -0,0 +1,177 @@
+package minipool
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/exits"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func presignExits(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Check the output file
+	outputPath, err := homedir.Expand(c.String("file"))
+	if err != nil {
+		return fmt.Errorf("error expanding file path: %w", err)
+	}
+	if _, err := os.Stat(outputPath); err == nil {
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%s already exists. Do you want to overwrite it?", outputPath))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Get minipool statuses
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	// Get staking minipools with validators on the Beacon Chain
+	stakingMinipools := []api.MinipoolDetails{}
+	for _, minipool := range status.Minipools {
+		if minipool.Status.Status == types.Staking && minipool.Validator.Exists {
+			stakingMinipools = append(stakingMinipools, minipool)
+		}
+	}
+
+	// Check for staking minipools
+	if len(stakingMinipools) == 0 {
+		fmt.Println("No minipools have validators that can be exited.")
+		return nil
+	}
+
+	// Get selected minipools
+	var selectedMinipools []api.MinipoolDetails
+	if c.String("minipool") == "" {
+
+		// Prompt for minipool selection
+		options := make([]string, len(stakingMinipools)+1)
+		options[0] = "All available minipools"
+		for mi, minipool := range stakingMinipools {
+			options[mi+1] = fmt.Sprintf("%s (validator %d)", minipool.Address.Hex(), minipool.Validator.Index)
+		}
+		selected, _ := cliutils.Select("Please select a minipool to pre-sign an exit for:", options)
+
+		// Get minipools
+		if selected == 0 {
+			selectedMinipools = stakingMinipools
+		} else {
+			selectedMinipools = []api.MinipoolDetails{stakingMinipools[selected-1]}
+		}
+
+	} else {
+
+		// Get matching minipools
+		if c.String("minipool") == "all" {
+			selectedMinipools = stakingMinipools
+		} else {
+			selectedAddress := common.HexToAddress(c.String("minipool"))
+			for _, minipool := range stakingMinipools {
+				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
+					selectedMinipools = []api.MinipoolDetails{minipool}
+					break
+				}
+			}
+			if selectedMinipools == nil {
+				return fmt.Errorf("The minipool %s is not available for exiting.", selectedAddress.Hex())
+			}
+		}
+
+	}
+
+	// Get the archive password
+	password := c.String("password")
+	if password == "" {
+		password = promptArchivePassword()
+	}
+
+	// Show a warning message
+	fmt.Printf("%sNOTE:\n", colorYellow)
+	fmt.Println("Anyone with this archive and its password will be able to exit your validators at any time; exits can't be cancelled once they've been broadcast.")
+	fmt.Println("Store the archive and its password separately, and only give them to someone you trust to act on your behalf.")
+	fmt.Printf("The archive won't contain your validator keys, so it can't be used to sign anything else.%s\n\n", colorReset)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to pre-sign exits for %d minipool(s)?", len(selectedMinipools)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign the exits
+	presignedExits := []exits.PresignedExit{}
+	for _, minipool := range selectedMinipools {
+		response, err := rp.PresignExit(minipool.Address)
+		if err != nil {
+			return fmt.Errorf("error pre-signing the exit for minipool %s: %w", minipool.Address.Hex(), err)
+		}
+		presignedExits = append(presignedExits, exits.PresignedExit{
+			MinipoolAddress: minipool.Address,
+			ValidatorPubkey: response.ValidatorPubkey,
+			ValidatorIndex:  response.ValidatorIndex,
+			Epoch:           response.Epoch,
+			Signature:       response.Signature,
+		})
+		fmt.Printf("Pre-signed the exit for minipool %s (validator %d).\n", minipool.Address.Hex(), response.ValidatorIndex)
+	}
+
+	// Save the archive
+	archive, err := exits.NewArchive(presignedExits, cfg.Smartnode.Network.Value.(cfgtypes.Network), password)
+	if err != nil {
+		return err
+	}
+	if err := archive.Save(outputPath); err != nil {
+		return err
+	}
+	fmt.Printf("\nSaved %d encrypted exit(s) to %s.\n", len(presignedExits), outputPath)
+	fmt.Println("They can be broadcast later from any Smartnode with a synced Beacon Node using `rocketpool minipool broadcast-exit --file <archive>`.")
+	return nil
+
+}
+
+// Prompt for a new archive password
+func promptArchivePassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the exit archive with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
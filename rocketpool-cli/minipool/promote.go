@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -50,50 +49,26 @@ func promoteMinipools(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
-
-		// Prompt for minipool selection
-		options := make([]string, len(promotableMinipools)+1)
-		options[0] = "All available minipools"
-		for mi, minipool := range promotableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%s until dissolved)", minipool.Address.Hex(), minipool.TimeUntilDissolve)
-		}
-		selected, _ := cliutils.Select("Please select a minipool to promote:", options)
-
-		// Get minipools
-		if selected == 0 {
-			selectedMinipools = promotableMinipools
-		} else {
-			selectedMinipools = []api.MinipoolDetails{promotableMinipools[selected-1]}
-		}
-
-	} else {
-
-		// Get matching minipools
-		if c.String("minipool") == "all" {
-			selectedMinipools = promotableMinipools
-		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range promotableMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s is not available to promote.", selectedAddress.Hex())
-			}
-		}
-
+	addresses := make([]common.Address, len(promotableMinipools))
+	options := make([]string, len(promotableMinipools))
+	for mi, minipool := range promotableMinipools {
+		addresses[mi] = minipool.Address
+		options[mi] = fmt.Sprintf("%s (%s until dissolved)", minipool.Address.Hex(), minipool.TimeUntilDissolve)
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "promote", addresses, options, "Please select a minipool to promote:", "The minipool %s is not available to promote.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
 	}
 
 	// Get the total gas limit estimate
 	var totalGas uint64 = 0
 	var totalSafeGas uint64 = 0
 	var gasInfo rocketpoolapi.GasInfo
-	for _, minipool := range selectedMinipools {
-		canResponse, err := rp.CanPromoteMinipool(minipool.Address)
+	for _, index := range selected {
+		canResponse, err := rp.CanPromoteMinipool(addresses[index])
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for promote transaction (%s)", err)
 			break
@@ -106,33 +81,28 @@ func promoteMinipools(c *cli.Context) error {
 	gasInfo.EstGasLimit = totalGas
 	gasInfo.SafeGasLimit = totalSafeGas
 
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	// Assign max fees, or schedule the transactions if requested
+	_, err = gas.AssignMaxFeeOrSchedule(c, gasInfo, rp)
 	if err != nil {
 		return err
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to promote %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to promote %d minipools?", len(selected)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Promote minipools
-	for _, minipool := range selectedMinipools {
-		response, err := rp.PromoteMinipool(minipool.Address)
+	err = runBatch(c, rp, plan, "Promoting", func(address common.Address) (common.Hash, error) {
+		response, err := rp.PromoteMinipool(address)
 		if err != nil {
-			fmt.Printf("Could not promote minipool %s: %s.\n", minipool.Address.Hex(), err)
-			continue
-		}
-
-		fmt.Printf("Promoting minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not promote minipool %s: %s.\n", minipool.Address.Hex(), err)
-		} else {
-			fmt.Printf("Successfully promoted minipool %s.\n", minipool.Address.Hex())
+			return common.Hash{}, err
 		}
+		return response.TxHash, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Return
@@ -110,42 +110,18 @@ func beginReduceBondAmount(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
-
-		// Prompt for minipool selection
-		options := make([]string, len(reduceableMinipools)+1)
-		options[0] = "All available minipools"
-		for mi, minipool := range reduceableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (Current bond: %d ETH, commission: %.2f%%)", minipool.Address.Hex(), int(eth.WeiToEth(minipool.Node.DepositBalance)), minipool.Node.Fee*100)
-		}
-		selected, _ := cliutils.Select("Please select a minipool to begin the ETH bond reduction for:", options)
-
-		// Get minipools
-		if selected == 0 {
-			selectedMinipools = reduceableMinipools
-		} else {
-			selectedMinipools = []api.MinipoolDetails{reduceableMinipools[selected-1]}
-		}
-
-	} else {
-
-		// Get matching minipools
-		if c.String("minipool") == "all" {
-			selectedMinipools = reduceableMinipools
-		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range reduceableMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s cannot have its bond reduced.", selectedAddress.Hex())
-			}
-		}
-
+	addresses := make([]common.Address, len(reduceableMinipools))
+	options := make([]string, len(reduceableMinipools))
+	for mi, minipool := range reduceableMinipools {
+		addresses[mi] = minipool.Address
+		options[mi] = fmt.Sprintf("%s (Current bond: %d ETH, commission: %.2f%%)", minipool.Address.Hex(), int(eth.WeiToEth(minipool.Node.DepositBalance)), minipool.Node.Fee*100)
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "begin-bond-reduction", addresses, options, "Please select a minipool to begin the ETH bond reduction for:", "The minipool %s cannot have its bond reduced.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
 	}
 
 	// Get the total gas limit estimate
@@ -153,7 +129,8 @@ func beginReduceBondAmount(c *cli.Context) error {
 	var totalSafeGas uint64 = 0
 	var gasInfo rocketpoolapi.GasInfo
 	totalMatchRequest := big.NewInt(0)
-	for _, minipool := range selectedMinipools {
+	for _, index := range selected {
+		minipool := reduceableMinipools[index]
 		canResponse, err := rp.CanBeginReduceBondAmount(minipool.Address, newBondAmount)
 		if err != nil {
 			return fmt.Errorf("couldn't check if minipool %s could have its bond reduced: %s)", minipool.Address.Hex(), err.Error())
@@ -202,26 +179,21 @@ func beginReduceBondAmount(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to begin bond reduction for %d minipools from 16 ETH to 8 ETH?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to begin bond reduction for %d minipools from 16 ETH to 8 ETH?", len(selected)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Begin bond reduction
-	for _, minipool := range selectedMinipools {
-		response, err := rp.BeginReduceBondAmount(minipool.Address, newBondAmount)
+	err = runBatch(c, rp, plan, "Beginning the bond reduction for", func(address common.Address) (common.Hash, error) {
+		response, err := rp.BeginReduceBondAmount(address, newBondAmount)
 		if err != nil {
-			fmt.Printf("Could not begin bond reduction for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-			continue
-		}
-
-		fmt.Printf("Beginning bond reduction for minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not begin bond reduction for minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-		} else {
-			fmt.Printf("Successfully started bond reduction for minipool %s.\n", minipool.Address.Hex())
+			return common.Hash{}, err
 		}
+		return response.TxHash, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Return
@@ -338,8 +310,8 @@ func reduceBondAmount(c *cli.Context) error {
 	gasInfo.EstGasLimit = totalGas
 	gasInfo.SafeGasLimit = totalSafeGas
 
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	// Assign max fees, or schedule the transactions if requested
+	scheduled, err := gas.AssignMaxFeeOrSchedule(c, gasInfo, rp)
 	if err != nil {
 		return err
 	}
@@ -358,6 +330,11 @@ func reduceBondAmount(c *cli.Context) error {
 			continue
 		}
 
+		if scheduled {
+			fmt.Printf("Scheduled the bond reduction of minipool %s.\n", minipool.Address.Hex())
+			continue
+		}
+
 		fmt.Printf("Reducing bond for minipool %s...\n", minipool.Address.Hex())
 		cliutils.PrintTransactionHash(rp, response.TxHash)
 		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
@@ -366,6 +343,9 @@ func reduceBondAmount(c *cli.Context) error {
 			fmt.Printf("Successfully reduced bond for minipool %s.\n", minipool.Address.Hex())
 		}
 	}
+	if scheduled {
+		gas.PrintScheduledTransaction()
+	}
 
 	// Return
 	return nil
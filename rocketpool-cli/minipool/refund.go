@@ -1,7 +1,6 @@
 package minipool
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -52,50 +51,26 @@ func refundMinipools(c *cli.Context) error {
 	}
 
 	// Get selected minipools
-	var selectedMinipools []api.MinipoolDetails
-	if c.String("minipool") == "" {
-
-		// Prompt for minipool selection
-		options := make([]string, len(refundableMinipools)+1)
-		options[0] = "All available minipools"
-		for mi, minipool := range refundableMinipools {
-			options[mi+1] = fmt.Sprintf("%s (%.6f ETH to claim)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
-		}
-		selected, _ := cliutils.Select("Please select a minipool to refund ETH from:", options)
-
-		// Get minipools
-		if selected == 0 {
-			selectedMinipools = refundableMinipools
-		} else {
-			selectedMinipools = []api.MinipoolDetails{refundableMinipools[selected-1]}
-		}
-
-	} else {
-
-		// Get matching minipools
-		if c.String("minipool") == "all" {
-			selectedMinipools = refundableMinipools
-		} else {
-			selectedAddress := common.HexToAddress(c.String("minipool"))
-			for _, minipool := range refundableMinipools {
-				if bytes.Equal(minipool.Address.Bytes(), selectedAddress.Bytes()) {
-					selectedMinipools = []api.MinipoolDetails{minipool}
-					break
-				}
-			}
-			if selectedMinipools == nil {
-				return fmt.Errorf("The minipool %s is not available for refund.", selectedAddress.Hex())
-			}
-		}
-
+	addresses := make([]common.Address, len(refundableMinipools))
+	options := make([]string, len(refundableMinipools))
+	for mi, minipool := range refundableMinipools {
+		addresses[mi] = minipool.Address
+		options[mi] = fmt.Sprintf("%s (%.6f ETH to claim)", minipool.Address.Hex(), math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
+	}
+	selected, plan, err := selectBatchMinipools(c, rp, "refund", addresses, options, "Please select a minipool to refund ETH from:", "The minipool %s is not available for refund.")
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return nil
 	}
 
 	// Get the total gas limit estimate
 	var totalGas uint64 = 0
 	var totalSafeGas uint64 = 0
 	var gasInfo rocketpoolapi.GasInfo
-	for _, minipool := range selectedMinipools {
-		canResponse, err := rp.CanRefundMinipool(minipool.Address)
+	for _, index := range selected {
+		canResponse, err := rp.CanRefundMinipool(addresses[index])
 		if err != nil {
 			fmt.Printf("WARNING: Couldn't get gas price for refund transaction (%s)", err.Error())
 			break
@@ -115,26 +90,21 @@ func refundMinipools(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to refund %d minipools?", len(selectedMinipools)))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to refund %d minipools?", len(selected)))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
 	// Refund minipools
-	for _, minipool := range selectedMinipools {
-		response, err := rp.RefundMinipool(minipool.Address)
+	err = runBatch(c, rp, plan, "Refunding", func(address common.Address) (common.Hash, error) {
+		response, err := rp.RefundMinipool(address)
 		if err != nil {
-			fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-			continue
-		}
-
-		fmt.Printf("Refunding minipool %s...\n", minipool.Address.Hex())
-		cliutils.PrintTransactionHash(rp, response.TxHash)
-		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
-			fmt.Printf("Could not refund ETH from minipool %s: %s.\n", minipool.Address.Hex(), err.Error())
-		} else {
-			fmt.Printf("Successfully refunded ETH from minipool %s.\n", minipool.Address.Hex())
+			return common.Hash{}, err
 		}
+		return response.TxHash, nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Return
@@ -0,0 +1,121 @@
+package minipool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getTimeline(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the minipool
+	var minipoolAddress common.Address
+	if c.String("minipool") != "" {
+		minipoolAddress = common.HexToAddress(c.String("minipool"))
+	} else {
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return err
+		}
+		if len(status.Minipools) == 0 {
+			fmt.Println("The node doesn't have any minipools.")
+			return nil
+		}
+		options := make([]string, len(status.Minipools))
+		for i, mp := range status.Minipools {
+			options[i] = fmt.Sprintf("%s (%s)", mp.Address.Hex(), mp.Status.Status.String())
+		}
+		selected, _ := cliutils.Select("Please select a minipool to show the timeline of:", options)
+		minipoolAddress = status.Minipools[selected].Address
+	}
+
+	// Get the timeline
+	if !c.Bool("json") {
+		fmt.Println("Scanning the chain for the minipool's events, this may take a while...")
+	}
+	response, err := rp.GetMinipoolTimeline(minipoolAddress, c.Uint64("start-block"))
+	if err != nil {
+		return err
+	}
+
+	// Export it as JSON
+	if c.Bool("json") {
+		bytes, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bytes))
+		return nil
+	}
+
+	// Print the timeline
+	fmt.Printf("\n%sTimeline for minipool %s%s\n", colorGreen, response.MinipoolAddress.Hex(), colorReset)
+	fmt.Printf("Validator pubkey: %s\n", response.Pubkey.Hex())
+	fmt.Printf("Scanned blocks %d to %d.\n\n", response.StartBlock, response.EndBlock)
+	if len(response.Events) == 0 {
+		fmt.Println("No events were found for this minipool.")
+		return nil
+	}
+	for _, event := range response.Events {
+		if event.Source == "beacon" {
+			fmt.Printf("%s  %s[%s]%s %s (epoch %d)\n", event.Time.Format(TimeFormat), colorBlue, event.Contract, colorReset, event.Event, event.Epoch)
+		} else {
+			fmt.Printf("%s  %s[%s]%s %s (block %d, tx %s)\n", event.Time.Format(TimeFormat), colorBlue, event.Contract, colorReset, event.Event, event.BlockNumber, event.TxHash.Hex())
+		}
+		printTimelineValues(event)
+	}
+	fmt.Println()
+	fmt.Println("Use --json to export the full timeline.")
+	return nil
+
+}
+
+// Print the values of a timeline event, showing amounts in ETH and statuses by name
+func printTimelineValues(event api.MinipoolTimelineEvent) {
+	names := make([]string, 0, len(event.Values))
+	for name := range event.Values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := event.Values[name]
+		lowerName := strings.ToLower(name)
+		if strings.Contains(lowerName, "amount") || strings.Contains(lowerName, "balance") || strings.Contains(lowerName, "value") {
+			if wei, ok := big.NewInt(0).SetString(value, 10); ok {
+				value = fmt.Sprintf("%.6f ETH", math.RoundDown(eth.WeiToEth(wei), 6))
+			}
+		} else if event.Event == "StatusUpdated" && name == "status" {
+			if status, err := strconv.ParseUint(value, 10, 8); err == nil {
+				value = types.MinipoolStatus(status).String()
+			}
+		}
+		fmt.Printf("\t%s: %s\n", name, value)
+	}
+}
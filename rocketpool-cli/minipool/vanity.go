@@ -1,11 +1,24 @@
 package minipool
 
 import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/fs"
+	"math"
 	"math/big"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -18,8 +31,60 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
+// Config
+const (
+	vanitySearchVersion  int         = 1
+	vanitySearchFileMode fs.FileMode = 0600
+	vanityChunkSize      uint64      = 1 << 18
+	vanityReportInterval             = 5 * time.Second
+)
+
+var vanityPatternRegex = regexp.MustCompile("^[0-9a-fA-F]+$")
+var vanityMaxSalt = big.NewInt(0).Lsh(big.NewInt(1), 256)
+
+// A salt that produces a matching minipool address
+type vanityMatch struct {
+	Salt    *big.Int       `json:"salt"`
+	Address common.Address `json:"address"`
+}
+
+// The state of a vanity address search, saved so it can be resumed or merged with the searches of other machines.
+// Every salt from StartSalt up to (but not including) NextSalt has been checked.
+type vanitySearch struct {
+	Version                int            `json:"version"`
+	NodeAddress            common.Address `json:"nodeAddress"`
+	MinipoolFactoryAddress common.Address `json:"minipoolFactoryAddress"`
+	InitHash               common.Hash    `json:"initHash"`
+	Amount                 *big.Int       `json:"amount"`
+	Prefixes               []string       `json:"prefixes"`
+	Suffixes               []string       `json:"suffixes"`
+	CaseSensitive          bool           `json:"caseSensitive"`
+	Partition              uint64         `json:"partition"`
+	Partitions             uint64         `json:"partitions"`
+	StartSalt              *big.Int       `json:"startSalt"`
+	EndSalt                *big.Int       `json:"endSalt"`
+	NextSalt               *big.Int       `json:"nextSalt"`
+	Elapsed                time.Duration  `json:"elapsed"`
+	Matches                []vanityMatch  `json:"matches"`
+	path                   string
+}
+
+// Matches hex-encoded addresses against a search's prefixes and suffixes
+type vanityMatcher struct {
+	prefixes      [][]byte
+	suffixes      [][]byte
+	casedPrefixes [][]byte
+	casedSuffixes [][]byte
+	caseSensitive bool
+}
+
 func findVanitySalt(c *cli.Context) error {
 
+	// Merge the results of other searches
+	if c.String("merge") != "" {
+		return mergeVanitySearches(strings.Split(c.String("merge"), ","))
+	}
+
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c)
 	if err != nil {
@@ -33,29 +98,47 @@ func findVanitySalt(c *cli.Context) error {
 		return err
 	}
 
-	// Get the target prefix
-	prefix := c.String("prefix")
-	if prefix == "" {
-		prefix = cliutils.Prompt("Please specify the address prefix you would like to search for (must start with 0x):", "^0x[0-9a-fA-F]+$", "Invalid hex string")
-	}
-	if !strings.HasPrefix(prefix, "0x") {
-		return fmt.Errorf("Prefix must start with 0x.")
-	}
-	targetPrefix, success := big.NewInt(0).SetString(prefix, 0)
-	if !success {
-		return fmt.Errorf("Invalid prefix: %s", prefix)
+	// Resume the saved search if there is one
+	var search *vanitySearch
+	statePath := c.String("state")
+	if statePath != "" {
+		search, err = loadVanitySearch(statePath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
+	if search != nil {
+		for _, flag := range []string{"prefix", "suffix", "case-sensitive", "salt", "partition", "node-address", "amount"} {
+			if c.IsSet(flag) {
+				return fmt.Errorf("%s already holds a search, so --%s can't be used. Resume it without the search flags, or use a different --state file to start a new search.", statePath, flag)
+			}
+		}
 
-	// Get the starting salt
-	saltString := c.String("salt")
-	var salt *big.Int
-	if saltString == "" {
-		salt = big.NewInt(0)
+		// Make sure the minipool contracts haven't changed since the search started
+		vanityArtifacts, err := rp.GetVanityArtifacts(search.Amount, search.NodeAddress.Hex())
+		if err != nil {
+			return err
+		}
+		if vanityArtifacts.MinipoolFactoryAddress != search.MinipoolFactoryAddress || vanityArtifacts.InitHash != search.InitHash {
+			return fmt.Errorf("The minipool contracts have changed since the search in %s started, so its salts are no longer valid. Please start a new search.", statePath)
+		}
+		fmt.Printf("Resuming the search in %s from salt 0x%x (%s salts already checked in %s).\n", statePath, search.NextSalt, humanize.BigComma(big.NewInt(0).Sub(search.NextSalt, search.StartSalt)), search.Elapsed.Round(time.Second))
 	} else {
-		salt, success = big.NewInt(0).SetString(saltString, 0)
-		if !success {
-			return fmt.Errorf("Invalid starting salt: %s", salt)
+		search, err = newVanitySearch(c, rp)
+		if err != nil {
+			return err
 		}
+		search.path = statePath
+		if search.path == "" {
+			fmt.Println("NOTE: This search won't be saved. Use --state to save its progress so it can be resumed if it's interrupted.")
+		} else if err := search.save(); err != nil {
+			return err
+		}
+	}
+	if search.NextSalt.Cmp(search.EndSalt) >= 0 {
+		fmt.Println("This search has already checked every salt in its range.")
+		printVanityMatches(search.Matches)
+		return nil
 	}
 
 	// Get the core count
@@ -68,6 +151,95 @@ func findVanitySalt(c *cli.Context) error {
 		threads = runtime.GOMAXPROCS(0)
 	}
 
+	// Get the number of matches to find
+	count := c.Int("count")
+	if count < 1 {
+		count = 1
+	}
+
+	// Print the search details
+	probability := search.getMatchProbability()
+	if len(search.Prefixes) > 0 {
+		fmt.Printf("Prefixes: 0x%s\n", strings.Join(search.Prefixes, ", 0x"))
+	}
+	if len(search.Suffixes) > 0 {
+		fmt.Printf("Suffixes: %s\n", strings.Join(search.Suffixes, ", "))
+	}
+	if search.CaseSensitive {
+		fmt.Println("Matching the checksummed (mixed-case) address.")
+	}
+	if search.Partitions > 1 {
+		fmt.Printf("Searching partition %d of %d (salts 0x%x to 0x%x).\n", search.Partition, search.Partitions, search.StartSalt, big.NewInt(0).Sub(search.EndSalt, big.NewInt(1)))
+	}
+	fmt.Printf("Each salt has a 1 in %s chance of matching.\n", formatVanityNumber(1/probability))
+	fmt.Printf("Running with %d threads.\n", threads)
+
+	// Run the search
+	start := time.Now()
+	newMatches, err := search.run(threads, count, probability)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Finished in %s\n", time.Since(start))
+	if newMatches == 0 && search.NextSalt.Cmp(search.EndSalt) >= 0 {
+		fmt.Println("Checked every salt in the search range without finding a match.")
+	}
+	if search.path != "" && search.NextSalt.Cmp(search.EndSalt) < 0 {
+		fmt.Printf("Run the same command again to resume the search from salt 0x%x.\n", search.NextSalt)
+	}
+	printVanityMatches(search.Matches)
+
+	// Return
+	return nil
+
+}
+
+// Create a new search from the command's flags
+func newVanitySearch(c *cli.Context, rp *rocketpool.Client) (*vanitySearch, error) {
+
+	// Get the target patterns
+	prefixes, err := parseVanityPatterns("prefix", c.String("prefix"), true)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, err := parseVanityPatterns("suffix", c.String("suffix"), false)
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixes) == 0 && len(suffixes) == 0 {
+		prefix := cliutils.Prompt("Please specify the address prefix you would like to search for (must start with 0x):", "^0x[0-9a-fA-F]+$", "Invalid hex string")
+		prefixes = []string{strings.TrimPrefix(prefix, "0x")}
+	}
+	for _, prefix := range prefixes {
+		for _, suffix := range suffixes {
+			if len(prefix)+len(suffix) > common.AddressLength*2 {
+				return nil, fmt.Errorf("The prefix 0x%s and suffix %s are too long to both fit in an address.", prefix, suffix)
+			}
+		}
+	}
+
+	// Get the salt range
+	partition, partitions, err := parseVanityPartition(c.String("partition"))
+	if err != nil {
+		return nil, err
+	}
+	partitionSize := big.NewInt(0).Div(vanityMaxSalt, big.NewInt(0).SetUint64(partitions))
+	startSalt := big.NewInt(0).Mul(partitionSize, big.NewInt(0).SetUint64(partition-1))
+	endSalt := big.NewInt(0).Add(startSalt, partitionSize)
+	if partition == partitions {
+		endSalt.Set(vanityMaxSalt)
+	}
+	if c.String("salt") != "" {
+		salt, err := cliutils.ValidateBigInt("starting salt", c.String("salt"))
+		if err != nil {
+			return nil, err
+		}
+		if salt.Cmp(startSalt) < 0 || salt.Cmp(endSalt) >= 0 {
+			return nil, fmt.Errorf("The starting salt 0x%x is outside of partition %d/%d (0x%x to 0x%x).", salt, partition, partitions, startSalt, big.NewInt(0).Sub(endSalt, big.NewInt(1)))
+		}
+		startSalt = salt
+	}
+
 	// Get the node address
 	nodeAddressStr := c.String("node-address")
 	if nodeAddressStr == "" {
@@ -79,20 +251,20 @@ func findVanitySalt(c *cli.Context) error {
 	if c.String("amount") != "" {
 		// Parse amount
 		if amount, err = cliutils.ValidatePositiveEthAmount("deposit", c.String("amount")); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		// Check if Atlas is deployed
 		atlasResponse, err := rp.IsAtlasDeployed()
 		if err != nil {
-			return fmt.Errorf("error checking if Atlas has been deployed: %w", err)
+			return nil, fmt.Errorf("error checking if Atlas has been deployed: %w", err)
 		}
 
 		if !atlasResponse.IsAtlasDeployed {
 			// Get node status
 			status, err := rp.NodeStatus()
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			// Get deposit amount options
@@ -136,120 +308,476 @@ func findVanitySalt(c *cli.Context) error {
 	// Get the vanity generation artifacts
 	vanityArtifacts, err := rp.GetVanityArtifacts(amountWei, nodeAddressStr)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Set up some variables
-	nodeAddress := vanityArtifacts.NodeAddress.Bytes()
-	minipoolFactoryAddress := vanityArtifacts.MinipoolFactoryAddress
-	initHash := vanityArtifacts.InitHash.Bytes()
-	shiftAmount := uint(42 - len(prefix))
+	return &vanitySearch{
+		Version:                vanitySearchVersion,
+		NodeAddress:            vanityArtifacts.NodeAddress,
+		MinipoolFactoryAddress: vanityArtifacts.MinipoolFactoryAddress,
+		InitHash:               vanityArtifacts.InitHash,
+		Amount:                 amountWei,
+		Prefixes:               prefixes,
+		Suffixes:               suffixes,
+		CaseSensitive:          c.Bool("case-sensitive"),
+		Partition:              partition,
+		Partitions:             partitions,
+		StartSalt:              startSalt,
+		EndSalt:                endSalt,
+		NextSalt:               big.NewInt(0).Set(startSalt),
+		Matches:                []vanityMatch{},
+	}, nil
 
-	// Run the search
-	fmt.Printf("Running with %d threads.\n", threads)
+}
+
+// Run the search until the given number of new matches are found, the salt range is exhausted, or the user interrupts it.
+// Returns the number of new matches.
+func (s *vanitySearch) run(threads int, count int, probability float64) (int, error) {
 
+	matcher := s.getMatcher()
+	nodeAddress := s.NodeAddress.Bytes()
+	initHash := s.InitHash.Bytes()
+	baseSalt := big.NewInt(0).Set(s.NextSalt)
+	previousElapsed := s.Elapsed
+
+	// Stop cleanly on Ctrl+C so the progress can be saved
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	// Spawn worker threads; each one takes the next chunk of salts until the search stops
+	var stop atomic.Bool
+	var nextChunk atomic.Uint64
+	var checked atomic.Uint64
+	completedChan := make(chan uint64, threads)
+	foundChan := make(chan vanityMatch, threads)
 	wg := new(sync.WaitGroup)
 	wg.Add(threads)
-	stop := false
-	stopPtr := &stop
+	for i := 0; i < threads; i++ {
+		go func() {
+			runWorker(&stop, &nextChunk, &checked, matcher, nodeAddress, s.MinipoolFactoryAddress, initHash, baseSalt, s.EndSalt, completedChan, foundChan)
+			wg.Done()
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
 
-	// Spawn worker threads
+	// Track the chunks that are done; the saved progress only moves past a chunk once every chunk before it is done too
+	completedChunks := map[uint64]bool{}
+	watermark := uint64(0)
+	newMatches := 0
+	var saveErr error
 	start := time.Now()
-	for i := 0; i < threads; i++ {
-		saltOffset := big.NewInt(int64(i))
-		workerSalt := big.NewInt(0).Add(salt, saltOffset)
-
-		go func(i int) {
-			foundSalt, foundAddress := runWorker(i == 0, stopPtr, targetPrefix, nodeAddress, minipoolFactoryAddress, initHash, workerSalt, int64(threads), shiftAmount)
-			if foundSalt != nil {
-				fmt.Printf("Found on thread %d: salt 0x%x = %s\n", i, foundSalt, foundAddress.Hex())
-				*stopPtr = true
+	updateProgress := func() {
+		nextSalt := big.NewInt(0).SetUint64(watermark)
+		nextSalt.Mul(nextSalt, big.NewInt(0).SetUint64(vanityChunkSize))
+		nextSalt.Add(nextSalt, baseSalt)
+		if nextSalt.Cmp(s.EndSalt) > 0 {
+			nextSalt.Set(s.EndSalt)
+		}
+		s.NextSalt = nextSalt
+		s.Elapsed = previousElapsed + time.Since(start)
+		if err := s.save(); err != nil && saveErr == nil {
+			saveErr = err
+			stop.Store(true)
+		}
+	}
+	handleCompleted := func(chunk uint64) {
+		completedChunks[chunk] = true
+		for completedChunks[watermark] {
+			delete(completedChunks, watermark)
+			watermark++
+		}
+	}
+	handleFound := func(match vanityMatch) {
+		for _, existing := range s.Matches {
+			if existing.Salt.Cmp(match.Salt) == 0 {
+				return
 			}
-			wg.Done()
-		}(i)
+		}
+		fmt.Printf("%sFound salt 0x%x = %s%s\n", colorGreen, match.Salt, match.Address.Hex(), colorReset)
+		s.Matches = append(s.Matches, match)
+		newMatches++
+		if newMatches >= count {
+			stop.Store(true)
+		}
+		updateProgress()
 	}
 
-	// Wait for the workers to finish and print the elapsed time
-	wg.Wait()
-	end := time.Now()
-	elapsed := end.Sub(start)
-	fmt.Printf("Finished in %s\n", elapsed)
+	// Report progress until the workers are done
+	ticker := time.NewTicker(vanityReportInterval)
+	defer ticker.Stop()
+	lastChecked := uint64(0)
+	lastTime := start
+	running := true
+	for running {
+		select {
+		case chunk := <-completedChan:
+			handleCompleted(chunk)
+		case match := <-foundChan:
+			handleFound(match)
+		case <-ticker.C:
+			updateProgress()
+			total := checked.Load()
+			now := time.Now()
+			rate := float64(total-lastChecked) / now.Sub(lastTime).Seconds()
+			lastChecked = total
+			lastTime = now
+			fmt.Printf("At salt 0x%x... %s (%s salts/sec)", s.NextSalt, time.Since(start).Round(time.Second), formatVanityNumber(rate))
+			if rate > 0 && newMatches < count {
+				fmt.Printf(", expected time to the next match: %s (50%% chance within %s)", formatVanityDuration(1/(probability*rate)), formatVanityDuration(math.Ln2/(probability*rate)))
+			}
+			fmt.Println()
+		case <-interrupt:
+			fmt.Println("Stopping the search...")
+			stop.Store(true)
+		case <-done:
+			running = false
+		}
+	}
 
-	// Return
-	return nil
+	// Handle anything the workers sent before stopping
+	for draining := true; draining; {
+		select {
+		case chunk := <-completedChan:
+			handleCompleted(chunk)
+		case match := <-foundChan:
+			handleFound(match)
+		default:
+			draining = false
+		}
+	}
+	updateProgress()
+	if saveErr != nil {
+		return newMatches, saveErr
+	}
+	return newMatches, nil
 
 }
 
-func runWorker(report bool, stop *bool, targetPrefix *big.Int, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, salt *big.Int, increment int64, shiftAmount uint) (*big.Int, common.Address) {
+func runWorker(stop *atomic.Bool, nextChunk *atomic.Uint64, checked *atomic.Uint64, matcher *vanityMatcher, nodeAddress []byte, minipoolManagerAddress common.Address, initHash []byte, baseSalt *big.Int, endSalt *big.Int, completed chan<- uint64, found chan<- vanityMatch) {
 	saltBytes := [32]byte{}
-	hashInt := big.NewInt(0)
-	incrementInt := big.NewInt(increment)
+	hexAddress := [common.AddressLength * 2]byte{}
+	one := big.NewInt(1)
+	chunkSize := big.NewInt(0).SetUint64(vanityChunkSize)
 	hasher := crypto.NewKeccakState()
 	nodeSalt := common.Hash{}
 	addressResult := common.Hash{}
 
-	// Set up the reporting ticker if requested
-	var ticker *time.Ticker
-	var tickerChan chan struct{}
-	lastSalt := big.NewInt(0).Set(salt)
-	if report {
-		start := time.Now()
-		reportInterval := 5 * time.Second
-		ticker = time.NewTicker(reportInterval)
-		tickerChan = make(chan struct{})
-		go func() {
-			for {
-				select {
-				case <-ticker.C:
-					delta := big.NewInt(0).Sub(salt, lastSalt)
-					deltaFloat, suffix := humanize.ComputeSI(float64(delta.Uint64()) / 5.0)
-					deltaString := humanize.FtoaWithDigits(deltaFloat, 2) + suffix
-					fmt.Printf("At salt 0x%x... %s (%s salts/sec)\n", salt, time.Since(start), deltaString)
-					lastSalt.Set(salt)
-				case <-tickerChan:
-					ticker.Stop()
-					return
+	for {
+		// Get the next chunk of salts
+		chunk := nextChunk.Add(1) - 1
+		salt := big.NewInt(0).SetUint64(chunk)
+		salt.Mul(salt, chunkSize)
+		salt.Add(salt, baseSalt)
+		if salt.Cmp(endSalt) >= 0 {
+			return
+		}
+		chunkEnd := big.NewInt(0).Add(salt, chunkSize)
+		if chunkEnd.Cmp(endSalt) > 0 {
+			chunkEnd.Set(endSalt)
+		}
+
+		// Run the main salt finder loop
+		var count uint64
+		for salt.Cmp(chunkEnd) < 0 {
+			if stop.Load() {
+				checked.Add(count)
+				return
+			}
+
+			// Some speed optimizations -
+			// This block is the fast way to do `nodeSalt := crypto.Keccak256Hash(nodeAddress, saltBytes)`
+			salt.FillBytes(saltBytes[:])
+			hasher.Write(nodeAddress)
+			hasher.Write(saltBytes[:])
+			hasher.Read(nodeSalt[:])
+			hasher.Reset()
+
+			// This block is the fast way to do `crypto.CreateAddress2(minipoolManagerAddress, nodeSalt, initHash)`
+			// except instead of capturing the returned value as an address, we keep it as bytes. The first 12 bytes
+			// are ignored, since they are not part of the resulting address.
+			//
+			// Because we didn't call CreateAddress2 here, we have to call common.BytesToAddress below, but we can
+			// postpone that until we find the correct salt.
+			hasher.Write([]byte{0xff})
+			hasher.Write(minipoolManagerAddress.Bytes())
+			hasher.Write(nodeSalt[:])
+			hasher.Write(initHash)
+			hasher.Read(addressResult[:])
+			hasher.Reset()
+
+			hex.Encode(hexAddress[:], addressResult[12:])
+			if matcher.isMatch(hexAddress[:], addressResult[12:]) {
+				found <- vanityMatch{
+					Salt:    big.NewInt(0).Set(salt),
+					Address: common.BytesToAddress(addressResult[12:]),
 				}
 			}
-		}()
+			salt.Add(salt, one)
+			count++
+		}
+		checked.Add(count)
+		completed <- chunk
 	}
+}
 
-	// Run the main salt finder loop
-	for {
-		if *stop {
-			return nil, common.Address{}
-		}
-
-		// Some speed optimizations -
-		// This block is the fast way to do `nodeSalt := crypto.Keccak256Hash(nodeAddress, saltBytes)`
-		salt.FillBytes(saltBytes[:])
-		hasher.Write(nodeAddress)
-		hasher.Write(saltBytes[:])
-		hasher.Read(nodeSalt[:])
-		hasher.Reset()
-
-		// This block is the fast way to do `crypto.CreateAddress2(minipoolManagerAddress, nodeSalt, initHash)`
-		// except instead of capturing the returned value as an address, we keep it as bytes. The first 12 bytes
-		// are ignored, since they are not part of the resulting address.
-		//
-		// Because we didn't call CreateAddress2 here, we have to call common.BytesToAddress below, but we can
-		// postpone that until we find the correct salt.
-		hasher.Write([]byte{0xff})
-		hasher.Write(minipoolManagerAddress.Bytes())
-		hasher.Write(nodeSalt[:])
-		hasher.Write(initHash)
-		hasher.Read(addressResult[:])
-		hasher.Reset()
-
-		hashInt.SetBytes(addressResult[12:])
-		hashInt.Rsh(hashInt, shiftAmount*4)
-		if hashInt.Cmp(targetPrefix) == 0 {
-			if report {
-				close(tickerChan)
+// Get a matcher for the search's patterns
+func (s *vanitySearch) getMatcher() *vanityMatcher {
+	matcher := &vanityMatcher{
+		caseSensitive: s.CaseSensitive,
+	}
+	for _, prefix := range s.Prefixes {
+		matcher.prefixes = append(matcher.prefixes, []byte(strings.ToLower(prefix)))
+		matcher.casedPrefixes = append(matcher.casedPrefixes, []byte(prefix))
+	}
+	for _, suffix := range s.Suffixes {
+		matcher.suffixes = append(matcher.suffixes, []byte(strings.ToLower(suffix)))
+		matcher.casedSuffixes = append(matcher.casedSuffixes, []byte(suffix))
+	}
+	return matcher
+}
+
+// Check if an address matches any of the prefixes and any of the suffixes.
+// The lowercase hex is checked first, since computing the checksum is much slower.
+func (m *vanityMatcher) isMatch(hexAddress []byte, address []byte) bool {
+	if !matchVanityPatterns(hexAddress, m.prefixes, m.suffixes) {
+		return false
+	}
+	if !m.caseSensitive {
+		return true
+	}
+	checksummed := []byte(common.BytesToAddress(address).Hex()[2:])
+	return matchVanityPatterns(checksummed, m.casedPrefixes, m.casedSuffixes)
+}
+
+func matchVanityPatterns(hexAddress []byte, prefixes [][]byte, suffixes [][]byte) bool {
+	if len(prefixes) > 0 {
+		isMatch := false
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(hexAddress, prefix) {
+				isMatch = true
+				break
 			}
-			address := common.BytesToAddress(addressResult[12:])
-			return salt, address
 		}
-		salt.Add(salt, incrementInt)
+		if !isMatch {
+			return false
+		}
+	}
+	for _, suffix := range suffixes {
+		if bytes.HasSuffix(hexAddress, suffix) {
+			return true
+		}
+	}
+	return len(suffixes) == 0
+}
+
+// Get the chance of a single salt producing a matching address
+func (s *vanitySearch) getMatchProbability() float64 {
+	probability := float64(1)
+	for _, patterns := range [][]string{s.Prefixes, s.Suffixes} {
+		if len(patterns) == 0 {
+			continue
+		}
+		patternProbability := float64(0)
+		for _, pattern := range patterns {
+			chance := math.Pow(16, -float64(len(pattern)))
+			if s.CaseSensitive {
+				// Each letter in a checksummed address is uppercase about half of the time
+				for _, char := range pattern {
+					if (char >= 'a' && char <= 'f') || (char >= 'A' && char <= 'F') {
+						chance /= 2
+					}
+				}
+			}
+			patternProbability += chance
+		}
+		probability *= math.Min(patternProbability, 1)
+	}
+	return probability
+}
+
+// Save the search state to disk, if it has a path
+func (s *vanitySearch) save() error {
+	if s.path == "" {
+		return nil
+	}
+	stateBytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing vanity search: %w", err)
+	}
+
+	// Write to a temporary file first so an interruption can't leave a partial state behind
+	tempPath := filepath.Join(filepath.Dir(s.path), fmt.Sprintf(".%s.tmp", filepath.Base(s.path)))
+	if err := os.WriteFile(tempPath, stateBytes, vanitySearchFileMode); err != nil {
+		return fmt.Errorf("error writing vanity search to %s: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		return fmt.Errorf("error saving vanity search to %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Load a search state from disk
+func loadVanitySearch(path string) (*vanitySearch, error) {
+	stateBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	search := new(vanitySearch)
+	if err := json.Unmarshal(stateBytes, search); err != nil {
+		return nil, fmt.Errorf("error deserializing vanity search from %s: %w", path, err)
+	}
+	if search.Version != vanitySearchVersion {
+		return nil, fmt.Errorf("the vanity search in %s has unsupported version %d", path, search.Version)
+	}
+	search.path = path
+	return search, nil
+}
+
+// Combine the results of searches that split the same salt space between several machines
+func mergeVanitySearches(paths []string) error {
+
+	// Load the searches
+	searches := make([]*vanitySearch, len(paths))
+	for i, path := range paths {
+		search, err := loadVanitySearch(path)
+		if err != nil {
+			return fmt.Errorf("error loading vanity search from %s: %w", path, err)
+		}
+		searches[i] = search
+	}
+
+	// Make sure they're all part of the same search
+	first := searches[0]
+	for _, search := range searches[1:] {
+		if search.NodeAddress != first.NodeAddress ||
+			search.MinipoolFactoryAddress != first.MinipoolFactoryAddress ||
+			search.InitHash != first.InitHash ||
+			search.CaseSensitive != first.CaseSensitive ||
+			search.Partitions != first.Partitions ||
+			strings.Join(search.Prefixes, ",") != strings.Join(first.Prefixes, ",") ||
+			strings.Join(search.Suffixes, ",") != strings.Join(first.Suffixes, ",") {
+			return fmt.Errorf("%s and %s are for different searches and can't be merged.", first.path, search.path)
+		}
+	}
+
+	// Print each partition's progress and gather the matches
+	partitions := map[uint64]bool{}
+	matches := []vanityMatch{}
+	seenSalts := map[string]bool{}
+	totalChecked := big.NewInt(0)
+	var totalElapsed time.Duration
+	for _, search := range searches {
+		checked := big.NewInt(0).Sub(search.NextSalt, search.StartSalt)
+		totalChecked.Add(totalChecked, checked)
+		totalElapsed += search.Elapsed
+		partitions[search.Partition] = true
+		fmt.Printf("%s: partition %d of %d, %s salts checked in %s, %d match(es)\n", search.path, search.Partition, search.Partitions, humanize.BigComma(checked), search.Elapsed.Round(time.Second), len(search.Matches))
+		for _, match := range search.Matches {
+			key := match.Salt.String()
+			if !seenSalts[key] {
+				seenSalts[key] = true
+				matches = append(matches, match)
+			}
+		}
+	}
+	missing := []string{}
+	for partition := uint64(1); partition <= first.Partitions; partition++ {
+		if !partitions[partition] {
+			missing = append(missing, strconv.FormatUint(partition, 10))
+		}
+	}
+	fmt.Printf("\nIn total, %s salts were checked in %s of search time.\n", humanize.BigComma(totalChecked), totalElapsed.Round(time.Second))
+	if len(missing) > 0 {
+		fmt.Printf("%sNo results were provided for partition(s) %s.%s\n", colorYellow, strings.Join(missing, ", "), colorReset)
+	}
+
+	sort.Slice(matches, func(i int, j int) bool {
+		return matches[i].Salt.Cmp(matches[j].Salt) < 0
+	})
+	printVanityMatches(matches)
+	return nil
+
+}
+
+// Print the salts that were found
+func printVanityMatches(matches []vanityMatch) {
+	if len(matches) == 0 {
+		fmt.Println("No matching addresses have been found yet.")
+		return
+	}
+	fmt.Println()
+	fmt.Println("Matching addresses:")
+	for _, match := range matches {
+		fmt.Printf("\tsalt 0x%x = %s\n", match.Salt, match.Address.Hex())
+	}
+	fmt.Println("Use one of these salts with `rocketpool node deposit --salt` to create a minipool at that address.")
+}
+
+// Parse a comma-separated list of hex prefixes (which must start with 0x) or suffixes
+func parseVanityPatterns(name string, value string, isPrefix bool) ([]string, error) {
+	patterns := []string{}
+	if value == "" {
+		return patterns, nil
+	}
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if isPrefix && !strings.HasPrefix(pattern, "0x") {
+			return nil, fmt.Errorf("Invalid %s '%s' - it must start with 0x.", name, pattern)
+		}
+		pattern = strings.TrimPrefix(pattern, "0x")
+		if !vanityPatternRegex.MatchString(pattern) {
+			return nil, fmt.Errorf("Invalid %s '%s' - it must be a hex string.", name, pattern)
+		}
+		if len(pattern) > common.AddressLength*2 {
+			return nil, fmt.Errorf("Invalid %s '%s' - it's longer than an address.", name, pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// Parse a salt space partition in the form 'index/count', such as '2/4'
+func parseVanityPartition(value string) (uint64, uint64, error) {
+	if value == "" {
+		return 1, 1, nil
+	}
+	elements := strings.Split(value, "/")
+	if len(elements) != 2 {
+		return 0, 0, fmt.Errorf("Invalid partition '%s' - it must be in the form 'index/count', such as '2/4'.", value)
+	}
+	partition, err := strconv.ParseUint(elements[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid partition index '%s': %w", elements[0], err)
+	}
+	partitions, err := strconv.ParseUint(elements[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid partition count '%s': %w", elements[1], err)
+	}
+	if partitions == 0 || partition == 0 || partition > partitions {
+		return 0, 0, fmt.Errorf("Invalid partition '%s' - the index must be between 1 and the partition count.", value)
+	}
+	return partition, partitions, nil
+}
+
+// Format a large number with an SI suffix
+func formatVanityNumber(value float64) string {
+	number, suffix := humanize.ComputeSI(value)
+	return humanize.FtoaWithDigits(number, 2) + suffix
+}
+
+// Format an expected duration, which can be far too long for a time.Duration
+func formatVanityDuration(seconds float64) string {
+	const secondsPerYear = 365.25 * 24 * 60 * 60
+	if math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return "forever"
+	}
+	if seconds >= secondsPerYear {
+		return fmt.Sprintf("%s years", formatVanityNumber(seconds/secondsPerYear))
 	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second).String()
 }
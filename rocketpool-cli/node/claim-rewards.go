@@ -208,14 +208,15 @@ func nodeClaimRewards(c *cli.Context) error {
 	}
 
 	// Check claim ability
+	var scheduled bool
 	if restakeAmountWei == nil {
 		canClaim, err := rp.CanNodeClaimRewards(indices)
 		if err != nil {
 			return err
 		}
 
-		// Assign max fees
-		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
+		// Assign max fees, or schedule the transaction if requested
+		scheduled, err = gas.AssignMaxFeeOrSchedule(c, canClaim.GasInfo, rp)
 		if err != nil {
 			return err
 		}
@@ -225,8 +226,8 @@ func nodeClaimRewards(c *cli.Context) error {
 			return err
 		}
 
-		// Assign max fees
-		err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
+		// Assign max fees, or schedule the transaction if requested
+		scheduled, err = gas.AssignMaxFeeOrSchedule(c, canClaim.GasInfo, rp)
 		if err != nil {
 			return err
 		}
@@ -254,6 +255,14 @@ func nodeClaimRewards(c *cli.Context) error {
 		txHash = response.TxHash
 	}
 
+	if cliutils.PrintExportedTransactions(rp) {
+		return nil
+	}
+	if scheduled {
+		gas.PrintScheduledTransaction()
+		return nil
+	}
+
 	fmt.Printf("Claiming Rewards...\n")
 	cliutils.PrintTransactionHash(rp, txHash)
 	if _, err = rp.WaitForTransaction(txHash); err != nil {
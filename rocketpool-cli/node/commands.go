@@ -1,8 +1,11 @@
 package node
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/gas"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -183,6 +186,47 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "set-validator-settings",
+				Usage:     "Set or clear the graffiti and builder gas limit used by one of your validators, which the node daemon applies through the Keymanager API",
+				UsageText: "rocketpool node set-validator-settings pubkey [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "graffiti, g",
+						Usage: "The graffiti the validator should use (at most 32 bytes)",
+					},
+					cli.Uint64Flag{
+						Name:  "gas-limit, l",
+						Usage: "The gas limit the validator should register with block builders",
+					},
+					cli.BoolFlag{
+						Name:  "clear-graffiti",
+						Usage: "Go back to using the Validator Client's default graffiti",
+					},
+					cli.BoolFlag{
+						Name:  "clear-gas-limit",
+						Usage: "Go back to using the Smartnode's Builder Gas Limit setting",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					if _, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0)); err != nil {
+						return err
+					}
+					if len(c.String("graffiti")) > 32 {
+						return fmt.Errorf("The graffiti '%s' is longer than 32 bytes.", c.String("graffiti"))
+					}
+
+					// Run
+					return setValidatorSettings(c)
+
+				},
+			},
+
 			{
 				Name:      "swap-rpl",
 				Aliases:   []string{"p"},
@@ -263,7 +307,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Aliases:   []string{"c"},
 				Usage:     "Claim available RPL and ETH rewards for any checkpoint you haven't claimed yet",
 				UsageText: "rocketpool node claim-rpl [options]",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "restake-amount, a",
 						Usage: "The amount of RPL to automatically restake during claiming (or '150%' to stake up to 150% collateral, or 'all' for all available RPL)",
@@ -272,7 +316,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm rewards claim",
 					},
-				},
+				}, gas.ScheduleFlags...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -631,6 +675,55 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return signMessage(c)
 				},
 			},
+
+			{
+				Name:      "transactions",
+				Aliases:   []string{"tx"},
+				Usage:     "List the node account's pending transactions and recent transaction history",
+				UsageText: "rocketpool node transactions [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The maximum number of completed transactions to show (0 for all)",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getTransactions(c)
+
+				},
+			},
+
+			{
+				Name:      "scheduled-transactions",
+				Aliases:   []string{"st"},
+				Usage:     "List the transactions scheduled with `--when-gas-below`, or cancel one that hasn't been submitted yet",
+				UsageText: "rocketpool node scheduled-transactions [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "cancel, c",
+						Usage: "The ID of a waiting scheduled transaction to cancel",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getScheduledTransactions(c)
+
+				},
+			},
 		},
 	})
 }
@@ -36,7 +36,7 @@ func signMessage(c *cli.Context) error {
 		return err
 	}
 
-	if !status.WalletInitialized {
+	if !status.WalletInitialized && status.ExternalSignerUrl == "" {
 		fmt.Println("The node wallet is not initialized.")
 		return nil
 	}
@@ -89,6 +89,10 @@ func nodeStakeRpl(c *cli.Context) error {
 				if err != nil {
 					return err
 				}
+				if cliutils.PrintExportedTransactions(rp) {
+					fmt.Println("Once the approval has been confirmed, run this command again to swap your legacy RPL.")
+					return nil
+				}
 				hash := response.ApproveTxHash
 				fmt.Printf("Approving legacy RPL for swapping...\n")
 				cliutils.PrintTransactionHash(rp, hash)
@@ -134,6 +138,10 @@ func nodeStakeRpl(c *cli.Context) error {
 				return err
 			}
 
+			if cliutils.PrintExportedTransactions(rp) {
+				fmt.Println("Once the swap has been confirmed, run this command again to stake your RPL.")
+				return nil
+			}
 			fmt.Printf("Swapping old RPL for new RPL...\n")
 			cliutils.PrintTransactionHash(rp, swapResponse.SwapTxHash)
 			if _, err = rp.WaitForTransaction(swapResponse.SwapTxHash); err != nil {
@@ -297,6 +305,10 @@ func nodeStakeRpl(c *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		if cliutils.PrintExportedTransactions(rp) {
+			fmt.Println("Once the approval has been confirmed, run this command again to stake your RPL.")
+			return nil
+		}
 		hash := response.ApproveTxHash
 		fmt.Printf("Approving RPL for staking...\n")
 		cliutils.PrintTransactionHash(rp, hash)
@@ -346,6 +358,9 @@ func nodeStakeRpl(c *cli.Context) error {
 		return err
 	}
 
+	if cliutils.PrintExportedTransactions(rp) {
+		return nil
+	}
 	fmt.Printf("Staking RPL...\n")
 	cliutils.PrintTransactionHash(rp, stakeResponse.StakeTxHash)
 	if _, err = rp.WaitForTransaction(stakeResponse.StakeTxHash); err != nil {
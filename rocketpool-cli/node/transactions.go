@@ -0,0 +1,147 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+)
+
+const transactionTimeFormat = "2006-01-02, 15:04 -0700 MST"
+
+func getTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the transactions
+	response, err := rp.GetNodeTransactions()
+	if err != nil {
+		return err
+	}
+
+	// Split them into pending and finished transactions, newest first
+	pending := []*transactions.TransactionRecord{}
+	history := []*transactions.TransactionRecord{}
+	for i := len(response.Transactions) - 1; i >= 0; i-- {
+		record := response.Transactions[i]
+		if record.IsPending() {
+			pending = append(pending, record)
+		} else {
+			history = append(history, record)
+		}
+	}
+	limit := int(c.Uint64("limit"))
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	// Print pending transactions
+	fmt.Printf("%s=== Pending Transactions ===%s\n", colorGreen, colorReset)
+	if len(pending) == 0 {
+		fmt.Println("The node account has no pending transactions.")
+	}
+	for _, record := range pending {
+		printTransactionRecord(record)
+	}
+	fmt.Println()
+
+	// Print the history
+	fmt.Printf("%s=== Transaction History ===%s\n", colorGreen, colorReset)
+	if len(history) == 0 {
+		fmt.Println("The transaction journal doesn't have any completed transactions for the node account.")
+	}
+	for _, record := range history {
+		printTransactionRecord(record)
+	}
+	return nil
+
+}
+
+// Print the details of a journaled transaction
+func printTransactionRecord(record *transactions.TransactionRecord) {
+	statusColor := colorGreen
+	switch record.Status {
+	case transactions.TransactionStatus_Pending:
+		statusColor = colorYellow
+	case transactions.TransactionStatus_Failed, transactions.TransactionStatus_Dropped:
+		statusColor = colorRed
+	}
+
+	purpose := record.Purpose
+	if purpose == "" {
+		purpose = "unknown"
+	}
+
+	fmt.Printf("%s%s%s (nonce %d, %s)\n", statusColor, record.Hash.Hex(), colorReset, record.Nonce, record.Status)
+	fmt.Printf("\tPurpose:   %s\n", purpose)
+	fmt.Printf("\tSubmitted: %s\n", record.SubmittedTime.Format(transactionTimeFormat))
+	fmt.Printf("\tMax fee:   %.2f gwei (priority fee %.2f gwei)\n", eth.WeiToGwei(record.MaxFee), eth.WeiToGwei(record.MaxPriorityFee))
+	if record.Replacements > 0 || record.Rebroadcasts > 0 {
+		fmt.Printf("\tReplaced %d time(s), rebroadcast %d time(s)\n", record.Replacements, record.Rebroadcasts)
+	}
+	if record.BlockNumber > 0 {
+		fmt.Printf("\tIncluded in block %d at %s, using %d gas\n", record.BlockNumber, record.ConfirmedTime.Format(transactionTimeFormat), record.GasUsed)
+	}
+}
+
+func getScheduledTransactions(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Cancel a transaction if requested
+	if c.IsSet("cancel") {
+		id := c.Uint64("cancel")
+		if _, err := rp.CancelScheduledTransaction(id); err != nil {
+			return err
+		}
+		fmt.Printf("Scheduled transaction %d has been cancelled.\n", id)
+		return nil
+	}
+
+	// Get the scheduled transactions
+	response, err := rp.GetScheduledTransactions()
+	if err != nil {
+		return err
+	}
+	if len(response.Transactions) == 0 {
+		fmt.Println("The node doesn't have any scheduled transactions.")
+		return nil
+	}
+
+	// Print them
+	for _, tx := range response.Transactions {
+		statusColor := colorGreen
+		switch tx.Status {
+		case transactions.ScheduledTransactionStatus_Waiting:
+			statusColor = colorYellow
+		case transactions.ScheduledTransactionStatus_Failed, transactions.ScheduledTransactionStatus_Cancelled:
+			statusColor = colorRed
+		}
+
+		fmt.Printf("%s#%d: %s (%s)%s\n", statusColor, tx.ID, tx.Purpose, tx.Status, colorReset)
+		fmt.Printf("\tMax fee:   %.2f gwei (priority fee %.2f gwei)\n", eth.WeiToGwei(tx.MaxFee), eth.WeiToGwei(tx.MaxPriorityFee))
+		fmt.Printf("\tScheduled: %s\n", tx.CreatedTime.Format(transactionTimeFormat))
+		fmt.Printf("\tDeadline:  %s\n", tx.Deadline.Format(transactionTimeFormat))
+		switch tx.Status {
+		case transactions.ScheduledTransactionStatus_Submitted:
+			fmt.Printf("\tSubmitted at %s with hash %s\n", tx.SubmittedTime.Format(transactionTimeFormat), tx.Hash.Hex())
+		case transactions.ScheduledTransactionStatus_Failed:
+			fmt.Printf("\tError: %s\n", tx.Error)
+		}
+	}
+	return nil
+
+}
@@ -0,0 +1,72 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func setValidatorSettings(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the validator and the settings to change
+	pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	setGraffiti := c.IsSet("graffiti") || c.Bool("clear-graffiti")
+	setGasLimit := c.IsSet("gas-limit") || c.Bool("clear-gas-limit")
+	if !setGraffiti && !setGasLimit {
+		return errors.New("Please specify a setting to change with --graffiti, --gas-limit, --clear-graffiti or --clear-gas-limit.")
+	}
+	graffiti := c.String("graffiti")
+	if c.Bool("clear-graffiti") {
+		graffiti = ""
+	}
+	gasLimit := c.Uint64("gas-limit")
+	if c.Bool("clear-gas-limit") {
+		gasLimit = 0
+	}
+
+	// Save the settings
+	response, err := rp.SetValidatorSettings(pubkey, setGraffiti, graffiti, setGasLimit, gasLimit)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The settings for validator %s have been saved:\n", pubkey.Hex())
+	if response.Graffiti != nil {
+		fmt.Printf("\tGraffiti:          %s\n", *response.Graffiti)
+	} else {
+		fmt.Println("\tGraffiti:          your Validator Client's default")
+	}
+	if response.GasLimit != nil {
+		fmt.Printf("\tBuilder gas limit: %d\n", *response.GasLimit)
+	} else {
+		fmt.Println("\tBuilder gas limit: the Smartnode's Builder Gas Limit setting")
+	}
+	if response.KeymanagerActive {
+		fmt.Println("\nThe node daemon will apply them through your Validator Client's Keymanager API the next time it checks your fee recipient.")
+	} else {
+		fmt.Printf("\n%sThese settings are applied through your Validator Client's Keymanager API, which isn't enabled. Enable it in the Smartnode settings with `rocketpool service config` to use them.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
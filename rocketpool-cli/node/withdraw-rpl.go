@@ -130,6 +130,9 @@ func nodeWithdrawRpl(c *cli.Context) error {
 		return err
 	}
 
+	if cliutils.PrintExportedTransactions(rp) {
+		return nil
+	}
 	fmt.Printf("Withdrawing RPL...\n")
 	cliutils.PrintTransactionHash(rp, response.TxHash)
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
@@ -70,7 +70,8 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 		return err
 	}
 
-	if confirm {
+	// Test transactions can't be sent while exporting transactions for offline signing
+	if confirm && !rp.IsExportingTransactions() {
 		// Prompt for a test transaction
 		if cliutils.Confirm("Would you like to send a test transaction to make sure you have the correct address?") {
 			inputAmount := cliutils.Prompt(fmt.Sprintf("Please enter an amount of ETH to send to %s:", withdrawalAddressString), "^\\d+(\\.\\d+)?$", "Invalid amount")
@@ -128,6 +129,9 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 		return err
 	}
 
+	if cliutils.PrintExportedTransactions(rp) {
+		return nil
+	}
 	fmt.Printf("Setting withdrawal address...\n")
 	cliutils.PrintTransactionHash(rp, response.TxHash)
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
@@ -188,6 +192,9 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 		return err
 	}
 
+	if cliutils.PrintExportedTransactions(rp) {
+		return nil
+	}
 	fmt.Printf("Confirming new withdrawal address...\n")
 	cliutils.PrintTransactionHash(rp, response.TxHash)
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
@@ -3,6 +3,7 @@ package queue
 import (
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/gas"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -36,7 +37,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "process",
 				Aliases:   []string{"p"},
 				Usage:     "Process the deposit pool",
-				UsageText: "rocketpool queue process",
+				UsageText: "rocketpool queue process [options]",
+				Flags:     gas.ScheduleFlags,
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -46,8 +46,8 @@ func processQueue(c *cli.Context) error {
 		return nil
 	}
 
-	// Assign max fees
-	err = gas.AssignMaxFeeAndLimit(canProcess.GasInfo, rp, c.Bool("yes"))
+	// Assign max fees, or schedule the transaction if requested
+	scheduled, err := gas.AssignMaxFeeOrSchedule(c, canProcess.GasInfo, rp)
 	if err != nil {
 		return err
 	}
@@ -64,6 +64,11 @@ func processQueue(c *cli.Context) error {
 		return err
 	}
 
+	if scheduled {
+		gas.PrintScheduledTransaction()
+		return nil
+	}
+
 	fmt.Printf("Processing queue...\n")
 	cliutils.PrintTransactionHash(rp, response.TxHash)
 	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
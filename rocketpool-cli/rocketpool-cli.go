@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -95,6 +96,14 @@ ______           _        _    ______           _
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.StringFlag{
+			Name:  "export-unsigned",
+			Usage: "Save unsigned transactions to files in this `folder` instead of submitting them, so they can be signed offline with `rocketpool wallet sign-tx` and submitted with `rocketpool wallet broadcast`",
+		},
+		cli.BoolFlag{
+			Name:  "skip-trace",
+			Usage: "Don't trace transactions to show the ETH and token balance changes they'll make before asking you to confirm them; they're still checked for reverts",
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
@@ -164,7 +173,12 @@ ______           _        _    ______           _
 
 	// Run application
 	fmt.Println("")
-	if err := app.Run(os.Args); err != nil {
+	if err := app.Run(os.Args); errors.Is(err, rocketpool.ErrTransactionExported) {
+		// Exporting a transaction instead of submitting it isn't a failure
+		if err != rocketpool.ErrTransactionExported {
+			fmt.Println(err.Error())
+		}
+	} else if err != nil {
 		cliutils.PrettyPrintError(err)
 	}
 	fmt.Println("")
@@ -19,6 +19,7 @@ type settingsHome struct {
 	fallbackPage     *FallbackConfigPage
 	ccPage           *ConsensusConfigPage
 	mevBoostPage     *MevBoostConfigPage
+	web3SignerPage   *Web3SignerConfigPage
 	metricsPage      *MetricsConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
@@ -44,6 +45,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.ccPage = NewConsensusConfigPage(home)
 	home.fallbackPage = NewFallbackConfigPage(home)
 	home.mevBoostPage = NewMevBoostConfigPage(home)
+	home.web3SignerPage = NewWeb3SignerConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
@@ -52,6 +54,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.ccPage,
 		home.fallbackPage,
 		home.mevBoostPage,
+		home.web3SignerPage,
 		home.metricsPage,
 		home.addonsPage,
 	}
@@ -231,6 +234,10 @@ func (home *settingsHome) refresh() {
 		home.mevBoostPage.layout.refresh()
 	}
 
+	if home.web3SignerPage != nil {
+		home.web3SignerPage.layout.refresh()
+	}
+
 	if home.metricsPage != nil {
 		home.metricsPage.layout.refresh()
 	}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The page wrapper for the Web3Signer config
+type Web3SignerConfigPage struct {
+	home          *settingsHome
+	page          *page
+	layout        *standardLayout
+	masterConfig  *config.RocketPoolConfig
+	enableBox     *parameterizedFormItem
+	modeBox       *parameterizedFormItem
+	localItems    []*parameterizedFormItem
+	externalItems []*parameterizedFormItem
+}
+
+// Creates a new page for the Web3Signer settings
+func NewWeb3SignerConfigPage(home *settingsHome) *Web3SignerConfigPage {
+
+	configPage := &Web3SignerConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-web3signer",
+		"Web3Signer",
+		"Select this to configure Web3Signer, a remote signer that holds your validator keys so they don't need to be stored alongside your Validator Client.\n\nFor more information on Web3Signer, please see https://docs.web3signer.consensys.net/",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *Web3SignerConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the Web3Signer settings page
+func (configPage *Web3SignerConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Web3Signer Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Return to the home page
+		if event.Key() == tcell.KeyEsc {
+			// Close all dropdowns and break if one was open
+			for _, param := range configPage.layout.parameters {
+				dropDown, ok := param.item.(*DropDown)
+				if ok && dropDown.open {
+					dropDown.CloseList(configPage.home.md.app)
+					return nil
+				}
+			}
+
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.enableBox = createParameterizedCheckbox(&configPage.masterConfig.EnableWeb3Signer)
+	configPage.modeBox = createParameterizedDropDown(&configPage.masterConfig.Web3Signer.Mode, configPage.layout.descriptionBox)
+
+	localParams := []*cfgtypes.Parameter{
+		&configPage.masterConfig.Web3Signer.Port,
+		&configPage.masterConfig.Web3Signer.ContainerTag,
+		&configPage.masterConfig.Web3Signer.AdditionalFlags,
+	}
+	externalParams := []*cfgtypes.Parameter{&configPage.masterConfig.Web3Signer.ExternalUrl}
+
+	configPage.localItems = createParameterizedFormItems(localParams, configPage.layout.descriptionBox)
+	configPage.externalItems = createParameterizedFormItems(externalParams, configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableBox, configPage.modeBox)
+	configPage.layout.mapParameterizedFormItems(configPage.localItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.externalItems...)
+
+	// Set up the setting callbacks
+	configPage.enableBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.EnableWeb3Signer.Value == checked {
+			return
+		}
+		configPage.masterConfig.EnableWeb3Signer.Value = checked
+		configPage.handleModeChanged()
+	})
+	configPage.modeBox.item.(*DropDown).SetSelectedFunc(func(text string, index int) {
+		if configPage.masterConfig.Web3Signer.Mode.Value == configPage.masterConfig.Web3Signer.Mode.Options[index].Value {
+			return
+		}
+		configPage.masterConfig.Web3Signer.Mode.Value = configPage.masterConfig.Web3Signer.Mode.Options[index].Value
+		configPage.handleModeChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleModeChanged()
+}
+
+// Handle all of the form changes when the Web3Signer mode has changed
+func (configPage *Web3SignerConfigPage) handleModeChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enableBox.item)
+	if configPage.masterConfig.EnableWeb3Signer.Value == true {
+		configPage.layout.form.AddFormItem(configPage.modeBox.item)
+
+		selectedMode := configPage.masterConfig.Web3Signer.Mode.Value.(cfgtypes.Mode)
+		switch selectedMode {
+		case cfgtypes.Mode_Local:
+			configPage.layout.addFormItems(configPage.localItems)
+		case cfgtypes.Mode_External:
+			configPage.layout.addFormItems(configPage.externalItems)
+		}
+	}
+
+	configPage.layout.refresh()
+}
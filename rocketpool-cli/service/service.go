@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rivo/tview"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
@@ -20,6 +21,12 @@ import (
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/sys"
@@ -31,6 +38,7 @@ const (
 	ExporterContainerSuffix         string = "_exporter"
 	ValidatorContainerSuffix        string = "_validator"
 	BeaconContainerSuffix           string = "_eth2"
+	SlashingProtectionToolSuffix    string = "_slashing_protection"
 	ExecutionContainerSuffix        string = "_eth1"
 	NodeContainerSuffix             string = "_node"
 	ApiContainerSuffix              string = "_api"
@@ -933,6 +941,16 @@ func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig
 			}
 		}
 
+		// Move the slashing protection history to the new client so it doesn't need to wait out the old client's duties
+		if validatorDutyContainerName == prefix+ValidatorContainerSuffix {
+			err = migrateSlashingProtection(rp, cfg, validatorDutyContainerName, currentValidatorImageString, selectedConsensusClientConfig.GetValidatorImage())
+			if err == nil {
+				fmt.Printf("%sMigrated the slashing protection data from [%s] to [%s] - no slashing prevention delay necessary.%s\n", colorGreen, currentValidatorName, pendingValidatorName, colorReset)
+				return nil
+			}
+			fmt.Printf("%sWARNING: couldn't migrate the slashing protection data to the new client: %s\nFalling back to the slashing prevention delay.%s\n\n", colorYellow, err.Error(), colorReset)
+		}
+
 		// Print the warning and start the time lockout
 		safeStartTime := validatorFinishTime.Add(15 * time.Minute)
 		remainingTime := time.Until(safeStartTime)
@@ -962,6 +980,80 @@ func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig
 	return nil
 }
 
+// Export the slashing protection history of the stopped Validator Client and import it into the new one before it starts
+func migrateSlashingProtection(rp *rocketpool.Client, cfg *config.RocketPoolConfig, validatorContainerName string, currentImage string, pendingImage string) error {
+
+	// Get the validators folder
+	validatorsPath, err := rp.GetClientVolumeSource(validatorContainerName, slashing.ValidatorsMountPath)
+	if err != nil {
+		return fmt.Errorf("Error getting the validators folder: %w", err)
+	}
+	if validatorsPath == "" {
+		validatorsPath, err = homedir.Expand(cfg.Smartnode.GetValidatorKeychainPathInCLI())
+		if err != nil {
+			return fmt.Errorf("Error expanding validators path: %w", err)
+		}
+	}
+
+	// Export from the old client
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	toolContainer := cfg.Smartnode.ProjectName.Value.(string) + SlashingProtectionToolSuffix
+	fmt.Println("Exporting the slashing protection data from the previous Validator Client...")
+	exportFile, err := rp.ExportSlashingProtection(currentImage, validatorsPath, network, toolContainer)
+	if err != nil {
+		return err
+	}
+	interchange, err := slashing.LoadInterchange(exportFile)
+	if err != nil {
+		return err
+	}
+	if err := interchange.Validate(network); err != nil {
+		return fmt.Errorf("the exported slashing protection data is invalid: %w", err)
+	}
+
+	// Make sure it covers every validator key, since an export from the wrong data directory can be valid but empty
+	pubkeys, err := getValidatorKeyPubkeys(validatorsPath)
+	if err != nil {
+		return err
+	}
+	if len(pubkeys) == 0 {
+		return fmt.Errorf("couldn't find any validator keys in %s to check the slashing protection data against", validatorsPath)
+	}
+	if err := interchange.CheckValidators(pubkeys); err != nil {
+		return err
+	}
+
+	// Import into the new one
+	fmt.Printf("Importing the slashing protection data for %d validator(s) into the new Validator Client...\n", len(interchange.Data))
+	return rp.ImportSlashingProtection(pendingImage, validatorsPath, exportFile, network, toolContainer)
+
+}
+
+// Get the pubkeys of the validator keys stored in the validators folder for any client
+func getValidatorKeyPubkeys(validatorsPath string) ([]types.ValidatorPubkey, error) {
+	keystores := []keystore.ListableKeystore{
+		lighthouse.NewKeystore(validatorsPath, nil),
+		lodestar.NewKeystore(validatorsPath, nil),
+		nimbus.NewKeystore(validatorsPath, nil),
+		teku.NewKeystore(validatorsPath, nil),
+	}
+	pubkeys := []types.ValidatorPubkey{}
+	found := map[types.ValidatorPubkey]bool{}
+	for _, ks := range keystores {
+		keystorePubkeys, err := ks.ListValidatorPubkeys()
+		if err != nil {
+			return nil, fmt.Errorf("Error getting the validator keys: %w", err)
+		}
+		for _, pubkey := range keystorePubkeys {
+			if !found[pubkey] {
+				found[pubkey] = true
+				pubkeys = append(pubkeys, pubkey)
+			}
+		}
+	}
+	return pubkeys, nil
+}
+
 // Get the name of the container responsible for validator duties based on the client name
 // TODO: this is temporary and can change, clean it up when Nimbus supports split mode
 func getContainerNameForValidatorDuties(CurrentValidatorClientName string, rp *rocketpool.Client) (string, error) {
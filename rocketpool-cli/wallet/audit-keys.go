@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func auditKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Audit the keys
+	response, err := rp.AuditValidatorKeys(c.Uint("lookahead"))
+	if err != nil {
+		return err
+	}
+
+	// Print the keystores that were checked
+	fmt.Printf("Checked keystores: %s\n", strings.Join(response.Keystores, ", "))
+	if len(response.KeystoreErrors) > 0 {
+		names := make([]string, 0, len(response.KeystoreErrors))
+		for name := range response.KeystoreErrors {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Printf("%sThe following keystores couldn't be checked, so keys in them may be reported as missing:\n", colorYellow)
+		for _, name := range names {
+			fmt.Printf("\t%s: %s\n", name, response.KeystoreErrors[name])
+		}
+		fmt.Print(colorReset)
+	}
+	fmt.Println()
+
+	// Group the keys by finding
+	findings := map[api.KeyAuditFinding][]api.ValidatorKeyAudit{}
+	for _, key := range response.Keys {
+		findings[key.Finding] = append(findings[key.Finding], key)
+	}
+
+	// Print the keys that are where they should be
+	fmt.Printf("%d key(s) are loaded for active or pending validators, or have been removed after their validators exited.\n\n", len(findings[api.KeyAuditFinding_Ok]))
+
+	// Print the findings
+	if keys := findings[api.KeyAuditFinding_Missing]; len(keys) > 0 {
+		fmt.Printf("%s=== Missing Keys (%d) ===%s\n", colorRed, len(keys), colorReset)
+		fmt.Println("These minipools' validators still have duties, but their keys aren't in any keystore, so they aren't attesting:")
+		for _, key := range keys {
+			printKeyAudit(key)
+		}
+		fmt.Println("Keys derived from the node wallet can be restored with `rocketpool wallet rebuild`; keys from solo validators can be imported with `rocketpool minipool import-key`.")
+		fmt.Println()
+	}
+	if keys := findings[api.KeyAuditFinding_Exited]; len(keys) > 0 {
+		fmt.Printf("%s=== Keys for Exited Validators (%d) ===%s\n", colorYellow, len(keys), colorReset)
+		fmt.Println("These keys are still loaded, but their validators have exited and have no more duties:")
+		for _, key := range keys {
+			printKeyAudit(key)
+		}
+		fmt.Println()
+	}
+	if keys := findings[api.KeyAuditFinding_Orphaned]; len(keys) > 0 {
+		fmt.Printf("%s=== Orphaned Keys (%d) ===%s\n", colorYellow, len(keys), colorReset)
+		fmt.Println("These keys are in a keystore, but don't belong to any of the node's active minipools (for example, from a failed deposit or a dissolved or closed minipool):")
+		for _, key := range keys {
+			printKeyAudit(key)
+		}
+		fmt.Println()
+	}
+	if keys := findings[api.KeyAuditFinding_Unused]; len(keys) > 0 {
+		fmt.Printf("=== Unused Derivation Indices (%d) ===\n", len(keys))
+		fmt.Println("These keys were derived by the node wallet but were never used by a minipool, usually because the deposit failed:")
+		for _, key := range keys {
+			printKeyAudit(key)
+		}
+		fmt.Println()
+	}
+
+	// Print the next safe index
+	fmt.Printf("The node wallet has created %d validator key(s).\n", response.WalletKeyCount)
+	if response.NextSafeIndex > response.WalletKeyCount {
+		fmt.Printf("%sKeys past the wallet's key count are already in use, so the next safe derivation index is %d. Run `rocketpool wallet rebuild` to update the wallet's key count before creating new minipools.%s\n", colorRed, response.NextSafeIndex, colorReset)
+	} else {
+		fmt.Printf("The next safe derivation index is %d.\n", response.NextSafeIndex)
+	}
+	return nil
+
+}
+
+// Print the details of an audited key
+func printKeyAudit(key api.ValidatorKeyAudit) {
+	fmt.Printf("\t%s\n", key.Pubkey.Hex())
+	if key.HasDerivationIndex {
+		fmt.Printf("\t\tDerivation index: %d\n", key.DerivationIndex)
+	} else {
+		fmt.Println("\t\tDerivation index: not derived from the node wallet")
+	}
+	if len(key.Keystores) > 0 {
+		fmt.Printf("\t\tKeystores:        %s\n", strings.Join(key.Keystores, ", "))
+	}
+	if key.HasMinipool {
+		fmt.Printf("\t\tMinipool:         %s (%s)\n", key.MinipoolAddress.Hex(), key.MinipoolStatus.String())
+	}
+	if key.ValidatorExists {
+		fmt.Printf("\t\tValidator:        %d (%s)\n", key.ValidatorIndex, key.ValidatorStatus)
+	}
+}
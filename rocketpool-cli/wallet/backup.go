@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func backupWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Prompt for user confirmation before printing sensitive information
+	if !(c.GlobalBool("secure-session") ||
+		cliutils.ConfirmSecureSession("Backing up a wallet will print sensitive information to your screen.")) {
+		return nil
+	}
+
+	// Split the seed
+	shareCount := int(c.Uint("shares"))
+	threshold := int(c.Uint("threshold"))
+	response, err := rp.BackupWallet(shareCount, threshold)
+	if err != nil {
+		return err
+	}
+
+	// Print the shares
+	fmt.Printf("Your node wallet's seed has been split into %d backup shares, printed below. Any %d of them can be used with `rocketpool wallet recover --from-shares` to recover your node account and validator keys if they are lost.\n", len(response.Shares), response.Threshold)
+	fmt.Println("Record each share separately and store them in different secure locations. Anyone with enough of them will control your node account and validators.")
+	if status.HasPassphrase {
+		fmt.Println("The shares already include your wallet's BIP-39 passphrase, so you won't need it to recover from them.")
+	}
+	fmt.Println("==============================================================================================================================================")
+	for i, share := range response.Shares {
+		fmt.Println("")
+		fmt.Printf("Share %d of %d:\n", i+1, len(response.Shares))
+		fmt.Println(share)
+	}
+	fmt.Println("")
+	fmt.Println("==============================================================================================================================================")
+	fmt.Println("")
+	fmt.Printf("Use `rocketpool wallet test-recovery --from-shares` to make sure the shares you recorded work.\n")
+	return nil
+
+}
@@ -52,6 +52,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") to derive the wallet's seed with, in addition to its mnemonic",
+					},
+					cli.BoolFlag{
+						Name:  "use-passphrase",
+						Usage: "Prompt for the wallet's BIP-39 passphrase instead of passing it with --passphrase",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -104,6 +112,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") the wallet's seed was derived with, if it has one",
+					},
+					cli.BoolFlag{
+						Name:  "use-passphrase",
+						Usage: "Prompt for the wallet's BIP-39 passphrase instead of passing it with --passphrase",
+					},
+					cli.BoolFlag{
+						Name:  "from-shares",
+						Usage: "Recover the wallet from Shamir backup shares created with `rocketpool wallet backup` instead of a mnemonic phrase",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -123,6 +143,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.Bool("from-shares") {
+						for _, flag := range []string{"mnemonic", "address", "derivation-path", "wallet-index", "passphrase", "use-passphrase"} {
+							if c.IsSet(flag) {
+								return fmt.Errorf("--from-shares can't be used with --%s", flag)
+							}
+						}
+					}
 
 					// Run
 					return recoverWallet(c)
@@ -134,7 +161,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "rebuild",
 				Aliases:   []string{"b"},
 				Usage:     "Rebuild validator keystores from derived keys",
-				UsageText: "rocketpool wallet rebuild",
+				UsageText: "rocketpool wallet rebuild [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") the wallet's seed was derived with, if it has one",
+					},
+					cli.BoolFlag{
+						Name:  "use-passphrase",
+						Usage: "Prompt for the wallet's BIP-39 passphrase instead of passing it with --passphrase",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -175,6 +212,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "address, a",
 						Usage: "If you are recovering a wallet that was not generated by the Smartnode and don't know the derivation path or index of it, enter the address here. The Smartnode will search through its library of paths and indices to try to find it.",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") the wallet's seed was derived with, if it has one",
+					},
+					cli.BoolFlag{
+						Name:  "use-passphrase",
+						Usage: "Prompt for the wallet's BIP-39 passphrase instead of passing it with --passphrase",
+					},
+					cli.BoolFlag{
+						Name:  "from-shares",
+						Usage: "Recover the wallet from Shamir backup shares created with `rocketpool wallet backup` instead of a mnemonic phrase",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -189,6 +238,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.Bool("from-shares") {
+						for _, flag := range []string{"mnemonic", "address", "derivation-path", "wallet-index", "passphrase", "use-passphrase"} {
+							if c.IsSet(flag) {
+								return fmt.Errorf("--from-shares can't be used with --%s", flag)
+							}
+						}
+					}
 
 					// Run
 					return testRecovery(c)
@@ -196,6 +252,144 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "backup",
+				Usage:     "Split the node wallet's seed into Shamir backup shares that can be stored separately; any `threshold` of them can recover the wallet",
+				UsageText: "rocketpool wallet backup [options]",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "shares, n",
+						Usage: "The number of shares to create",
+						Value: 5,
+					},
+					cli.UintFlag{
+						Name:  "threshold, k",
+						Usage: "The number of shares required to recover the wallet",
+						Value: 3,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.Uint("threshold") < 2 || c.Uint("threshold") > c.Uint("shares") || c.Uint("shares") > 255 {
+						return fmt.Errorf("The threshold must be at least 2 and no more than the number of shares, which can't be more than 255.")
+					}
+
+					// Run
+					return backupWallet(c)
+
+				},
+			},
+
+			{
+				Name:      "export-validator-keys",
+				Usage:     "Export validator keys as standard EIP-2335 keystores, with a manifest of their derivation paths, for use with another Validator Client setup",
+				UsageText: "rocketpool wallet export-validator-keys --pubkeys pubkeys --out dir [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "pubkeys, k",
+						Usage: "A comma-separated list of the validator pubkeys to export, or 'all' for every minipool that hasn't been dissolved or finalised",
+					},
+					cli.StringFlag{
+						Name:  "out, o",
+						Usage: "The folder to write the keystores and manifest to",
+					},
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password to encrypt the keystores with",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm exporting the keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("pubkeys") == "" || c.String("out") == "" {
+						return fmt.Errorf("Please specify the keys to export with --pubkeys and the folder to write them to with --out.")
+					}
+					if c.String("password") != "" {
+						if _, err := cliutils.ValidateNodePassword("keystore password", c.String("password")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return exportValidatorKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "audit-keys",
+				Usage:     "Cross-reference the node wallet's validator keys with the keystores, the node's minipools and their validators to find missing, orphaned and exited keys",
+				UsageText: "rocketpool wallet audit-keys [options]",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "lookahead, l",
+						Usage: "The number of keys past the wallet's key count to check for use",
+						Value: 10,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return auditKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "rotate-keystore-passwords",
+				Usage:     "Re-encrypt every validator keystore with a new random password and the node wallet with the node password, optionally changing the node password",
+				UsageText: "rocketpool wallet rotate-keystore-passwords [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "change-password, c",
+						Usage: "Prompt for a new node password to replace the current one with",
+					},
+					cli.StringFlag{
+						Name:  "new-password, p",
+						Usage: "A new node password to replace the current one with",
+					},
+					cli.BoolFlag{
+						Name:  "no-restart",
+						Usage: "Don't restart the Validator Client after rotating the passwords",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm rotating the passwords and restarting the Validator Client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return rotateKeystorePasswords(c)
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -231,6 +425,150 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "set-watch-only",
+				Usage:     "Use an address whose wallet is kept on an offline machine as the node account. Its transactions must be exported with the `--export-unsigned` flag, signed with `rocketpool wallet sign-tx`, and submitted with `rocketpool wallet broadcast`. The node daemon stops submitting its own transactions (such as staking prelaunch minipools), so you must export those yourself.",
+				UsageText: "rocketpool wallet set-watch-only [options] address",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm using the watch-only address",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return setWatchOnly(c, address)
+
+				},
+			},
+
+			{
+				Name:      "clear-watch-only",
+				Usage:     "Stop using a watch-only node account",
+				UsageText: "rocketpool wallet clear-watch-only",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return clearWatchOnly(c)
+
+				},
+			},
+
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign a transaction exported with the `--export-unsigned` flag using the node wallet",
+				UsageText: "rocketpool wallet sign-tx [options] file",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the signed transaction to (defaults to a file next to the unsigned one)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm signing the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return signTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "broadcast",
+				Usage:     "Submit a transaction that was signed offline with `rocketpool wallet sign-tx` and wait for it to be included in a block",
+				UsageText: "rocketpool wallet broadcast [options] file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm submitting the transaction",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return broadcastTransaction(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "export-slashing-protection",
+				Usage:     "Export your Validator Client's slashing protection history in the EIP-3076 interchange format",
+				UsageText: "rocketpool wallet export-slashing-protection [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The file to save the slashing protection data to (defaults to a file in the validators folder)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator Client during the export",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportSlashingProtection(c)
+
+				},
+			},
+
+			{
+				Name:      "import-slashing-protection",
+				Usage:     "Import slashing protection history in the EIP-3076 interchange format into your Validator Client",
+				UsageText: "rocketpool wallet import-slashing-protection [options] file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the Validator Client during the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return importSlashingProtection(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	exportManifestFileName string      = "manifest.json"
+	exportDirMode          os.FileMode = 0700
+	exportFileMode         os.FileMode = 0600
+)
+
+// An entry in the export manifest, in the style of the deposit data files created by the staking deposit CLI
+type exportManifestEntry struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Path                  string `json:"path"`
+	Keystore              string `json:"keystore"`
+	MinipoolAddress       string `json:"minipool_address"`
+	NetworkName           string `json:"network_name"`
+}
+
+func exportValidatorKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get the pubkeys
+	var pubkeys []types.ValidatorPubkey
+	if c.String("pubkeys") == "all" {
+		minipoolStatus, err := rp.MinipoolStatus()
+		if err != nil {
+			return err
+		}
+		for _, minipool := range minipoolStatus.Minipools {
+			if minipool.Status.Status != types.Dissolved && !minipool.Finalised {
+				pubkeys = append(pubkeys, minipool.ValidatorPubkey)
+			}
+		}
+		if len(pubkeys) == 0 {
+			fmt.Println("The node doesn't have any minipools with validator keys to export.")
+			return nil
+		}
+	} else {
+		pubkeys, err = cliutils.ValidatePubkeys("pubkeys", c.String("pubkeys"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check the output folder
+	outputDir, err := homedir.Expand(c.String("out"))
+	if err != nil {
+		return fmt.Errorf("error expanding output path: %w", err)
+	}
+	if entries, err := os.ReadDir(outputDir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s already exists and isn't empty; please choose a new folder", outputDir)
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("%sWARNING: Never run the same validator key in more than one Validator Client at once, or it will be slashed!\n", colorRed)
+	fmt.Println("Before loading these keys anywhere else, stop the Smartnode's Validator Client or remove the keys from it, wait at least two finalized epochs, and import its slashing protection data (see `rocketpool wallet export-slashing-protection`) into the new setup.")
+	fmt.Printf("%s\n", colorReset)
+	if !(c.GlobalBool("secure-session") ||
+		cliutils.ConfirmSecureSession("Anyone with the exported keystores and their password will control these validators.")) {
+		return nil
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to export %d validator key(s) to %s?", len(pubkeys), outputDir))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the keystore password
+	password := c.String("password")
+	if password == "" {
+		password = promptKeystorePassword()
+	}
+
+	// Export the keys
+	response, err := rp.ExportValidatorKeys(password, pubkeys)
+	if err != nil {
+		return err
+	}
+
+	// Write the keystores and manifest
+	if err := os.MkdirAll(outputDir, exportDirMode); err != nil {
+		return fmt.Errorf("error creating %s: %w", outputDir, err)
+	}
+	network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	timestamp := time.Now().Unix()
+	manifest := make([]exportManifestEntry, 0, len(response.Keys))
+	for _, key := range response.Keys {
+		var fileName string
+		if key.DerivationPath != "" {
+			fileName = fmt.Sprintf("keystore-%s-%d.json", strings.ReplaceAll(key.DerivationPath, "/", "_"), timestamp)
+		} else {
+			fileName = fmt.Sprintf("keystore-%s-%d.json", hexutils.RemovePrefix(key.Pubkey.Hex()), timestamp)
+		}
+		keystoreBytes, err := json.Marshal(key.Keystore)
+		if err != nil {
+			return fmt.Errorf("error encoding the keystore for validator %s: %w", key.Pubkey.Hex(), err)
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, fileName), keystoreBytes, exportFileMode); err != nil {
+			return fmt.Errorf("error writing the keystore for validator %s: %w", key.Pubkey.Hex(), err)
+		}
+
+		entry := exportManifestEntry{
+			Pubkey:      hexutils.RemovePrefix(key.Pubkey.Hex()),
+			Path:        key.DerivationPath,
+			Keystore:    fileName,
+			NetworkName: network,
+		}
+		if key.MinipoolAddress != (common.Address{}) {
+			entry.MinipoolAddress = key.MinipoolAddress.Hex()
+			entry.WithdrawalCredentials = hexutils.RemovePrefix(key.WithdrawalCredentials.Hex())
+		}
+		manifest = append(manifest, entry)
+		fmt.Printf("Exported validator %s to %s.\n", key.Pubkey.Hex(), fileName)
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding the manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, exportManifestFileName), manifestBytes, exportFileMode); err != nil {
+		return fmt.Errorf("error writing the manifest: %w", err)
+	}
+
+	fmt.Printf("\nExported %d EIP-2335 keystore(s) and %s to %s.\n", len(response.Keys), exportManifestFileName, outputDir)
+	fmt.Println("The keystores can be imported into any Validator Client, remote signer or DVT cluster that supports the standard format, using the password you chose.")
+	return nil
+
+}
+
+// Prompt for a password to encrypt exported keystores with
+func promptKeystorePassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to encrypt the exported keystores with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
@@ -54,8 +54,15 @@ func initWallet(c *cli.Context) error {
 		fmt.Printf("Using a custom derivation path (%s).\n\n", derivationPath)
 	}
 
+	// Get the passphrase
+	passphrase := getPassphrase(c, true)
+	if passphrase != "" {
+		fmt.Println("Using a BIP-39 passphrase. You will need both it and your mnemonic phrase to recover your wallet.")
+		fmt.Println()
+	}
+
 	// Initialize wallet
-	response, err := rp.InitWallet(derivationPath)
+	response, err := rp.InitWallet(derivationPath, passphrase)
 	if err != nil {
 		return err
 	}
@@ -76,7 +83,7 @@ func initWallet(c *cli.Context) error {
 	}
 
 	// Do a recover to save the wallet
-	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, 0)
+	recoverResponse, err := rp.RecoverWallet(response.Mnemonic, true, derivationPath, 0, passphrase)
 	if err != nil {
 		return fmt.Errorf("error saving wallet: %w", err)
 	}
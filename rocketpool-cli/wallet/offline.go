@@ -0,0 +1,212 @@
+package wallet
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	unsignedTxPrefix string = "unsigned-"
+	signedTxPrefix   string = "signed-"
+)
+
+func setWatchOnly(c *cli.Context, address common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to use %s as a watch-only node account? Its transactions will have to be exported with the `--export-unsigned` flag and signed on the machine that holds its wallet.\n\nThe node daemon can't sign transactions either, so it will stop staking prelaunch minipools, distributing balances, reducing bonds, promoting minipools and submitting scheduled transactions. You'll have to export those transactions yourself, and stake each new minipool before its scrub period ends.", address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Set the address
+	if _, err := rp.SetWatchOnlyAddress(address); err != nil {
+		return err
+	}
+
+	fmt.Printf("The node is now watching %s.\n", address.Hex())
+	return nil
+
+}
+
+func clearWatchOnly(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Clear the address
+	if _, err := rp.ClearWatchOnlyAddress(); err != nil {
+		return err
+	}
+
+	fmt.Println("The node is no longer using a watch-only account.")
+	return nil
+
+}
+
+func signTransaction(c *cli.Context, file string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Load the transaction
+	path, err := homedir.Expand(file)
+	if err != nil {
+		return fmt.Errorf("error expanding file path: %w", err)
+	}
+	offlineTx, err := transactions.LoadOfflineTransaction(path)
+	if err != nil {
+		return err
+	}
+	if len(offlineTx.SignedTx) > 0 {
+		return fmt.Errorf("%s has already been signed.", path)
+	}
+
+	// Make sure it's for this wallet
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized && status.ExternalSignerUrl == "" {
+		return fmt.Errorf("The node wallet is not initialized, so it can't sign transactions.")
+	}
+	if status.AccountAddress != offlineTx.From {
+		return fmt.Errorf("The transaction is from %s, but the node wallet's account is %s.", offlineTx.From.Hex(), status.AccountAddress.Hex())
+	}
+
+	// Prompt for confirmation
+	printOfflineTransaction(offlineTx)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to sign this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Sign it
+	response, err := rp.SignTransaction(offlineTx.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	offlineTx.SignedTx = response.SignedTx
+	if _, err := offlineTx.GetSignedTransaction(); err != nil {
+		return fmt.Errorf("The node wallet returned an invalid signature: %w", err)
+	}
+
+	// Save it
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = getSignedTransactionPath(path)
+	} else if outputPath, err = homedir.Expand(outputPath); err != nil {
+		return fmt.Errorf("error expanding output path: %w", err)
+	}
+	if err := offlineTx.Save(outputPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved the signed transaction to %s.\nCopy it to your node and submit it with `rocketpool wallet broadcast`.\n", outputPath)
+	return nil
+
+}
+
+func broadcastTransaction(c *cli.Context, file string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Load the transaction
+	path, err := homedir.Expand(file)
+	if err != nil {
+		return fmt.Errorf("error expanding file path: %w", err)
+	}
+	offlineTx, err := transactions.LoadOfflineTransaction(path)
+	if err != nil {
+		return err
+	}
+	if _, err := offlineTx.GetSignedTransaction(); err != nil {
+		return fmt.Errorf("Can't broadcast %s: %w", path, err)
+	}
+
+	// Prompt for confirmation
+	printOfflineTransaction(offlineTx)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to submit this transaction?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Submit it
+	response, err := rp.BroadcastTransaction(offlineTx.SignedTx, offlineTx.Purpose)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Submitting transaction...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("The transaction was successfully included in a block.")
+	return nil
+
+}
+
+// Print the details of an offline transaction for review
+func printOfflineTransaction(offlineTx *transactions.OfflineTransaction) {
+	to := "<contract creation>"
+	if offlineTx.To != nil {
+		to = offlineTx.To.Hex()
+	}
+	fmt.Printf("Purpose:          %s\n", offlineTx.Purpose)
+	fmt.Printf("Chain ID:         %s\n", offlineTx.ChainID.String())
+	fmt.Printf("From:             %s\n", offlineTx.From.Hex())
+	fmt.Printf("To:               %s\n", to)
+	fmt.Printf("Nonce:            %d\n", offlineTx.Nonce)
+	fmt.Printf("Value:            %.6f ETH\n", eth.WeiToEth(offlineTx.Value))
+	fmt.Printf("Gas limit:        %d\n", offlineTx.GasLimit)
+	fmt.Printf("Max fee:          %.2f gwei\n", eth.WeiToGwei(offlineTx.MaxFee))
+	fmt.Printf("Max priority fee: %.2f gwei\n", eth.WeiToGwei(offlineTx.MaxPriorityFee))
+	fmt.Printf("Data:             %s\n\n", offlineTx.Data.String())
+}
+
+// Get the default file to save a signed transaction to
+func getSignedTransactionPath(unsignedPath string) string {
+	dir, name := filepath.Split(unsignedPath)
+	if strings.HasPrefix(name, unsignedTxPrefix) {
+		return filepath.Join(dir, signedTxPrefix+strings.TrimPrefix(name, unsignedTxPrefix))
+	}
+	return filepath.Join(dir, signedTxPrefix+name)
+}
@@ -61,11 +61,17 @@ func rebuildWallet(c *cli.Context) error {
 		}(customKeyPasswordFile)
 	}
 
+	// Get the passphrase, which is required if the wallet has one
+	passphrase := getPassphrase(c, false)
+	if passphrase == "" && status.HasPassphrase {
+		passphrase = cliutils.PromptPassword("Your node wallet is protected by a BIP-39 passphrase. Please enter it:", "^.+$", "The passphrase can't be blank. Please try again:")
+	}
+
 	// Log
 	fmt.Println("Rebuilding node validator keystores...")
 
 	// Rebuild wallet
-	response, err := rp.RebuildWallet()
+	response, err := rp.RebuildWallet(passphrase)
 	if err != nil {
 		return err
 	}
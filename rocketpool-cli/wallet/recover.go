@@ -53,14 +53,21 @@ func recoverWallet(c *cli.Context) error {
 		}
 	}
 
-	// Prompt for mnemonic
+	// Prompt for the mnemonic and passphrase, or for backup shares
 	var mnemonic string
-	if c.String("mnemonic") != "" {
-		mnemonic = c.String("mnemonic")
+	var passphrase string
+	var shares []string
+	if c.Bool("from-shares") {
+		shares = promptBackupShares()
 	} else {
-		mnemonic = PromptMnemonic()
+		if c.String("mnemonic") != "" {
+			mnemonic = c.String("mnemonic")
+		} else {
+			mnemonic = PromptMnemonic()
+		}
+		mnemonic = strings.TrimSpace(mnemonic)
+		passphrase = getPassphrase(c, false)
 	}
-	mnemonic = strings.TrimSpace(mnemonic)
 
 	// Handle validator key recovery skipping
 	skipValidatorKeyRecovery := c.Bool("skip-validator-key-recovery")
@@ -87,9 +94,43 @@ func recoverWallet(c *cli.Context) error {
 		}
 	}
 
-	// Check for a search-by-address operation
+	// Check for a recover-from-shares or search-by-address operation
 	addressString := c.String("address")
-	if addressString != "" {
+	if len(shares) > 0 {
+
+		// Log
+		if skipValidatorKeyRecovery {
+			fmt.Println("Recovering node wallet from backup shares only (ignoring validator keys)...")
+		} else {
+			// Check and assign the EC status
+			err = cliutils.CheckClientStatus(rp)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Recovering node wallet and validator keys from backup shares...")
+		}
+
+		// Recover wallet
+		response, err := rp.RecoverWalletFromShares(shares, skipValidatorKeyRecovery)
+		if err != nil {
+			return err
+		}
+
+		// Log & return
+		fmt.Println("The node wallet was successfully recovered.")
+		fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+		if !skipValidatorKeyRecovery {
+			if len(response.ValidatorKeys) > 0 {
+				fmt.Println("Validator keys:")
+				for _, key := range response.ValidatorKeys {
+					fmt.Println(key.Hex())
+				}
+			} else {
+				fmt.Println("No validator keys were found.")
+			}
+		}
+
+	} else if addressString != "" {
 
 		// Get the address to search for
 		address := common.HexToAddress(addressString)
@@ -107,7 +148,7 @@ func recoverWallet(c *cli.Context) error {
 		}
 
 		// Recover wallet
-		response, err := rp.SearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery)
+		response, err := rp.SearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery, passphrase)
 		if err != nil {
 			return err
 		}
@@ -157,7 +198,7 @@ func recoverWallet(c *cli.Context) error {
 		}
 
 		// Recover wallet
-		response, err := rp.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex)
+		response, err := rp.RecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex, passphrase)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func rotateKeystorePasswords(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Get the new node password, if it's being changed
+	newPassword := ""
+	if c.String("new-password") != "" {
+		newPassword, err = cliutils.ValidateNodePassword("new password", c.String("new-password"))
+		if err != nil {
+			return err
+		}
+	} else if c.Bool("change-password") {
+		newPassword = promptPassword()
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This will re-encrypt every validator keystore managed by the Smartnode with a new random password, and re-encrypt the node wallet with the node password.")
+	if newPassword != "" {
+		fmt.Println("The node password will also be replaced with the new one you provided.")
+	}
+	fmt.Printf("%sNOTE: All of the files are replaced together; if anything fails, the original keystores, wallet and password are restored.\nValidator keys loaded into your Validator Client through its Keymanager API are stored by the client itself, and are not affected.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to rotate your keystore passwords?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Rotate the passwords
+	response, err := rp.RotateKeystorePasswords(newPassword)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Re-encrypted the node wallet and %d validator keystore(s):\n", len(response.Keystores))
+	for _, keystore := range response.Keystores {
+		fmt.Printf("\t%s\n", keystore)
+	}
+	if len(response.SkippedKeystores) > 0 {
+		fmt.Printf("%sThe following keystores are encrypted by the Validator Client or remote signer rather than the Smartnode, so they were not rotated:\n", colorYellow)
+		for _, keystore := range response.SkippedKeystores {
+			fmt.Printf("\t%s\n", keystore)
+		}
+		fmt.Printf("Use their own tools to change their passwords if you need to.%s\n", colorReset)
+	}
+	if response.PasswordChanged {
+		fmt.Println("The node password has been changed.")
+	}
+	fmt.Println()
+
+	// Restart the VC if necessary
+	if !response.RestartRequired {
+		fmt.Println("Your Validator Client doesn't need to be restarted.")
+		return nil
+	}
+	if c.Bool("no-restart") {
+		fmt.Printf("%sPlease restart your Validator Client so it loads the re-encrypted keystores with their new passwords.%s\n", colorYellow, colorReset)
+		return nil
+	}
+	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the Smartnode's Validator Client now so it loads the re-encrypted keystores?") {
+		fmt.Print("Restarting Validator Client... ")
+		_, err := rp.RestartVc()
+		if err != nil {
+			fmt.Printf("failed!\n%sWARNING: error restarting validator client: %s\n\nPlease restart it manually so it loads the re-encrypted keystores with their new passwords.%s\n", colorYellow, err.Error(), colorReset)
+			return nil
+		}
+		fmt.Println("done!")
+	}
+	return nil
+
+}
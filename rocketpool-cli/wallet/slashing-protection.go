@@ -0,0 +1,207 @@
+package wallet
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	validatorContainerSuffix     string = "_validator"
+	slashingProtectionToolSuffix string = "_slashing_protection"
+)
+
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the Validator Client
+	cfg, validatorContainer, validatorImage, validatorsPath, err := getValidatorClientInfo(rp)
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Your Validator Client must be stopped while its slashing protection data is exported, so it will miss a few attestations. It will be restarted afterwards.\nAre you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Export the data with the Validator Client stopped
+	var hostFile string
+	err = withValidatorClientStopped(rp, validatorContainer, func() error {
+		var err error
+		hostFile, err = rp.ExportSlashingProtection(validatorImage, validatorsPath, cfg.Smartnode.Network.Value.(cfgtypes.Network), cfg.Smartnode.ProjectName.Value.(string)+slashingProtectionToolSuffix)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Check the export
+	interchange, err := slashing.LoadInterchange(hostFile)
+	if err != nil {
+		return err
+	}
+	if err := interchange.Validate(cfg.Smartnode.Network.Value.(cfgtypes.Network)); err != nil {
+		return fmt.Errorf("the exported slashing protection data is invalid: %w", err)
+	}
+	printInterchangeSummary(interchange)
+
+	// Save it to the requested file
+	if c.String("file") != "" {
+		outputPath, err := homedir.Expand(c.String("file"))
+		if err != nil {
+			return fmt.Errorf("error expanding output path: %w", err)
+		}
+		if err := interchange.Save(outputPath); err != nil {
+			return err
+		}
+		hostFile = outputPath
+	}
+
+	fmt.Printf("Exported the slashing protection data to %s.\n", hostFile)
+	return nil
+
+}
+
+func importSlashingProtection(c *cli.Context, file string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the Validator Client
+	cfg, validatorContainer, validatorImage, validatorsPath, err := getValidatorClientInfo(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the file
+	filePath, err := homedir.Expand(file)
+	if err != nil {
+		return fmt.Errorf("error expanding file path: %w", err)
+	}
+	filePath, err = filepath.Abs(filePath)
+	if err != nil {
+		return fmt.Errorf("error getting the absolute path of %s: %w", file, err)
+	}
+	interchange, err := slashing.LoadInterchange(filePath)
+	if err != nil {
+		return err
+	}
+	if err := interchange.Validate(cfg.Smartnode.Network.Value.(cfgtypes.Network)); err != nil {
+		return fmt.Errorf("can't import %s: %w", filePath, err)
+	}
+	printInterchangeSummary(interchange)
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Your Validator Client must be stopped while the slashing protection data is imported, so it will miss a few attestations. It will be restarted afterwards.\nAre you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Import the data with the Validator Client stopped
+	err = withValidatorClientStopped(rp, validatorContainer, func() error {
+		return rp.ImportSlashingProtection(validatorImage, validatorsPath, filePath, cfg.Smartnode.Network.Value.(cfgtypes.Network), cfg.Smartnode.ProjectName.Value.(string)+slashingProtectionToolSuffix)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Imported the slashing protection data successfully.")
+	return nil
+
+}
+
+// Get the config, container name, image, and validators folder of the Validator Client
+func getValidatorClientInfo(rp *rocketpool.Client) (*config.RocketPoolConfig, string, string, string, error) {
+
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, "", "", "", fmt.Errorf("error loading configuration: %w", err)
+	}
+	if isNew {
+		return nil, "", "", "", fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		return nil, "", "", "", fmt.Errorf("This command isn't supported in Native mode. Please use your Validator Client's own slashing protection export and import commands.")
+	}
+
+	validatorContainer := cfg.Smartnode.ProjectName.Value.(string) + validatorContainerSuffix
+	validatorImage, err := rp.GetDockerImage(validatorContainer)
+	if err != nil {
+		return nil, "", "", "", fmt.Errorf("error getting the Validator Client image: %w", err)
+	}
+	if validatorImage == "" {
+		return nil, "", "", "", fmt.Errorf("The Validator Client container doesn't exist yet. Please start the Smartnode with `rocketpool service start` first.")
+	}
+
+	// Use the folder that's actually mounted into the container, falling back to the configured one
+	validatorsPath, err := rp.GetClientVolumeSource(validatorContainer, slashing.ValidatorsMountPath)
+	if err != nil || validatorsPath == "" {
+		validatorsPath, err = homedir.Expand(cfg.Smartnode.GetValidatorKeychainPathInCLI())
+		if err != nil {
+			return nil, "", "", "", fmt.Errorf("error expanding validators path: %w", err)
+		}
+	}
+
+	return cfg, validatorContainer, validatorImage, validatorsPath, nil
+
+}
+
+// Run a function with the Validator Client stopped, restarting it afterwards if it was running
+func withValidatorClientStopped(rp *rocketpool.Client, validatorContainer string, run func() error) error {
+
+	status, err := rp.GetDockerStatus(validatorContainer)
+	if err != nil {
+		return fmt.Errorf("error getting the Validator Client status: %w", err)
+	}
+	wasRunning := (status == "running")
+	if wasRunning {
+		fmt.Println("Stopping the Validator Client...")
+		if _, err := rp.StopContainer(validatorContainer); err != nil {
+			return fmt.Errorf("error stopping the Validator Client: %w", err)
+		}
+	}
+
+	runErr := run()
+
+	if wasRunning {
+		fmt.Println("Restarting the Validator Client...")
+		if _, err := rp.StartContainer(validatorContainer); err != nil {
+			if runErr != nil {
+				return fmt.Errorf("%w\nerror restarting the Validator Client: %s", runErr, err.Error())
+			}
+			return fmt.Errorf("error restarting the Validator Client: %w", err)
+		}
+	}
+	return runErr
+
+}
+
+// Print a summary of interchange data
+func printInterchangeSummary(interchange *slashing.Interchange) {
+	fmt.Printf("The slashing protection data covers %d validator(s):\n", len(interchange.Data))
+	for _, history := range interchange.Data {
+		slot, epoch := history.GetHighWatermarks()
+		fmt.Printf("\t%s: %d block(s) up to slot %d, %d attestation(s) up to epoch %d\n", history.Pubkey.Hex(), len(history.SignedBlocks), slot, len(history.SignedAttestations), epoch)
+	}
+	fmt.Println()
+}
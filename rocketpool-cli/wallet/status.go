@@ -31,8 +31,21 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// Print status & return
-	if status.WalletInitialized {
+	if status.ExternalSignerUrl != "" {
+		fmt.Printf("The node account is held by the external signer at %s.\n", status.ExternalSignerUrl)
+		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		if !status.WalletInitialized {
+			fmt.Println("The node wallet has not been initialized, so it can't create validator keys.")
+		}
+	} else if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
+		if status.HasPassphrase {
+			fmt.Println("Its seed is protected by a BIP-39 passphrase.")
+		}
+		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+	} else if status.WatchOnly {
+		fmt.Println("The node wallet is watch-only, so its transactions must be exported with the `--export-unsigned` flag and signed offline.")
+		fmt.Println("The node daemon can't sign transactions either, so you must stake prelaunch minipools, distribute balances, reduce bonds and promote minipools yourself.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
@@ -37,14 +37,21 @@ func testRecovery(c *cli.Context) error {
 	// Prompt a notice about test recovery
 	fmt.Printf("%sNOTE:\nThis command will test the recovery of your node wallet's private key and (unless explicitly disabled) the validator keys for your minipools, but will not actually write any files; it's simply a \"dry run\" of recovery.\nUse `rocketpool wallet recover` to actually recover the wallet and validator keys.%s\n\n", colorYellow, colorReset)
 
-	// Prompt for mnemonic
+	// Prompt for the mnemonic and passphrase, or for backup shares
 	var mnemonic string
-	if c.String("mnemonic") != "" {
-		mnemonic = c.String("mnemonic")
+	var passphrase string
+	var shares []string
+	if c.Bool("from-shares") {
+		shares = promptBackupShares()
 	} else {
-		mnemonic = PromptMnemonic()
+		if c.String("mnemonic") != "" {
+			mnemonic = c.String("mnemonic")
+		} else {
+			mnemonic = PromptMnemonic()
+		}
+		mnemonic = strings.TrimSpace(mnemonic)
+		passphrase = getPassphrase(c, false)
 	}
-	mnemonic = strings.TrimSpace(mnemonic)
 
 	// Handle validator key recovery skipping
 	skipValidatorKeyRecovery := c.Bool("skip-validator-key-recovery")
@@ -71,9 +78,43 @@ func testRecovery(c *cli.Context) error {
 		}
 	}
 
-	// Check for a search-by-address operation
+	// Check for a recover-from-shares or search-by-address operation
 	addressString := c.String("address")
-	if addressString != "" {
+	if len(shares) > 0 {
+
+		// Log
+		if skipValidatorKeyRecovery {
+			fmt.Println("Testing recovery of node wallet from backup shares only (ignoring validator keys)...")
+		} else {
+			// Check and assign the EC status
+			err = cliutils.CheckClientStatus(rp)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Testing recovery of node wallet and validator keys from backup shares...")
+		}
+
+		// Test recover wallet
+		response, err := rp.TestRecoverWalletFromShares(shares, skipValidatorKeyRecovery)
+		if err != nil {
+			return err
+		}
+
+		// Log & return
+		fmt.Println("The node wallet was successfully found - recovery is possible.")
+		fmt.Printf("Node account: %s\n", response.AccountAddress.Hex())
+		if !skipValidatorKeyRecovery {
+			if len(response.ValidatorKeys) > 0 {
+				fmt.Println("Validator keys:")
+				for _, key := range response.ValidatorKeys {
+					fmt.Println(key.Hex())
+				}
+			} else {
+				fmt.Println("No validator keys were found.")
+			}
+		}
+
+	} else if addressString != "" {
 
 		// Get the address to search for
 		address := common.HexToAddress(addressString)
@@ -91,7 +132,7 @@ func testRecovery(c *cli.Context) error {
 		}
 
 		// Test recover wallet
-		response, err := rp.TestSearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery)
+		response, err := rp.TestSearchAndRecoverWallet(mnemonic, address, skipValidatorKeyRecovery, passphrase)
 		if err != nil {
 			return err
 		}
@@ -141,7 +182,7 @@ func testRecovery(c *cli.Context) error {
 		}
 
 		// Test recover wallet
-		response, err := rp.TestRecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex)
+		response, err := rp.TestRecoverWallet(mnemonic, skipValidatorKeyRecovery, derivationPath, walletIndex, passphrase)
 		if err != nil {
 			return err
 		}
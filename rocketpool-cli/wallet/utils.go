@@ -17,6 +17,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
+	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 )
 
@@ -85,6 +87,78 @@ func PromptMnemonic() string {
 	}
 }
 
+// Get the wallet's BIP-39 passphrase from the command's flags, prompting for it if requested.
+// New passphrases must be entered twice to confirm them.
+func getPassphrase(c *cli.Context, isNew bool) string {
+	if c.String("passphrase") != "" {
+		return c.String("passphrase")
+	}
+	if !c.Bool("use-passphrase") {
+		return ""
+	}
+	for {
+		passphrase := cliutils.PromptPassword("Please enter your wallet's BIP-39 passphrase:", "^.+$", "The passphrase can't be blank. Please try again:")
+		if !isNew {
+			return passphrase
+		}
+		confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Prompt for enough Shamir backup shares to recover a wallet
+func promptBackupShares() []string {
+	shares := []string{}
+	var first shamir.Share
+	for {
+		prompt := "Please enter one of your backup shares:"
+		if len(shares) > 0 {
+			prompt = fmt.Sprintf("Please enter another backup share (%d of %d):", len(shares)+1, first.Threshold)
+		}
+		shareString := strings.TrimSpace(cliutils.PromptPassword(prompt, "^.+$", "Please enter a backup share."))
+		share, err := shamir.ParseShare(shareString)
+		if err != nil {
+			fmt.Printf("Invalid share: %s\n", err.Error())
+			fmt.Println("Please try again.")
+			fmt.Println("")
+			continue
+		}
+
+		// Make sure it belongs with the other shares
+		if len(shares) == 0 {
+			first = share
+			fmt.Printf("This share belongs to a set of backup shares that requires %d of them to recover the wallet.\n", share.Threshold)
+		} else if share.SetID != first.SetID {
+			fmt.Println("This share is from a different backup than the first one you entered. Please try again.")
+			fmt.Println("")
+			continue
+		} else if isDuplicateShare(shares, share) {
+			fmt.Printf("You've already entered share %d. Please enter a different one.\n", share.Index)
+			fmt.Println("")
+			continue
+		}
+		shares = append(shares, shareString)
+		if len(shares) >= int(first.Threshold) {
+			return shares
+		}
+	}
+}
+
+// Check if a backup share has already been entered
+func isDuplicateShare(shares []string, share shamir.Share) bool {
+	for _, shareString := range shares {
+		existing, err := shamir.ParseShare(shareString)
+		if err == nil && existing.Index == share.Index {
+			return true
+		}
+	}
+	return false
+}
+
 // Confirm a recovery mnemonic phrase
 func confirmMnemonic(mnemonic string) {
 	for {
@@ -57,6 +57,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		return err
 	}
 
+	// Simulate every transaction before it's confirmed so the user can see what it will do
+	command.Before = func(c *cli.Context) error {
+		services.EnableTransactionSimulation(!c.GlobalBool("skip-trace"))
+		return nil
+	}
+
 	// Register subcommands
 	auction.RegisterSubcommands(&command, "auction", []string{"a"})
 	faucet.RegisterSubcommands(&command, "faucet", []string{"f"})
@@ -0,0 +1,290 @@
+package minipool
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func signWithdrawalCredsChanges(c *cli.Context, mnemonic string, validatorIndices []uint64) (*api.SignWithdrawalCredsChangesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SignWithdrawalCredsChangesResponse{}
+
+	// Get the node account and signature domain
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	signatureDomain, err := getWithdrawalCredsChangeDomain(bc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign a change for each validator
+	keyIndices := map[types.ValidatorPubkey]uint{}
+	nextKeyIndex := uint(0)
+	for _, validatorIndex := range validatorIndices {
+		change := api.WithdrawalCredsChange{
+			ValidatorIndex: validatorIndex,
+		}
+		err := func() error {
+
+			// Get the validator
+			status, err := bc.GetValidatorStatusByIndex(strconv.FormatUint(validatorIndex, 10), nil)
+			if err != nil {
+				return fmt.Errorf("error getting the status of validator %d: %w", validatorIndex, err)
+			}
+			if !status.Exists {
+				return fmt.Errorf("validator %d doesn't exist on the Beacon Chain", validatorIndex)
+			}
+			change.ValidatorPubkey = status.Pubkey
+
+			// Find the validator key in the mnemonic, continuing the search from where the last validator left off
+			keyIndex, found := keyIndices[status.Pubkey]
+			for !found && nextKeyIndex < validatorLimit {
+				key, err := validator.GetPrivateKey(mnemonic, nextKeyIndex, validator.ValidatorKeyPath)
+				if err != nil {
+					return fmt.Errorf("error deriving key for index %d: %w", nextKeyIndex, err)
+				}
+				pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+				keyIndices[pubkey] = nextKeyIndex
+				if pubkey == status.Pubkey {
+					keyIndex = nextKeyIndex
+					found = true
+				}
+				nextKeyIndex++
+			}
+			if !found {
+				return fmt.Errorf("couldn't find the key for validator %d in this mnemonic after %d tries", validatorIndex, validatorLimit)
+			}
+
+			// Get the withdrawal key and the minipool it's changing to
+			withdrawalKey, err := validator.GetWithdrawalKey(mnemonic, keyIndex, validator.ValidatorKeyPath)
+			if err != nil {
+				return err
+			}
+			change.FromBLSPubkey = types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal())
+			change.ToExecutionAddress, err = minipool.GetMinipoolByPubkey(rp, status.Pubkey, nil)
+			if err != nil {
+				return fmt.Errorf("error getting the minipool for validator %d: %w", validatorIndex, err)
+			}
+
+			// Sign the change and make sure it's valid
+			change.Signature, err = validator.GetSignedWithdrawalCredsChangeMessage(withdrawalKey, validatorIndex, change.ToExecutionAddress, signatureDomain)
+			if err != nil {
+				return err
+			}
+			_, err = checkWithdrawalCredsChange(rp, bc, nodeAccount.Address, signatureDomain, change.ValidatorIndex, change.FromBLSPubkey, change.ToExecutionAddress, change.Signature)
+			return err
+
+		}()
+		if err != nil {
+			change.Error = err.Error()
+		}
+		response.Changes = append(response.Changes, change)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func canSubmitWithdrawalCredsChange(c *cli.Context, validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) (*api.CanSubmitWithdrawalCredsChangeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanSubmitWithdrawalCredsChangeResponse{}
+
+	// Check the change
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	signatureDomain, err := getWithdrawalCredsChangeDomain(bc)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorPubkey, err = checkWithdrawalCredsChange(rp, bc, nodeAccount.Address, signatureDomain, validatorIndex, fromBlsPubkey, toExecutionAddress, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update & return response
+	response.CanSubmit = true
+	return &response, nil
+
+}
+
+func submitWithdrawalCredsChange(c *cli.Context, validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) (*api.SubmitWithdrawalCredsChangeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SubmitWithdrawalCredsChangeResponse{}
+
+	// Check the change
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	signatureDomain, err := getWithdrawalCredsChangeDomain(bc)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := checkWithdrawalCredsChange(rp, bc, nodeAccount.Address, signatureDomain, validatorIndex, fromBlsPubkey, toExecutionAddress, signature); err != nil {
+		return nil, err
+	}
+
+	// Broadcast withdrawal creds change message
+	if err := bc.ChangeWithdrawalCredentials(validatorIndex, fromBlsPubkey, toExecutionAddress, signature); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the signature domain for withdrawal creds changes, which always uses the genesis fork
+func getWithdrawalCredsChangeDomain(bc beacon.Client) ([]byte, error) {
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+	return bc.GetDomainData(eth2types.DomainBlsToExecutionChange[:], head.Epoch, true)
+}
+
+// Check a withdrawal creds change against the Beacon Chain and the node's minipools, returning the validator's pubkey
+func checkWithdrawalCredsChange(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, signatureDomain []byte, validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) (types.ValidatorPubkey, error) {
+
+	// Check the signature
+	if err := validator.VerifyWithdrawalCredsChangeSignature(validatorIndex, fromBlsPubkey, toExecutionAddress, signatureDomain, signature); err != nil {
+		return types.ValidatorPubkey{}, fmt.Errorf("invalid change for validator %d: %w", validatorIndex, err)
+	}
+
+	// Check the validator's current withdrawal creds
+	status, err := bc.GetValidatorStatusByIndex(strconv.FormatUint(validatorIndex, 10), nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, fmt.Errorf("error getting the status of validator %d: %w", validatorIndex, err)
+	}
+	if !status.Exists {
+		return types.ValidatorPubkey{}, fmt.Errorf("validator %d doesn't exist on the Beacon Chain", validatorIndex)
+	}
+	if status.WithdrawalCredentials[0] != 0x00 {
+		return types.ValidatorPubkey{}, fmt.Errorf("validator %d (pubkey %s) has already been migrated - its withdrawal credentials are %s", validatorIndex, status.Pubkey.Hex(), status.WithdrawalCredentials.Hex())
+	}
+	expectedCreds := validator.GetBLSWithdrawalCredentials(fromBlsPubkey.Bytes())
+	if status.WithdrawalCredentials != expectedCreds {
+		return types.ValidatorPubkey{}, fmt.Errorf("withdrawal credentials mismatch for validator %d (pubkey %s): they're %s, but the withdrawal key %s provides %s", validatorIndex, status.Pubkey.Hex(), status.WithdrawalCredentials.Hex(), fromBlsPubkey.Hex(), expectedCreds.Hex())
+	}
+
+	// Check the new address is the validator's minipool
+	minipoolAddress, err := minipool.GetMinipoolByPubkey(rp, status.Pubkey, nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, fmt.Errorf("error getting the minipool for validator %d: %w", validatorIndex, err)
+	}
+	if minipoolAddress == (common.Address{}) {
+		return types.ValidatorPubkey{}, fmt.Errorf("validator %d (pubkey %s) doesn't belong to a minipool", validatorIndex, status.Pubkey.Hex())
+	}
+	if minipoolAddress != toExecutionAddress {
+		return types.ValidatorPubkey{}, fmt.Errorf("the change for validator %d would set its withdrawal address to %s, but its minipool is %s", validatorIndex, toExecutionAddress.Hex(), minipoolAddress.Hex())
+	}
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAddress); err != nil {
+		return types.ValidatorPubkey{}, err
+	}
+
+	// Check the minipool is a vacant one awaiting the change, since it can't be undone
+	mpv3, success := minipool.GetMinipoolAsV3(mp)
+	if !success {
+		return types.ValidatorPubkey{}, fmt.Errorf("minipool %s's delegate is too old - it must be upgraded before you can change the withdrawal credentials to this minipool", minipoolAddress.Hex())
+	}
+	details, err := mpv3.GetStatusDetails(nil)
+	if err != nil {
+		return types.ValidatorPubkey{}, fmt.Errorf("error getting status details for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	if !details.IsVacant {
+		return types.ValidatorPubkey{}, fmt.Errorf("minipool %s is not vacant", minipoolAddress.Hex())
+	}
+	if details.Status != types.Prelaunch {
+		return types.ValidatorPubkey{}, fmt.Errorf("minipool %s is not in prelaunch state", minipoolAddress.Hex())
+	}
+	if status.Status != beacon.ValidatorState_ActiveOngoing {
+		return types.ValidatorPubkey{}, fmt.Errorf("validator %d (pubkey %s) was in state %v, but is required to be active_ongoing for migration", validatorIndex, status.Pubkey.Hex(), status.Status)
+	}
+
+	return status.Pubkey, nil
+
+}
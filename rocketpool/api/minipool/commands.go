@@ -1,6 +1,9 @@
 package minipool
 
 import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -252,6 +255,56 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "presign-exit",
+				Usage:     "Sign a voluntary exit for a staking minipool's validator without broadcasting it",
+				UsageText: "rocketpool api minipool presign-exit minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(presignExit(c, minipoolAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "broadcast-exit",
+				Usage:     "Broadcast a pre-signed voluntary exit to the beacon chain",
+				UsageText: "rocketpool api minipool broadcast-exit validator-index epoch signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					validatorIndex, err := cliutils.ValidateUint("validator index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					epoch, err := cliutils.ValidateUint("epoch", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateValidatorSignature("signature", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastExit(c, validatorIndex, epoch, signature))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "get-minipool-close-details-for-node",
@@ -635,6 +688,91 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "plan-bond-reduction",
+				Usage:     "Simulate reducing the bonds of the node's 16 ETH minipools to 8 ETH",
+				UsageText: "rocketpool api minipool plan-bond-reduction minipool-addresses",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					var minipoolAddresses []common.Address
+					if c.Args().Get(0) != "all" {
+						var err error
+						minipoolAddresses, err = cliutils.ValidateAddresses("minipool addresses", c.Args().Get(0))
+						if err != nil {
+							return err
+						}
+					}
+
+					// Run
+					api.PrintResponse(planBondReduction(c, minipoolAddresses))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "timeline",
+				Usage:     "Get the lifecycle timeline of a minipool",
+				UsageText: "rocketpool api minipool timeline minipool-address start-block",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					startBlock, err := cliutils.ValidateUint("start block", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolTimeline(c, minipoolAddress, startBlock))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "income-statement",
+				Usage:     "Get the node's earnings from its minipools over a time range",
+				UsageText: "rocketpool api minipool income-statement minipool-addresses start-time end-time",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					var minipoolAddresses []common.Address
+					if c.Args().Get(0) != "all" {
+						var err error
+						minipoolAddresses, err = cliutils.ValidateAddresses("minipool addresses", c.Args().Get(0))
+						if err != nil {
+							return err
+						}
+					}
+					startTime, err := cliutils.ValidateUint("start time", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					endTime, err := cliutils.ValidateUint("end time", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getIncomeStatement(c, minipoolAddresses, time.Unix(int64(startTime), 0), time.Unix(int64(endTime), 0)))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "get-distribute-balance-details",
@@ -751,6 +889,184 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "sign-withdrawal-creds-changes",
+				Usage:     "Sign BLS-to-execution withdrawal credential changes for a list of validators, using the mnemonic their withdrawal keys were derived from",
+				UsageText: "rocketpool api minipool sign-withdrawal-creds-changes mnemonic validator-indices",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					mnemonic, err := cliutils.ValidateWalletMnemonic("mnemonic", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					validatorIndices, err := cliutils.ValidateUints("validator indices", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signWithdrawalCredsChanges(c, mnemonic, validatorIndices))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-submit-withdrawal-creds-change",
+				Usage:     "Check whether a signed BLS-to-execution withdrawal credential change is valid for one of the node's minipools",
+				UsageText: "rocketpool api minipool can-submit-withdrawal-creds-change validator-index from-bls-pubkey to-execution-address signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					validatorIndex, err := cliutils.ValidateUint("validator index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					fromBlsPubkey, err := cliutils.ValidatePubkey("from BLS pubkey", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					toExecutionAddress, err := cliutils.ValidateAddress("to execution address", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateValidatorSignature("signature", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSubmitWithdrawalCredsChange(c, validatorIndex, fromBlsPubkey, toExecutionAddress, signature))
+					return nil
+
+				},
+			},
+			{
+				Name:      "submit-withdrawal-creds-change",
+				Usage:     "Submit a signed BLS-to-execution withdrawal credential change to the Beacon Chain",
+				UsageText: "rocketpool api minipool submit-withdrawal-creds-change validator-index from-bls-pubkey to-execution-address signature",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 4); err != nil {
+						return err
+					}
+					validatorIndex, err := cliutils.ValidateUint("validator index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					fromBlsPubkey, err := cliutils.ValidatePubkey("from BLS pubkey", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					toExecutionAddress, err := cliutils.ValidateAddress("to execution address", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+					signature, err := cliutils.ValidateValidatorSignature("signature", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(submitWithdrawalCredsChange(c, validatorIndex, fromBlsPubkey, toExecutionAddress, signature))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "select",
+				Usage:     "Get the addresses of the node's minipools that match a selector",
+				UsageText: "rocketpool api minipool select selector",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					selector, err := cliutils.ValidateMinipoolSelector("selector", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(selectMinipools(c, selector))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-solo-migrations",
+				Usage:     "Get the current stage of each solo validator migration the node is working through",
+				UsageText: "rocketpool api minipool get-solo-migrations",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSoloMigrations(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "start-solo-migration",
+				Usage:     "Start tracking the migration of a solo validator into a minipool",
+				UsageText: "rocketpool api minipool start-solo-migration pubkey import-key",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					importKey, err := cliutils.ValidateBool("import-key", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(startSoloMigration(c, pubkey, importKey))
+					return nil
+
+				},
+			},
+			{
+				Name:      "forget-solo-migration",
+				Usage:     "Stop tracking the migration of a solo validator",
+				UsageText: "rocketpool api minipool forget-solo-migration pubkey",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(forgetSoloMigration(c, pubkey))
+					return nil
+
+				},
+			},
 		},
 	})
 }
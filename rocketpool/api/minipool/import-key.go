@@ -87,6 +87,7 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 	if err != nil {
 		return nil, fmt.Errorf("error saving keystore: %w", err)
 	}
+	response.RestartRequired = w.IsValidatorRestartRequired()
 
 	// Return response
 	return &response, nil
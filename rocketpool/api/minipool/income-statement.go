@@ -0,0 +1,499 @@
+package minipool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+var (
+	incomeValidatorBalance = eth.EthToWei(32)
+	incomeGweiToWei        = big.NewInt(1e9)
+)
+
+// A time range during which a validator was active
+type incomeActivePeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// Get the node's earnings from the given minipools (or all of its minipools if none are given) over a time range,
+// split into consensus rewards, execution layer rewards and Smoothing Pool rewards
+func getIncomeStatement(c *cli.Context, minipoolAddresses []common.Address, startTime time.Time, endTime time.Time) (*api.MinipoolIncomeStatementResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolIncomeStatementResponse{
+		SmoothingPoolIntervals: []uint64{},
+		MissingIntervals:       []uint64{},
+		Minipools:              []api.MinipoolIncomeStatement{},
+	}
+
+	// Get the network state
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	eth2Config := networkState.BeaconConfig
+	nodeMinipools := networkState.MinipoolDetailsByNode[nodeAccount.Address]
+
+	// Get the time range, up to the current state
+	headTime := getSlotTime(eth2Config, networkState.BeaconSlotNumber)
+	if endTime.After(headTime) {
+		endTime = headTime
+	}
+	if !startTime.Before(endTime) {
+		return nil, fmt.Errorf("The start time (%s) must be before the end time (%s)", startTime, endTime)
+	}
+	response.StartTime = startTime
+	response.EndTime = endTime
+
+	// Get the minipools to report on
+	var selectedMinipools []*rpstate.NativeMinipoolDetails
+	if len(minipoolAddresses) == 0 {
+		selectedMinipools = nodeMinipools
+	} else {
+		minipoolsByAddress := map[common.Address]*rpstate.NativeMinipoolDetails{}
+		for _, mpd := range nodeMinipools {
+			minipoolsByAddress[mpd.MinipoolAddress] = mpd
+		}
+		for _, address := range minipoolAddresses {
+			mpd, exists := minipoolsByAddress[address]
+			if !exists {
+				return nil, fmt.Errorf("Minipool %s does not belong to the node", address.Hex())
+			}
+			selectedMinipools = append(selectedMinipools, mpd)
+		}
+	}
+
+	// Get the periods the node's validators were active for; execution layer rewards are attributed by active time
+	activePeriods := map[common.Address]incomeActivePeriod{}
+	for _, mpd := range nodeMinipools {
+		period, isActive := getIncomeActivePeriod(eth2Config, networkState.ValidatorDetails[mpd.Pubkey], startTime, endTime)
+		if isActive {
+			activePeriods[mpd.MinipoolAddress] = period
+		}
+	}
+
+	// Get the blocks at the start and end of the range
+	deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+	}
+	blockFinder := func(t time.Time) (uint64, error) {
+		return getLastBlockAtTime(rp, deployBlock.Uint64(), networkState.ElBlockNumber, t)
+	}
+	response.StartBlock, err = blockFinder(startTime)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the block at %s: %w", startTime, err)
+	}
+	response.EndBlock, err = blockFinder(endTime)
+	if err != nil {
+		return nil, fmt.Errorf("error finding the block at %s: %w", endTime, err)
+	}
+
+	// Get the blocks in which the minipools and the fee distributor sent transactions, so their outgoing ETH can be accounted for
+	response.FeeDistributorAddress, err = node.GetDistributorAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor address: %w", err)
+	}
+	addresses := []common.Address{response.FeeDistributorAddress}
+	for _, mpd := range selectedMinipools {
+		addresses = append(addresses, mpd.MinipoolAddress)
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	activityBlocks, err := getActivityBlocks(rp, addresses, response.StartBlock+1, response.EndBlock, uint64(eventLogInterval))
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the ETH the fee distributor received
+	response.FeeDistributorIncome, _, err = getIncomingEth(rp, response.FeeDistributorAddress, activityBlocks[response.FeeDistributorAddress], response.StartBlock, response.EndBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor income: %w", err)
+	}
+	totalActiveSeconds := int64(0)
+	for _, period := range activePeriods {
+		totalActiveSeconds += int64(period.end.Sub(period.start).Seconds())
+	}
+
+	// Get the Smoothing Pool rewards for the intervals that overlap the range
+	smoothingPoolShares := map[common.Address]*big.Int{}
+	smoothingPoolEstimated := map[common.Address]bool{}
+	currentIndex, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current rewards interval: %w", err)
+	}
+	for i := currentIndex.Uint64(); i > 0; i-- {
+		interval := i - 1
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, interval)
+		if err != nil {
+			return nil, fmt.Errorf("error getting info for rewards interval %d: %w", interval, err)
+		}
+		if !intervalInfo.EndTime.After(startTime) {
+			break
+		}
+		if !intervalInfo.StartTime.Before(endTime) {
+			continue
+		}
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+			response.MissingIntervals = append(response.MissingIntervals, interval)
+			continue
+		}
+		if !intervalInfo.NodeExists || intervalInfo.SmoothingPoolEthAmount == nil || intervalInfo.SmoothingPoolEthAmount.Sign() == 0 {
+			continue
+		}
+		response.SmoothingPoolIntervals = append(response.SmoothingPoolIntervals, interval)
+
+		// Only count the part of the interval that's in the range
+		overlapStart := maxTime(startTime, intervalInfo.StartTime)
+		overlapEnd := minTime(endTime, intervalInfo.EndTime)
+		overlapSeconds := big.NewInt(int64(overlapEnd.Sub(overlapStart).Seconds()))
+		intervalSeconds := big.NewInt(int64(intervalInfo.EndTime.Sub(intervalInfo.StartTime).Seconds()))
+		isPartial := overlapSeconds.Cmp(intervalSeconds) < 0
+
+		// Use the minipool performance file if it's available, otherwise split the node's share by active time.
+		// A minipool that isn't in the performance file didn't earn anything from the Smoothing Pool in that interval.
+		performance, performanceFound := loadMinipoolPerformance(cfg.Smartnode.GetMinipoolPerformancePath(interval, true))
+		intervalActivePeriods := map[common.Address]incomeActivePeriod{}
+		intervalActiveSeconds := int64(0)
+		for _, mpd := range nodeMinipools {
+			period, isActive := getIncomeActivePeriod(eth2Config, networkState.ValidatorDetails[mpd.Pubkey], intervalInfo.StartTime, intervalInfo.EndTime)
+			if isActive {
+				intervalActivePeriods[mpd.MinipoolAddress] = period
+				intervalActiveSeconds += int64(period.end.Sub(period.start).Seconds())
+			}
+		}
+		for _, mpd := range selectedMinipools {
+			share := big.NewInt(0)
+			if performanceFound {
+				if minipoolPerformance, exists := performance[mpd.MinipoolAddress]; exists {
+					share = eth.EthToWei(minipoolPerformance.EthEarned)
+				}
+			} else if period, exists := intervalActivePeriods[mpd.MinipoolAddress]; exists && intervalActiveSeconds > 0 {
+				share.Mul(&intervalInfo.SmoothingPoolEthAmount.Int, big.NewInt(int64(period.end.Sub(period.start).Seconds())))
+				share.Div(share, big.NewInt(intervalActiveSeconds))
+				smoothingPoolEstimated[mpd.MinipoolAddress] = true
+			}
+			if isPartial && intervalSeconds.Sign() > 0 {
+				share.Mul(share, overlapSeconds)
+				share.Div(share, intervalSeconds)
+				smoothingPoolEstimated[mpd.MinipoolAddress] = true
+			}
+			if _, exists := smoothingPoolShares[mpd.MinipoolAddress]; !exists {
+				smoothingPoolShares[mpd.MinipoolAddress] = big.NewInt(0)
+			}
+			smoothingPoolShares[mpd.MinipoolAddress].Add(smoothingPoolShares[mpd.MinipoolAddress], share)
+		}
+	}
+
+	// Build the statement for each minipool
+	for _, mpd := range selectedMinipools {
+		statement := api.MinipoolIncomeStatement{
+			Address:                mpd.MinipoolAddress,
+			Pubkey:                 mpd.Pubkey,
+			NodeBond:               mpd.NodeDepositBalance,
+			NodeFee:                eth.WeiToEth(mpd.NodeFee),
+			StartBeaconBalance:     big.NewInt(0),
+			EndBeaconBalance:       big.NewInt(0),
+			Withdrawals:            big.NewInt(0),
+			Distributed:            big.NewInt(0),
+			ConsensusRewards:       big.NewInt(0),
+			ConsensusNodeShare:     big.NewInt(0),
+			ConsensusCommission:    big.NewInt(0),
+			Penalties:              big.NewInt(0),
+			ExecutionRewards:       big.NewInt(0),
+			ExecutionNodeShare:     big.NewInt(0),
+			ExecutionCommission:    big.NewInt(0),
+			SmoothingPoolNodeShare: big.NewInt(0),
+			SmoothingPoolEstimated: smoothingPoolEstimated[mpd.MinipoolAddress],
+			TotalNodeShare:         big.NewInt(0),
+		}
+		if statement.NodeBond == nil {
+			statement.NodeBond = big.NewInt(0)
+		}
+		if share, exists := smoothingPoolShares[mpd.MinipoolAddress]; exists {
+			statement.SmoothingPoolNodeShare = share
+		}
+
+		// Start the statement when the validator activated if that was during the range
+		period, isActive := activePeriods[mpd.MinipoolAddress]
+		statement.Active = isActive
+		statement.StartTime = startTime
+		statement.EndTime = endTime
+		if isActive {
+			statement.StartTime = period.start
+			minipoolStartBlock := response.StartBlock
+			if period.start.After(startTime) {
+				minipoolStartBlock, err = blockFinder(period.start)
+				if err != nil {
+					return nil, fmt.Errorf("error finding the block at %s: %w", period.start, err)
+				}
+			}
+
+			// Get the beacon balances at the start and end of the range
+			statement.StartBeaconBalance, err = getHistoricalBeaconBalance(bc, eth2Config, mpd, period.start)
+			if err != nil {
+				return nil, err
+			}
+			statement.EndBeaconBalance, err = getHistoricalBeaconBalance(bc, eth2Config, mpd, endTime)
+			if err != nil {
+				return nil, err
+			}
+
+			// Get the withdrawals from the beacon chain, which is everything the minipool received that it didn't get from a transaction
+			statement.Withdrawals, statement.Distributed, err = getIncomingEth(rp, mpd.MinipoolAddress, activityBlocks[mpd.MinipoolAddress], minipoolStartBlock, response.EndBlock)
+			if err != nil {
+				return nil, fmt.Errorf("error getting withdrawals for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+			}
+
+			// Get the consensus rewards; the node's bond absorbs any losses before the pool stakers do
+			statement.ConsensusRewards.Sub(statement.EndBeaconBalance, statement.StartBeaconBalance)
+			statement.ConsensusRewards.Add(statement.ConsensusRewards, statement.Withdrawals)
+			if statement.ConsensusRewards.Sign() >= 0 {
+				statement.ConsensusNodeShare, statement.ConsensusCommission = getNodeRewardShare(statement.ConsensusRewards, statement.NodeBond, mpd.NodeFee)
+			} else {
+				statement.Penalties.Neg(statement.ConsensusRewards)
+				if statement.Penalties.Cmp(statement.NodeBond) > 0 {
+					statement.Penalties.Set(statement.NodeBond)
+				}
+				statement.ConsensusNodeShare.Neg(statement.Penalties)
+			}
+
+			// Attribute the fee distributor's income by active time
+			if totalActiveSeconds > 0 {
+				statement.ExecutionRewards.Mul(response.FeeDistributorIncome, big.NewInt(int64(period.end.Sub(period.start).Seconds())))
+				statement.ExecutionRewards.Div(statement.ExecutionRewards, big.NewInt(totalActiveSeconds))
+				statement.ExecutionNodeShare, statement.ExecutionCommission = getNodeRewardShare(statement.ExecutionRewards, statement.NodeBond, mpd.NodeFee)
+			}
+		}
+
+		statement.TotalNodeShare.Add(statement.ConsensusNodeShare, statement.ExecutionNodeShare)
+		statement.TotalNodeShare.Add(statement.TotalNodeShare, statement.SmoothingPoolNodeShare)
+		response.Minipools = append(response.Minipools, statement)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the part of the given range that a validator was active for
+func getIncomeActivePeriod(eth2Config beacon.Eth2Config, validator beacon.ValidatorStatus, startTime time.Time, endTime time.Time) (incomeActivePeriod, bool) {
+	if !validator.Exists || validator.ActivationEpoch == timelineFarFutureEpoch {
+		return incomeActivePeriod{}, false
+	}
+	period := incomeActivePeriod{
+		start: maxTime(startTime, getEpochTime(eth2Config, validator.ActivationEpoch)),
+		end:   endTime,
+	}
+	if validator.ExitEpoch != timelineFarFutureEpoch {
+		period.end = minTime(endTime, getEpochTime(eth2Config, validator.ExitEpoch))
+	}
+	if !period.start.Before(period.end) {
+		return incomeActivePeriod{}, false
+	}
+	return period, true
+}
+
+// Get the last block with a timestamp at or before the given time
+func getLastBlockAtTime(rp *rocketpool.RocketPool, low uint64, high uint64, targetTime time.Time) (uint64, error) {
+	block, err := getFirstBlockAtTime(rp, low, high, targetTime.Add(time.Second))
+	if err != nil {
+		return 0, err
+	}
+	header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block))
+	if err != nil {
+		return 0, fmt.Errorf("error getting header for block %d: %w", block, err)
+	}
+	if header.Time > uint64(targetTime.Unix()) && block > low {
+		block--
+	}
+	return block, nil
+}
+
+// Get the blocks that contain events emitted by each of the given addresses
+func getActivityBlocks(rp *rocketpool.RocketPool, addresses []common.Address, startBlock uint64, endBlock uint64, eventLogInterval uint64) (map[common.Address][]uint64, error) {
+	activityBlocks := map[common.Address][]uint64{}
+	for from := startBlock; from <= endBlock; from += eventLogInterval {
+		to := from + eventLogInterval - 1
+		if to > endBlock {
+			to = endBlock
+		}
+		logs, err := rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: big.NewInt(0).SetUint64(from),
+			ToBlock:   big.NewInt(0).SetUint64(to),
+			Addresses: addresses,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error getting event logs for blocks %d to %d: %w", from, to, err)
+		}
+		for _, log := range logs {
+			blocks := activityBlocks[log.Address]
+			if len(blocks) == 0 || blocks[len(blocks)-1] != log.BlockNumber {
+				activityBlocks[log.Address] = append(blocks, log.BlockNumber)
+			}
+		}
+	}
+	return activityBlocks, nil
+}
+
+// Get the ETH an address sent out in the given blocks (after the start block, up to and including the end block)
+func getOutgoingEth(rp *rocketpool.RocketPool, address common.Address, blocks []uint64, startBlock uint64, endBlock uint64) (*big.Int, error) {
+	outgoing := big.NewInt(0)
+	for _, block := range blocks {
+		if block <= startBlock || block > endBlock {
+			continue
+		}
+		before, err := rp.Client.BalanceAt(context.Background(), address, big.NewInt(0).SetUint64(block-1))
+		if err != nil {
+			return nil, fmt.Errorf("error getting balance at block %d (this requires an archive node): %w", block-1, err)
+		}
+		after, err := rp.Client.BalanceAt(context.Background(), address, big.NewInt(0).SetUint64(block))
+		if err != nil {
+			return nil, fmt.Errorf("error getting balance at block %d (this requires an archive node): %w", block, err)
+		}
+		if before.Cmp(after) > 0 {
+			outgoing.Add(outgoing, big.NewInt(0).Sub(before, after))
+		}
+	}
+	return outgoing, nil
+}
+
+// Get the ETH an address received over a block range, which is its change in balance plus anything it sent out.
+// Also returns the ETH it sent out.
+func getIncomingEth(rp *rocketpool.RocketPool, address common.Address, blocks []uint64, startBlock uint64, endBlock uint64) (*big.Int, *big.Int, error) {
+	startBalance, err := rp.Client.BalanceAt(context.Background(), address, big.NewInt(0).SetUint64(startBlock))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting balance at block %d (this requires an archive node): %w", startBlock, err)
+	}
+	endBalance, err := rp.Client.BalanceAt(context.Background(), address, big.NewInt(0).SetUint64(endBlock))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting balance at block %d (this requires an archive node): %w", endBlock, err)
+	}
+	outgoing, err := getOutgoingEth(rp, address, blocks, startBlock, endBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+	incoming := big.NewInt(0).Sub(endBalance, startBalance)
+	return incoming.Add(incoming, outgoing), outgoing, nil
+}
+
+// Get a validator's beacon balance (in wei) at the given time
+func getHistoricalBeaconBalance(bc beacon.Client, eth2Config beacon.Eth2Config, mpd *rpstate.NativeMinipoolDetails, t time.Time) (*big.Int, error) {
+	slot := (uint64(t.Unix()) - eth2Config.GenesisTime) / eth2Config.SecondsPerSlot
+	status, err := bc.GetValidatorStatus(mpd.Pubkey, &beacon.ValidatorStatusOptions{Slot: &slot})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the status of validator %s at slot %d (this may require an archive beacon node): %w", mpd.Pubkey.Hex(), slot, err)
+	}
+	if !status.Exists {
+		return big.NewInt(0), nil
+	}
+	balance := big.NewInt(0).SetUint64(status.Balance)
+	return balance.Mul(balance, incomeGweiToWei), nil
+}
+
+// Get the node's share of a minipool's rewards, and the part of that share that's commission on the pool stakers' portion
+func getNodeRewardShare(amount *big.Int, nodeBond *big.Int, nodeFee *big.Int) (*big.Int, *big.Int) {
+	userShare := big.NewInt(0).Sub(incomeValidatorBalance, nodeBond)
+	userShare.Mul(userShare, amount)
+	userShare.Div(userShare, incomeValidatorBalance)
+
+	commission := big.NewInt(0).Mul(userShare, nodeFee)
+	commission.Div(commission, eth.EthToWei(1))
+
+	nodeShare := big.NewInt(0).Sub(amount, userShare)
+	nodeShare.Add(nodeShare, commission)
+	return nodeShare, commission
+}
+
+// Load a minipool performance file for a rewards interval, returning false if it hasn't been saved locally
+func loadMinipoolPerformance(path string) (map[common.Address]*rprewards.SmoothingPoolMinipoolPerformance, bool) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var performanceFile rprewards.MinipoolPerformanceFile
+	if err := json.Unmarshal(bytes, &performanceFile); err != nil {
+		return nil, false
+	}
+	return performanceFile.MinipoolPerformance, true
+}
+
+// Get the start time of a slot
+func getSlotTime(eth2Config beacon.Eth2Config, slot uint64) time.Time {
+	return time.Unix(int64(eth2Config.GenesisTime+slot*eth2Config.SecondsPerSlot), 0)
+}
+
+// Get the start time of an epoch
+func getEpochTime(eth2Config beacon.Eth2Config, epoch uint64) time.Time {
+	return time.Unix(int64(eth2Config.GenesisTime+epoch*eth2Config.SecondsPerEpoch), 0)
+}
+
+func minTime(first time.Time, second time.Time) time.Time {
+	if first.Before(second) {
+		return first
+	}
+	return second
+}
+
+func maxTime(first time.Time, second time.Time) time.Time {
+	if first.After(second) {
+		return first
+	}
+	return second
+}
@@ -0,0 +1,345 @@
+package minipool
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/migrations"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getSoloMigrations(c *cli.Context) (*api.SoloMigrationsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SoloMigrationsResponse{
+		Migrations: []api.SoloMigrationDetails{},
+	}
+
+	// Get the network state
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	genesisTime := time.Unix(int64(networkState.BeaconConfig.GenesisTime), 0)
+	blockTime := genesisTime.Add(time.Duration(networkState.BeaconSlotNumber*networkState.BeaconConfig.SecondsPerSlot) * time.Second)
+
+	// Get the validator keys the node has
+	storedKeys := map[rptypes.ValidatorPubkey]bool{}
+	storedPubkeys, _ := w.GetStoredValidatorPubkeys()
+	for _, pubkeys := range storedPubkeys {
+		for _, pubkey := range pubkeys {
+			storedKeys[pubkey] = true
+		}
+	}
+
+	// Get the validators being migrated; the network state only has the validators of the node's minipools, so the ones
+	// that don't have a minipool yet need to be fetched separately
+	store := getSoloMigrationStore(cfg)
+	soloMigrations, err := store.GetMigrations()
+	if err != nil {
+		return nil, err
+	}
+	validators := map[rptypes.ValidatorPubkey]beacon.ValidatorStatus{}
+	missingPubkeys := []rptypes.ValidatorPubkey{}
+	for _, migration := range soloMigrations {
+		if validator, exists := networkState.ValidatorDetails[migration.Pubkey]; exists {
+			validators[migration.Pubkey] = validator
+		} else {
+			missingPubkeys = append(missingPubkeys, migration.Pubkey)
+		}
+	}
+	if len(missingPubkeys) > 0 {
+		statuses, err := bc.GetValidatorStatuses(missingPubkeys, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting validator statuses: %w", err)
+		}
+		for pubkey, status := range statuses {
+			validators[pubkey] = status
+		}
+	}
+
+	// Work out the current stage of each migration and save any transitions
+	err = store.Update(func(soloMigrations []*migrations.SoloMigration) ([]*migrations.SoloMigration, error) {
+		now := time.Now()
+		for _, migration := range soloMigrations {
+			details := api.SoloMigrationDetails{
+				Pubkey:      migration.Pubkey,
+				ImportKey:   migration.ImportKey,
+				KeyImported: storedKeys[migration.Pubkey],
+				StartedTime: migration.StartedTime,
+				Blockers:    []string{},
+			}
+			stage := getSoloMigrationStage(migration, validators[migration.Pubkey], networkState, nodeAccount.Address, blockTime, &details)
+			if !migration.Stage.IsFinished() {
+				migration.SetStage(stage, now)
+			}
+			details.MinipoolAddress = migration.MinipoolAddress
+			details.Stage = migration.Stage
+			details.StageTime = migration.GetStageTime()
+			response.Migrations = append(response.Migrations, details)
+		}
+		return soloMigrations, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func startSoloMigration(c *cli.Context, pubkey rptypes.ValidatorPubkey, importKey bool) (*api.StartSoloMigrationResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.StartSoloMigrationResponse{}
+
+	// Check the validator
+	validatorStatus, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking status of existing validator: %w", err)
+	}
+	if !validatorStatus.Exists {
+		return nil, fmt.Errorf("validator %s does not exist on the Beacon chain. If you recently created it, please wait until the Consensus layer has processed your deposits.", pubkey.Hex())
+	}
+
+	// Check for an existing minipool, in case the migration was started with `node create-vacant-minipool`
+	minipoolAddress, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+	if err != nil {
+		return nil, err
+	}
+	if minipoolAddress != (common.Address{}) {
+		mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+		if err != nil {
+			return nil, err
+		}
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+			return nil, err
+		}
+	} else if blockers := getSoloMigrationValidatorBlockers(validatorStatus); len(blockers) > 0 {
+		return nil, fmt.Errorf("validator %s can't be migrated:\n%s", pubkey.Hex(), strings.Join(blockers, "\n"))
+	}
+
+	// Save the migration
+	store := getSoloMigrationStore(cfg)
+	err = store.Update(func(soloMigrations []*migrations.SoloMigration) ([]*migrations.SoloMigration, error) {
+		remaining := make([]*migrations.SoloMigration, 0, len(soloMigrations)+1)
+		for _, migration := range soloMigrations {
+			if migration.Pubkey != pubkey {
+				remaining = append(remaining, migration)
+				continue
+			}
+			if !migration.Stage.IsFinished() {
+				return nil, fmt.Errorf("validator %s is already being migrated (stage: %s)", pubkey.Hex(), migration.Stage)
+			}
+		}
+
+		now := time.Now()
+		migration := &migrations.SoloMigration{
+			Pubkey:          pubkey,
+			MinipoolAddress: minipoolAddress,
+			ImportKey:       importKey,
+			StartedTime:     now,
+		}
+		migration.SetStage(migrations.SoloMigrationStage_CreateMinipool, now)
+		return append(remaining, migration), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func forgetSoloMigration(c *cli.Context, pubkey rptypes.ValidatorPubkey) (*api.ForgetSoloMigrationResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ForgetSoloMigrationResponse{}
+
+	// Remove the migration
+	store := getSoloMigrationStore(cfg)
+	err = store.Update(func(soloMigrations []*migrations.SoloMigration) ([]*migrations.SoloMigration, error) {
+		remaining := make([]*migrations.SoloMigration, 0, len(soloMigrations))
+		for _, migration := range soloMigrations {
+			if migration.Pubkey != pubkey {
+				remaining = append(remaining, migration)
+			}
+		}
+		if len(remaining) == len(soloMigrations) {
+			return nil, fmt.Errorf("validator %s is not being migrated", pubkey.Hex())
+		}
+		return remaining, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the store for the node's solo migrations
+func getSoloMigrationStore(cfg *config.RocketPoolConfig) *migrations.SoloMigrationStore {
+	return migrations.NewSoloMigrationStore(os.ExpandEnv(cfg.Smartnode.GetSoloMigrationsPath()))
+}
+
+// Work out which stage a migration is in from the network state, filling in its details and anything blocking it
+func getSoloMigrationStage(migration *migrations.SoloMigration, validator beacon.ValidatorStatus, networkState *state.NetworkState, nodeAddress common.Address, blockTime time.Time, details *api.SoloMigrationDetails) migrations.SoloMigrationStage {
+
+	// Get the validator
+	details.ValidatorIndex = validator.Index
+	details.ValidatorStatus = validator.Status
+	details.WithdrawalCredentials = validator.WithdrawalCredentials
+
+	// Find the minipool
+	var mpd *rpstate.NativeMinipoolDetails
+	for _, candidate := range networkState.MinipoolDetailsByNode[nodeAddress] {
+		if candidate.Pubkey == migration.Pubkey {
+			mpd = candidate
+			break
+		}
+	}
+
+	// Check the validator can be migrated before the minipool is created
+	if mpd == nil {
+		details.Blockers = append(details.Blockers, getSoloMigrationValidatorBlockers(validator)...)
+		return migrations.SoloMigrationStage_CreateMinipool
+	}
+	migration.MinipoolAddress = mpd.MinipoolAddress
+
+	// Handle minipools that are done
+	switch mpd.Status {
+	case rptypes.Staking:
+		return migrations.SoloMigrationStage_Complete
+	case rptypes.Dissolved:
+		details.Blockers = append(details.Blockers, "The minipool was dissolved, most likely because the Oracle DAO scrubbed the migration.")
+		return migrations.SoloMigrationStage_Failed
+	}
+	if mpd.Status != rptypes.Prelaunch || !mpd.IsVacant {
+		details.Blockers = append(details.Blockers, fmt.Sprintf("Minipool %s is not a vacant minipool awaiting migration (status: %s).", mpd.MinipoolAddress.Hex(), mpd.Status.String()))
+		return migrations.SoloMigrationStage_Failed
+	}
+
+	// Check the minipool with the Oracle DAO's rules
+	check := migrations.CheckVacantMinipool(mpd, validator, networkState.NetworkDetails.PromotionScrubPeriod, blockTime)
+	details.CredsDeadline = check.CredsDeadline
+	details.PromotionTime = check.PromotionTime
+	if check.ScrubReason != "" {
+		details.Blockers = append(details.Blockers, fmt.Sprintf("The Oracle DAO will scrub this minipool: %s.", check.ScrubReason))
+	}
+
+	// Walk through the remaining stages
+	if !check.CredsChanged {
+		return migrations.SoloMigrationStage_ChangeWithdrawalCreds
+	}
+	if migration.ImportKey && !details.KeyImported {
+		return migrations.SoloMigrationStage_ImportKey
+	}
+	if blockTime.Before(check.PromotionTime) {
+		return migrations.SoloMigrationStage_ScrubCheck
+	}
+	return migrations.SoloMigrationStage_Promote
+
+}
+
+// Get the reasons a solo validator can't be migrated into a vacant minipool yet, if there are any
+func getSoloMigrationValidatorBlockers(validator beacon.ValidatorStatus) []string {
+	if !validator.Exists {
+		return []string{"The validator does not exist on the Beacon chain yet."}
+	}
+	blockers := []string{}
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		blockers = append(blockers, fmt.Sprintf("The validator must be in the active_ongoing state to be migrated, but it is currently in %s.", validator.Status))
+	}
+	if validator.WithdrawalCredentials[0] != 0x00 {
+		blockers = append(blockers, fmt.Sprintf("The validator already has withdrawal credentials [%s], which are not BLS credentials.", validator.WithdrawalCredentials.Hex()))
+	}
+	if validator.Balance < migrations.MigrationBalanceThreshold {
+		blockers = append(blockers, fmt.Sprintf("The validator's balance of %d gwei is lower than the threshold of %d gwei.", validator.Balance, migrations.MigrationBalanceThreshold))
+	}
+	return blockers
+}
@@ -0,0 +1,309 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Config
+const (
+	bondReductionOldBondEth float64 = 16
+	bondReductionNewBondEth float64 = 8
+	bondReductionMinBalance uint64  = 32000000000 // gwei
+)
+
+// Simulate reducing the bonds of the given minipools (or all of the node's 16 ETH minipools if none are given) from 16 ETH to 8 ETH
+func planBondReduction(c *cli.Context, minipoolAddresses []common.Address) (*api.PlanBondReductionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PlanBondReductionResponse{}
+
+	// Get the network state
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	if !networkState.IsAtlasDeployed {
+		return nil, fmt.Errorf("Minipool bonds cannot be reduced until Atlas has been deployed.")
+	}
+	nodeDetails := networkState.NodeDetailsByAddress[nodeAccount.Address]
+	nodeMinipools := networkState.MinipoolDetailsByNode[nodeAccount.Address]
+	oldBond := eth.EthToWei(bondReductionOldBondEth)
+	response.NewBond = eth.EthToWei(bondReductionNewBondEth)
+	response.NetworkNodeFee = networkState.NetworkDetails.NodeFee
+	response.WindowStart = networkState.NetworkDetails.BondReductionWindowStart
+	response.WindowLength = networkState.NetworkDetails.BondReductionWindowLength
+	response.RplPrice = networkState.NetworkDetails.RplPrice
+	response.RplStake = nodeDetails.RplStake
+	response.CurrentCreditBalance = nodeDetails.DepositCreditBalance
+
+	// Get the minipools to plan for
+	candidates := []*rpstate.NativeMinipoolDetails{}
+	if len(minipoolAddresses) == 0 {
+		for _, mpd := range nodeMinipools {
+			if !mpd.Finalised && mpd.NodeDepositBalance.Cmp(oldBond) == 0 {
+				candidates = append(candidates, mpd)
+			}
+		}
+	} else {
+		nodeMinipoolsByAddress := map[common.Address]*rpstate.NativeMinipoolDetails{}
+		for _, mpd := range nodeMinipools {
+			nodeMinipoolsByAddress[mpd.MinipoolAddress] = mpd
+		}
+		for _, address := range minipoolAddresses {
+			mpd, exists := nodeMinipoolsByAddress[address]
+			if !exists {
+				return nil, fmt.Errorf("Minipool %s does not belong to the node", address.Hex())
+			}
+			candidates = append(candidates, mpd)
+		}
+	}
+
+	// Data
+	var wg errgroup.Group
+	reduceBondTimes := make([]time.Time, len(candidates))
+	reduceBondCancelled := make([]bool, len(candidates))
+	gasInfos := make([]rocketpool.GasInfo, len(candidates))
+	gasErrors := make([]error, len(candidates))
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if bond reduction is enabled
+	wg.Go(func() error {
+		bondReductionEnabled, err := protocol.GetBondReductionEnabled(rp, nil)
+		if err != nil {
+			return fmt.Errorf("error checking if bond reduction is enabled: %w", err)
+		}
+		response.BondReductionDisabled = !bondReductionEnabled
+		return nil
+	})
+
+	// Get the node's collateral
+	wg.Go(func() error {
+		var err error
+		response.EthMatched, response.EthMatchedLimit, response.PendingMatchAmount, err = rputils.CheckCollateral(rp, nodeAccount.Address, nil)
+		return err
+	})
+
+	// Get the bond reduction status and gas estimate of each minipool
+	for i, mpd := range candidates {
+		i := i
+		address := mpd.MinipoolAddress
+		wg.Go(func() error {
+			var err error
+			reduceBondTimes[i], err = minipool.GetReduceBondTime(rp, address, nil)
+			if err != nil {
+				return fmt.Errorf("error getting bond reduction time for minipool %s: %w", address.Hex(), err)
+			}
+			reduceBondCancelled[i], err = minipool.GetReduceBondCancelled(rp, address, nil)
+			if err != nil {
+				return fmt.Errorf("error checking if minipool %s had its bond reduction cancelled: %w", address.Hex(), err)
+			}
+			gasInfos[i], gasErrors[i] = minipool.EstimateBeginReduceBondAmountGas(rp, address, response.NewBond, opts)
+			return nil
+		})
+	}
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Check each minipool
+	reductionTimeout := response.WindowStart + response.WindowLength
+	response.Minipools = make([]api.BondReductionPlanMinipool, len(candidates))
+	for i, mpd := range candidates {
+		plan := &response.Minipools[i]
+		plan.Address = mpd.MinipoolAddress
+		plan.CurrentBond = mpd.NodeDepositBalance
+		plan.CurrentFee = eth.WeiToEth(mpd.NodeFee)
+		plan.GasInfo = gasInfos[i]
+
+		// The contract raises the commission to the network's current rate if it's higher
+		plan.NewFee = plan.CurrentFee
+		if response.NetworkNodeFee > plan.NewFee {
+			plan.NewFee = response.NetworkNodeFee
+		}
+
+		validator, exists := networkState.ValidatorDetails[mpd.Pubkey]
+		exists = exists && validator.Exists
+		if exists {
+			plan.BeaconBalance = validator.Balance
+			plan.BeaconState = validator.Status
+		}
+
+		reasons := []string{}
+		if mpd.NodeDepositBalance.Cmp(oldBond) != 0 {
+			reasons = append(reasons, fmt.Sprintf("its bond is %.0f ETH, not %.0f ETH", eth.WeiToEth(mpd.NodeDepositBalance), bondReductionOldBondEth))
+		}
+		if mpd.Finalised || mpd.Status != types.Staking {
+			reasons = append(reasons, "it is not staking")
+		}
+		if mpd.Version < 3 {
+			reasons = append(reasons, "its delegate is too old; upgrade it with `rocketpool minipool delegate-upgrade` first")
+		}
+		if reduceBondCancelled[i] {
+			reasons = append(reasons, "a previous bond reduction was scrubbed by the Oracle DAO")
+		} else if reduceBondTimes[i].Unix() != 0 && time.Since(reduceBondTimes[i]) < reductionTimeout {
+			reasons = append(reasons, "a bond reduction is already in progress")
+		}
+		if !exists {
+			reasons = append(reasons, "its validator hasn't been seen on the Beacon Chain yet")
+		} else {
+			if !(validator.Status == beacon.ValidatorState_PendingInitialized ||
+				validator.Status == beacon.ValidatorState_PendingQueued ||
+				validator.Status == beacon.ValidatorState_ActiveOngoing) {
+				reasons = append(reasons, fmt.Sprintf("its validator must be pending or active (it is %s)", validator.Status))
+			}
+			if validator.Balance < bondReductionMinBalance {
+				reasons = append(reasons, fmt.Sprintf("its Beacon Chain balance is below 32 ETH (%.6f ETH)", float64(validator.Balance)/1e9))
+			}
+		}
+
+		// The gas estimate fails for the reasons above, so it only says something new if there aren't any
+		if len(reasons) == 0 && !response.BondReductionDisabled && gasErrors[i] != nil {
+			reasons = append(reasons, fmt.Sprintf("the bond reduction would fail: %s", gasErrors[i].Error()))
+		}
+		plan.Reasons = reasons
+		plan.CanReduce = len(reasons) == 0 && !response.BondReductionDisabled
+	}
+
+	// Order the reductions by how much they raise the node's share of the validator rewards: on each minipool the node
+	// goes from earning on 16 ETH plus the commission on 16 ETH to earning on 8 ETH plus the new commission on 24 ETH
+	order := []int{}
+	for i, plan := range response.Minipools {
+		if plan.CanReduce {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a int, b int) bool {
+		planA := response.Minipools[order[a]]
+		planB := response.Minipools[order[b]]
+		return getBondReductionRewardShare(planA) > getBondReductionRewardShare(planB)
+	})
+
+	// Include as many as the node's RPL stake can cover
+	remainingMatch := big.NewInt(0).Sub(response.EthMatchedLimit, response.EthMatched)
+	remainingMatch.Sub(remainingMatch, response.PendingMatchAmount)
+	matchRequest := big.NewInt(0).Sub(oldBond, response.NewBond)
+	includedCount := 0
+	for _, i := range order {
+		plan := &response.Minipools[i]
+		if remainingMatch.Cmp(matchRequest) < 0 {
+			plan.ExcludedByCollateral = true
+			continue
+		}
+		remainingMatch.Sub(remainingMatch, matchRequest)
+		includedCount++
+		plan.Included = true
+		plan.Order = includedCount
+	}
+
+	// Get the node's borrowed and bonded ETH before and after the reductions
+	response.CurrentBorrowedEth = big.NewInt(0)
+	response.CurrentBondedEth = big.NewInt(0)
+	for _, mpd := range nodeMinipools {
+		if mpd.Status == types.Staking && !mpd.Finalised {
+			response.CurrentBorrowedEth.Add(response.CurrentBorrowedEth, mpd.UserDepositBalance)
+			response.CurrentBondedEth.Add(response.CurrentBondedEth, mpd.NodeDepositBalance)
+		}
+	}
+	totalMatchRequest := big.NewInt(0).Mul(matchRequest, big.NewInt(int64(includedCount)))
+	response.NewBorrowedEth = big.NewInt(0).Add(response.CurrentBorrowedEth, totalMatchRequest)
+	response.NewBondedEth = big.NewInt(0).Sub(response.CurrentBondedEth, totalMatchRequest)
+	response.NewCreditBalance = big.NewInt(0).Add(response.CurrentCreditBalance, totalMatchRequest)
+
+	// Get the collateral ratio and effective stake before and after the reductions
+	response.CurrentCollateralRatio = getCollateralRatio(response.RplStake, response.RplPrice, response.CurrentBorrowedEth)
+	response.NewCollateralRatio = getCollateralRatio(response.RplStake, response.RplPrice, response.NewBorrowedEth)
+	response.CurrentEffectiveStake = getEffectiveRplStake(networkState.NetworkDetails, response.RplStake, response.CurrentBorrowedEth, response.CurrentBondedEth)
+	response.NewEffectiveStake = getEffectiveRplStake(networkState.NetworkDetails, response.RplStake, response.NewBorrowedEth, response.NewBondedEth)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the change in the node's share of a minipool's rewards (as a multiple of the reward per ETH) from reducing its bond
+func getBondReductionRewardShare(plan api.BondReductionPlanMinipool) float64 {
+	oldShare := bondReductionOldBondEth + (32-bondReductionOldBondEth)*plan.CurrentFee
+	newShare := bondReductionNewBondEth + (32-bondReductionNewBondEth)*plan.NewFee
+	return newShare - oldShare
+}
+
+// Get the value of the node's RPL stake as a fraction of the ETH it has borrowed
+func getCollateralRatio(rplStake *big.Int, rplPrice *big.Int, borrowedEth *big.Int) float64 {
+	if borrowedEth.Sign() == 0 {
+		return 0
+	}
+	return eth.WeiToEth(rplStake) * eth.WeiToEth(rplPrice) / eth.WeiToEth(borrowedEth)
+}
+
+// Get the node's effective RPL stake, which is zero below the minimum collateral and capped at the maximum
+func getEffectiveRplStake(details *rpstate.NetworkDetails, rplStake *big.Int, borrowedEth *big.Int, bondedEth *big.Int) *big.Int {
+	// The collateral fractions and the RPL price are both scaled by 1e18, so they cancel out
+	minCollateral := big.NewInt(0).Mul(borrowedEth, details.MinCollateralFraction)
+	minCollateral.Div(minCollateral, details.RplPrice)
+	maxCollateral := big.NewInt(0).Mul(bondedEth, details.MaxCollateralFraction)
+	maxCollateral.Div(maxCollateral, details.RplPrice)
+
+	effectiveStake := big.NewInt(0).Set(rplStake)
+	if effectiveStake.Cmp(minCollateral) < 0 {
+		effectiveStake.SetUint64(0)
+	} else if effectiveStake.Cmp(maxCollateral) > 0 {
+		effectiveStake.Set(maxCollateral)
+	}
+	return effectiveStake
+}
@@ -0,0 +1,137 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func presignExit(c *cli.Context, minipoolAddress common.Address) (*api.PresignExitResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PresignExitResponse{}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Check minipool status
+	status, err := mp.GetStatus(nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != types.Staking {
+		return nil, fmt.Errorf("minipool %s is not staking", minipoolAddress.Hex())
+	}
+
+	// Get minipool validator pubkey
+	validatorPubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get validator private key
+	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get beacon head
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get voluntary exit signature domain; EIP-7044 locks it to the Capella fork so the exit stays valid after later forks
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	if len(eth2Config.CapellaForkVersion) == 0 {
+		return nil, fmt.Errorf("the Beacon Node did not provide the Capella fork version, so a pre-signed exit can't be created")
+	}
+	signatureDomain := eth2types.Domain(eth2types.DomainVoluntaryExit, eth2Config.CapellaForkVersion, eth2Config.GenesisValidatorsRoot)
+
+	// Get validator index
+	validatorIndex, err := bc.GetValidatorIndex(validatorPubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get signed voluntary exit message
+	signature, err := validator.GetSignedExitMessage(validatorKey, validatorIndex, head.Epoch, signatureDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update & return response
+	response.ValidatorPubkey = validatorPubkey
+	response.ValidatorIndex = validatorIndex
+	response.Epoch = head.Epoch
+	response.Signature = signature
+	return &response, nil
+
+}
+
+func broadcastExit(c *cli.Context, validatorIndex uint64, epoch uint64, signature types.ValidatorSignature) (*api.BroadcastExitResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastExitResponse{}
+
+	// Broadcast voluntary exit message
+	if err := bc.ExitValidator(validatorIndex, epoch, signature); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,453 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Config
+const (
+	timelineSource_Minipool string = "minipool"
+	timelineSource_Network  string = "network"
+	timelineSource_Beacon   string = "beacon"
+	timelineFarFutureEpoch  uint64 = 18446744073709551615
+)
+
+// The contracts whose events make up a minipool's lifecycle.
+// Minipools delegate to these, so their events are emitted from the minipool's own address.
+var timelineMinipoolContracts = []string{
+	"rocketMinipoolDelegate",
+	"rocketMinipoolBase",
+	"rocketMinipool",
+}
+
+// Network contracts that emit events with the minipool address as the first indexed topic
+var timelineNetworkContracts = []string{
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketMinipoolBondReducer",
+	"rocketDepositPool",
+}
+
+// A contract that can emit events for the timeline
+type timelineContract struct {
+	name   string
+	events map[common.Hash]abi.Event
+}
+
+// Assemble the minipool's contract events, the related network and node events, and its validator's status transitions into one timeline
+func getMinipoolTimeline(c *cli.Context, minipoolAddress common.Address, startBlock uint64) (*api.MinipoolTimelineResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolTimelineResponse{
+		MinipoolAddress: minipoolAddress,
+		Events:          []api.MinipoolTimelineEvent{},
+	}
+
+	// Get the network state
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	var mpd *rpstate.NativeMinipoolDetails
+	for _, details := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		if details.MinipoolAddress == minipoolAddress {
+			mpd = details
+			break
+		}
+	}
+	if mpd == nil {
+		return nil, fmt.Errorf("Minipool %s does not belong to the node", minipoolAddress.Hex())
+	}
+	response.Pubkey = mpd.Pubkey
+
+	// Get the block range to scan; by default, start when the node registered since its minipools can't be any older
+	response.EndBlock = networkState.ElBlockNumber
+	if startBlock == 0 {
+		deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+		if err != nil {
+			return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+		}
+		registrationTime := time.Unix(networkState.NodeDetailsByAddress[nodeAccount.Address].RegistrationTime.Int64(), 0)
+		startBlock, err = getFirstBlockAtTime(rp, deployBlock.Uint64(), response.EndBlock, registrationTime)
+		if err != nil {
+			return nil, fmt.Errorf("error finding the node's registration block: %w", err)
+		}
+	}
+	if startBlock > response.EndBlock {
+		return nil, fmt.Errorf("Start block %d is after the latest block (%d)", startBlock, response.EndBlock)
+	}
+	response.StartBlock = startBlock
+
+	// Get the contracts to scan
+	minipoolContract := timelineContract{
+		name:   "minipool",
+		events: map[common.Hash]abi.Event{},
+	}
+	for _, name := range timelineMinipoolContracts {
+		contractAbi, err := rp.GetABI(name, nil)
+		if err != nil {
+			// Not every contract version exists on every network
+			continue
+		}
+		for _, event := range contractAbi.Events {
+			minipoolContract.events[event.ID] = event
+		}
+	}
+	networkContracts := map[common.Address]timelineContract{}
+	networkAddresses := []common.Address{}
+	for _, name := range timelineNetworkContracts {
+		address, err := rp.GetAddress(name, nil)
+		if err != nil || address == nil || *address == (common.Address{}) {
+			// Not every contract exists on every network
+			continue
+		}
+		contractAbi, err := rp.GetABI(name, nil)
+		if err != nil {
+			continue
+		}
+		networkContracts[*address] = getTimelineContract(name, contractAbi)
+		networkAddresses = append(networkAddresses, *address)
+	}
+
+	// Get the event logs
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	minipoolTopic := common.BytesToHash(minipoolAddress.Bytes())
+	logs := []ethtypes.Log{}
+	for from := startBlock; from <= response.EndBlock; from += uint64(eventLogInterval) {
+		to := from + uint64(eventLogInterval) - 1
+		if to > response.EndBlock {
+			to = response.EndBlock
+		}
+		queries := []ethereum.FilterQuery{
+			{
+				FromBlock: big.NewInt(0).SetUint64(from),
+				ToBlock:   big.NewInt(0).SetUint64(to),
+				Addresses: []common.Address{minipoolAddress},
+			},
+		}
+		if len(networkAddresses) > 0 {
+			queries = append(queries, ethereum.FilterQuery{
+				FromBlock: big.NewInt(0).SetUint64(from),
+				ToBlock:   big.NewInt(0).SetUint64(to),
+				Addresses: networkAddresses,
+				Topics:    [][]common.Hash{nil, {minipoolTopic}},
+			})
+		}
+		for _, query := range queries {
+			chunkLogs, err := rp.Client.FilterLogs(context.Background(), query)
+			if err != nil {
+				return nil, fmt.Errorf("error getting event logs for blocks %d to %d: %w", from, to, err)
+			}
+			logs = append(logs, chunkLogs...)
+		}
+	}
+
+	// Add the events from the minipool and the network contracts
+	blockTimes := map[uint64]time.Time{}
+	seenLogs := map[string]bool{}
+	txHashes := []common.Hash{}
+	seenTxs := map[common.Hash]bool{}
+	for _, log := range logs {
+		key := fmt.Sprintf("%s:%d", log.TxHash.Hex(), log.Index)
+		if seenLogs[key] {
+			continue
+		}
+		seenLogs[key] = true
+
+		source := timelineSource_Network
+		contract, exists := networkContracts[log.Address]
+		if log.Address == minipoolAddress {
+			source = timelineSource_Minipool
+			contract = minipoolContract
+		} else if !exists {
+			continue
+		}
+		event, err := getTimelineLogEvent(rp, blockTimes, source, contract, log)
+		if err != nil {
+			return nil, err
+		}
+		response.Events = append(response.Events, event)
+
+		if !seenTxs[log.TxHash] {
+			seenTxs[log.TxHash] = true
+			txHashes = append(txHashes, log.TxHash)
+		}
+	}
+
+	// Add the node's events from the same transactions, such as deposits and credit changes
+	nodeTopic := common.BytesToHash(nodeAccount.Address.Bytes())
+	resolvedContracts := map[common.Address]*timelineContract{}
+	for _, txHash := range txHashes {
+		receipt, err := rp.Client.TransactionReceipt(context.Background(), txHash)
+		if err != nil {
+			return nil, fmt.Errorf("error getting receipt for transaction %s: %w", txHash.Hex(), err)
+		}
+		for _, log := range receipt.Logs {
+			key := fmt.Sprintf("%s:%d", log.TxHash.Hex(), log.Index)
+			if seenLogs[key] || !hasTimelineTopic(log, minipoolTopic, nodeTopic) {
+				continue
+			}
+			seenLogs[key] = true
+
+			contract, exists := resolvedContracts[log.Address]
+			if !exists {
+				name, contractAbi, err := services.ResolveContract(rp, log.Address)
+				if err != nil {
+					return nil, fmt.Errorf("error resolving contract %s: %w", log.Address.Hex(), err)
+				}
+				if name != "" && contractAbi != nil && name != timelineMinipoolContracts[0] {
+					resolved := getTimelineContract(name, contractAbi)
+					contract = &resolved
+				}
+				resolvedContracts[log.Address] = contract
+			}
+			if contract == nil {
+				// Not a Rocket Pool network contract (other minipools are resolved to the delegate and skipped)
+				continue
+			}
+			event, err := getTimelineLogEvent(rp, blockTimes, timelineSource_Network, *contract, *log)
+			if err != nil {
+				return nil, err
+			}
+			response.Events = append(response.Events, event)
+		}
+	}
+
+	// Add the validator's status transitions
+	validator, exists := networkState.ValidatorDetails[mpd.Pubkey]
+	if exists && validator.Exists {
+		response.Events = append(response.Events, getValidatorTimelineEvents(networkState.BeaconConfig, validator)...)
+	}
+
+	// Sort the timeline
+	sort.SliceStable(response.Events, func(i int, j int) bool {
+		first := response.Events[i]
+		second := response.Events[j]
+		if !first.Time.Equal(second.Time) {
+			return first.Time.Before(second.Time)
+		}
+		if first.BlockNumber != second.BlockNumber {
+			return first.BlockNumber < second.BlockNumber
+		}
+		return first.LogIndex < second.LogIndex
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Find the first block with a timestamp at or after the given time
+func getFirstBlockAtTime(rp *rocketpool.RocketPool, low uint64, high uint64, targetTime time.Time) (uint64, error) {
+	target := uint64(targetTime.Unix())
+	for low < high {
+		mid := low + (high-low)/2
+		header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(mid))
+		if err != nil {
+			return 0, fmt.Errorf("error getting header for block %d: %w", mid, err)
+		}
+		if header.Time >= target {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	return low, nil
+}
+
+// Index a contract's events by their topic
+func getTimelineContract(name string, contractAbi *abi.ABI) timelineContract {
+	contract := timelineContract{
+		name:   name,
+		events: map[common.Hash]abi.Event{},
+	}
+	if contractAbi != nil {
+		for _, event := range contractAbi.Events {
+			contract.events[event.ID] = event
+		}
+	}
+	return contract
+}
+
+// Check if any of a log's indexed topics refer to one of the given values
+func hasTimelineTopic(log *ethtypes.Log, values ...common.Hash) bool {
+	if len(log.Topics) < 2 {
+		return false
+	}
+	for _, topic := range log.Topics[1:] {
+		for _, value := range values {
+			if topic == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Decode a log into a timeline event; logs that can't be decoded are included with their raw topics and data
+func getTimelineLogEvent(rp *rocketpool.RocketPool, blockTimes map[uint64]time.Time, source string, contract timelineContract, log ethtypes.Log) (api.MinipoolTimelineEvent, error) {
+
+	// Get the block time
+	blockTime, exists := blockTimes[log.BlockNumber]
+	if !exists {
+		header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(log.BlockNumber))
+		if err != nil {
+			return api.MinipoolTimelineEvent{}, fmt.Errorf("error getting header for block %d: %w", log.BlockNumber, err)
+		}
+		blockTime = time.Unix(int64(header.Time), 0)
+		blockTimes[log.BlockNumber] = blockTime
+	}
+
+	timelineEvent := api.MinipoolTimelineEvent{
+		Time:        blockTime,
+		Source:      source,
+		Contract:    contract.name,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+		Values:      map[string]string{},
+	}
+
+	// Decode the event
+	var event abi.Event
+	isKnown := false
+	if len(log.Topics) > 0 {
+		event, isKnown = contract.events[log.Topics[0]]
+	}
+	if isKnown {
+		values := map[string]interface{}{}
+		indexed := abi.Arguments{}
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed = append(indexed, input)
+			}
+		}
+		err := event.Inputs.NonIndexed().UnpackIntoMap(values, log.Data)
+		if err == nil {
+			err = abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:])
+		}
+		if err == nil {
+			timelineEvent.Event = event.Name
+			for name, value := range values {
+				timelineEvent.Values[name] = formatTimelineValue(value)
+			}
+			return timelineEvent, nil
+		}
+	}
+
+	// Fall back to the raw log
+	timelineEvent.Event = "Unknown"
+	for i, topic := range log.Topics {
+		timelineEvent.Values[fmt.Sprintf("topic%d", i)] = topic.Hex()
+	}
+	timelineEvent.Values["data"] = hexutil.Encode(log.Data)
+	return timelineEvent, nil
+
+}
+
+// Format a decoded event value for display
+func formatTimelineValue(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Get the timeline events for a validator's status transitions
+func getValidatorTimelineEvents(eth2Config beacon.Eth2Config, validator beacon.ValidatorStatus) []api.MinipoolTimelineEvent {
+	transitions := []struct {
+		name  string
+		epoch uint64
+	}{
+		{"ActivationEligibility", validator.ActivationEligibilityEpoch},
+		{"Activation", validator.ActivationEpoch},
+		{"Exit", validator.ExitEpoch},
+		{"Withdrawable", validator.WithdrawableEpoch},
+	}
+
+	events := []api.MinipoolTimelineEvent{}
+	for _, transition := range transitions {
+		if transition.epoch == timelineFarFutureEpoch {
+			continue
+		}
+		event := api.MinipoolTimelineEvent{
+			Time:     getEpochTime(eth2Config, transition.epoch),
+			Source:   timelineSource_Beacon,
+			Contract: "validator",
+			Event:    transition.name,
+			Epoch:    transition.epoch,
+			Values: map[string]string{
+				"index": fmt.Sprint(validator.Index),
+			},
+		}
+		if transition.name == "Exit" {
+			event.Values["slashed"] = fmt.Sprint(validator.Slashed)
+		}
+		events = append(events, event)
+	}
+	return events
+}
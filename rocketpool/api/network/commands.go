@@ -216,6 +216,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "fee-history-gas-prices",
+				Usage:     "Get gas price suggestions based on the Execution client's fee history.",
+				UsageText: "rocketpool api network fee-history-gas-prices",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getFeeHistoryGasPrices(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
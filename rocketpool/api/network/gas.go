@@ -0,0 +1,35 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getFeeHistoryGasPrices(c *cli.Context) (*api.FeeHistoryGasPricesResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.FeeHistoryGasPricesResponse{}
+
+	// Get the gas price suggestions
+	suggestion, err := feehistory.GetGasPrices(ec)
+	if err != nil {
+		return nil, err
+	}
+	response.Suggestion = suggestion
+
+	// Return response
+	return &response, nil
+
+}
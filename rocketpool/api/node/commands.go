@@ -229,6 +229,40 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "set-validator-settings",
+				Usage:     "Set or clear the graffiti and builder gas limit overrides for one of the node's validators",
+				UsageText: "rocketpool api node set-validator-settings pubkey set-graffiti graffiti set-gas-limit gas-limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 5); err != nil {
+						return err
+					}
+					pubkey, err := cliutils.ValidatePubkey("pubkey", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					setGraffiti, err := cliutils.ValidateBool("set-graffiti", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					graffiti := c.Args().Get(2)
+					setGasLimit, err := cliutils.ValidateBool("set-gas-limit", c.Args().Get(3))
+					if err != nil {
+						return err
+					}
+					gasLimit, err := cliutils.ValidateUint("gas limit", c.Args().Get(4))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setValidatorSettings(c, pubkey, setGraffiti, graffiti, setGasLimit, gasLimit))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-swap-rpl",
@@ -824,6 +858,63 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "transactions",
+				Usage:     "Get the transactions sent by the node account from the transaction journal",
+				UsageText: "rocketpool api node transactions",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTransactions(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "scheduled-transactions",
+				Usage:     "Get the node account's transactions that are scheduled for when the network fees are low enough",
+				UsageText: "rocketpool api node scheduled-transactions",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getScheduledTransactions(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "cancel-scheduled-transaction",
+				Usage:     "Cancel a scheduled transaction that hasn't been submitted yet",
+				UsageText: "rocketpool api node cancel-scheduled-transaction id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					id, err := cliutils.ValidatePositiveUint("scheduled transaction ID", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(cancelScheduledTransaction(c, id))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "estimate-set-snapshot-delegate-gas",
 				Usage:     "Estimate the gas required to set a voting snapshot delegate",
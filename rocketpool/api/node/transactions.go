@@ -0,0 +1,120 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getTransactions(c *cli.Context) (*api.NodeTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTransactionsResponse{
+		Transactions: []*transactions.TransactionRecord{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the transactions sent by the node account
+	journal := w.GetTransactionJournal()
+	if journal == nil {
+		return &response, nil
+	}
+	records, err := journal.GetTransactions()
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.From == nodeAccount.Address {
+			response.Transactions = append(response.Transactions, record)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func getScheduledTransactions(c *cli.Context) (*api.NodeScheduledTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeScheduledTransactionsResponse{
+		Transactions: []*transactions.ScheduledTransaction{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the scheduled transactions for the node account
+	scheduler := w.GetTransactionScheduler()
+	if scheduler == nil {
+		return &response, nil
+	}
+	scheduled, err := scheduler.GetTransactions()
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range scheduled {
+		if tx.From == nodeAccount.Address {
+			response.Transactions = append(response.Transactions, tx)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func cancelScheduledTransaction(c *cli.Context, id uint64) (*api.CancelScheduledTransactionResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CancelScheduledTransactionResponse{}
+
+	// Cancel the transaction
+	scheduler := w.GetTransactionScheduler()
+	if scheduler == nil {
+		return nil, fmt.Errorf("the transaction scheduler is not available")
+	}
+	if err := scheduler.Cancel(id); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
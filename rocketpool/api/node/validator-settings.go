@@ -0,0 +1,96 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func setValidatorSettings(c *cli.Context, pubkey types.ValidatorPubkey, setGraffiti bool, graffiti string, setGasLimit bool, gasLimit uint64) (*api.SetValidatorSettingsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetValidatorSettingsResponse{}
+
+	// Check the validator belongs to the node
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	minipoolAddress, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the minipool for validator %s: %w", pubkey.Hex(), err)
+	}
+	if minipoolAddress == (common.Address{}) {
+		return nil, fmt.Errorf("validator %s doesn't belong to a minipool", pubkey.Hex())
+	}
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := mp.GetNodeAddress(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the owner of minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	if owner != nodeAccount.Address {
+		return nil, fmt.Errorf("validator %s doesn't belong to the node", pubkey.Hex())
+	}
+	if setGraffiti && len(graffiti) > 32 {
+		return nil, fmt.Errorf("the graffiti '%s' is longer than 32 bytes", graffiti)
+	}
+
+	// Update the validator's overrides; blank values remove them
+	path := os.ExpandEnv(cfg.Smartnode.GetValidatorSettingsPath())
+	settings, err := validator.LoadValidatorSettings(path)
+	if err != nil {
+		return nil, err
+	}
+	entry := settings[pubkey]
+	if setGraffiti {
+		entry.Graffiti = nil
+		if graffiti != "" {
+			entry.Graffiti = &graffiti
+		}
+	}
+	if setGasLimit {
+		entry.GasLimit = nil
+		if gasLimit != 0 {
+			entry.GasLimit = &gasLimit
+		}
+	}
+	settings[pubkey] = entry
+	if err := validator.SaveValidatorSettings(path, settings); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	response.Graffiti = entry.Graffiti
+	response.GasLimit = entry.GasLimit
+	response.KeymanagerActive = (w.GetKeymanager() != nil)
+	return &response, nil
+
+}
@@ -0,0 +1,195 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func auditValidatorKeys(c *cli.Context, lookahead uint) (*api.AuditValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.AuditValidatorKeysResponse{
+		KeystoreErrors: map[string]string{},
+	}
+
+	// Get the node's minipools and their validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	networkState, _, err := m.GetHeadStateForNode(nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Get the keys in each keystore
+	storedPubkeys, keystoreErrors := w.GetStoredValidatorPubkeys()
+	for name, pubkeys := range storedPubkeys {
+		response.Keystores = append(response.Keystores, name)
+		for _, pubkey := range pubkeys {
+			key := getKeyAudit(&response, pubkey)
+			key.Keystores = append(key.Keystores, name)
+		}
+	}
+	sort.Strings(response.Keystores)
+	for name, err := range keystoreErrors {
+		response.KeystoreErrors[name] = err.Error()
+	}
+
+	// Add the minipools
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAccount.Address] {
+		key := getKeyAudit(&response, mpd.Pubkey)
+		key.HasMinipool = true
+		key.MinipoolAddress = mpd.MinipoolAddress
+		key.MinipoolStatus = mpd.Status
+		key.MinipoolFinalised = mpd.Finalised
+		if validator, exists := networkState.ValidatorDetails[mpd.Pubkey]; exists && validator.Exists {
+			key.ValidatorExists = true
+			key.ValidatorIndex = validator.Index
+			key.ValidatorStatus = string(validator.Status)
+		}
+	}
+
+	// Derive the wallet's keys, plus a few past its key count in case it has fallen behind
+	response.WalletKeyCount, err = w.GetValidatorKeyCount()
+	if err != nil {
+		return nil, err
+	}
+	derivedKeys, err := w.GetValidatorKeys(0, response.WalletKeyCount+lookahead)
+	if err != nil {
+		return nil, err
+	}
+	response.NextSafeIndex = response.WalletKeyCount
+	for _, derivedKey := range derivedKeys {
+		var key *api.ValidatorKeyAudit
+		if derivedKey.WalletIndex < response.WalletKeyCount {
+			key = getKeyAudit(&response, derivedKey.PublicKey)
+		} else {
+			// Only report keys past the wallet's key count if they're in use
+			key = findKeyAudit(&response, derivedKey.PublicKey)
+			if key == nil {
+				continue
+			}
+			if derivedKey.WalletIndex+1 > response.NextSafeIndex {
+				response.NextSafeIndex = derivedKey.WalletIndex + 1
+			}
+		}
+		key.HasDerivationIndex = true
+		key.DerivationIndex = derivedKey.WalletIndex
+	}
+
+	// Check each key
+	for i := range response.Keys {
+		key := &response.Keys[i]
+		sort.Strings(key.Keystores)
+		key.Finding = getKeyAuditFinding(key)
+	}
+
+	// Sort the keys by derivation index, with keys that weren't derived from the wallet last
+	sort.SliceStable(response.Keys, func(i, j int) bool {
+		a := response.Keys[i]
+		b := response.Keys[j]
+		if a.HasDerivationIndex != b.HasDerivationIndex {
+			return a.HasDerivationIndex
+		}
+		if a.HasDerivationIndex {
+			return a.DerivationIndex < b.DerivationIndex
+		}
+		return bytes.Compare(a.Pubkey.Bytes(), b.Pubkey.Bytes()) < 0
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the audit entry for a key, adding it if it doesn't exist yet
+func getKeyAudit(response *api.AuditValidatorKeysResponse, pubkey types.ValidatorPubkey) *api.ValidatorKeyAudit {
+	if key := findKeyAudit(response, pubkey); key != nil {
+		return key
+	}
+	response.Keys = append(response.Keys, api.ValidatorKeyAudit{
+		Pubkey:    pubkey,
+		Keystores: []string{},
+	})
+	return &response.Keys[len(response.Keys)-1]
+}
+
+// Find the audit entry for a key
+func findKeyAudit(response *api.AuditValidatorKeysResponse, pubkey types.ValidatorPubkey) *api.ValidatorKeyAudit {
+	for i := range response.Keys {
+		if response.Keys[i].Pubkey == pubkey {
+			return &response.Keys[i]
+		}
+	}
+	return nil
+}
+
+// Determine whether a key is where it should be
+func getKeyAuditFinding(key *api.ValidatorKeyAudit) api.KeyAuditFinding {
+	stored := len(key.Keystores) > 0
+
+	// Keys that don't belong to any of the node's minipools, such as ones from failed deposits or closed minipools
+	if !key.HasMinipool || key.MinipoolStatus == types.Dissolved {
+		if stored {
+			return api.KeyAuditFinding_Orphaned
+		}
+		return api.KeyAuditFinding_Unused
+	}
+
+	// Keys for validators that have no more duties
+	exited := key.MinipoolFinalised
+	switch beacon.ValidatorState(key.ValidatorStatus) {
+	case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_ExitedSlashed, beacon.ValidatorState_WithdrawalPossible, beacon.ValidatorState_WithdrawalDone:
+		exited = true
+	}
+	if exited {
+		if stored {
+			return api.KeyAuditFinding_Exited
+		}
+		return api.KeyAuditFinding_Ok
+	}
+
+	// Keys for validators that still have duties
+	if !stored {
+		return api.KeyAuditFinding_Missing
+	}
+	return api.KeyAuditFinding_Ok
+}
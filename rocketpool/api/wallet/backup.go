@@ -0,0 +1,154 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+func backupWallet(c *cli.Context, shareCount int, threshold int) (*api.BackupWalletResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BackupWalletResponse{}
+
+	// Check the wallet has a seed to back up
+	if !w.IsInitialized() {
+		return nil, errors.New("the node wallet is not initialized, so it doesn't have a seed to back up")
+	}
+
+	// Split the seed
+	response.Shares, err = w.CreateBackupShares(shareCount, threshold)
+	if err != nil {
+		return nil, err
+	}
+	response.Threshold = threshold
+
+	// Return response
+	return &response, nil
+
+}
+
+func recoverWalletFromShares(c *cli.Context, shares []string) (*api.RecoverWalletResponse, error) {
+
+	// Get services
+	if err := services.RequireNodePassword(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	var rp *rocketpool.RocketPool
+	if !c.Bool("skip-validator-key-recovery") {
+		if err := services.RequireRocketStorage(c); err != nil {
+			return nil, err
+		}
+		rp, err = services.GetRocketPool(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Response
+	response := api.RecoverWalletResponse{}
+
+	// Check if wallet is already initialized
+	if w.IsInitialized() {
+		return nil, errors.New("the wallet is already initialized")
+	}
+
+	// Recover wallet
+	if err := w.RecoverFromShares(shares); err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	if !c.Bool("skip-validator-key-recovery") {
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Save wallet
+	if err := w.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func testRecoverWalletFromShares(c *cli.Context, shares []string) (*api.RecoverWalletResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	var rp *rocketpool.RocketPool
+	if !c.Bool("skip-validator-key-recovery") {
+		if err := services.RequireRocketStorage(c); err != nil {
+			return nil, err
+		}
+		rp, err = services.GetRocketPool(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create a blank wallet
+	chainId := cfg.Smartnode.GetChainID()
+	w, err := wallet.NewWallet("", chainId, nil, nil, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RecoverWalletResponse{}
+
+	// Recover wallet
+	if err := w.TestRecoveryFromShares(shares); err != nil {
+		return nil, err
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	if !c.Bool("skip-validator-key-recovery") {
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -67,6 +67,10 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") used to derive the wallet's seed from its mnemonic",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -96,6 +100,10 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") used to derive the wallet's seed from its mnemonic",
+					},
 					cli.UintFlag{
 						Name:  "wallet-index, i",
 						Usage: "Specify the index to use with the derivation path when recovering your wallet",
@@ -130,6 +138,10 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") used to derive the wallet's seed from its mnemonic",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -158,6 +170,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				Aliases:   []string{"b"},
 				Usage:     "Rebuild validator keystores from derived keys",
 				UsageText: "rocketpool api wallet rebuild",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The BIP-39 passphrase (the \"25th word\") the wallet was created with, if it has one; it must match the one stored with the wallet",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -186,6 +204,10 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "derivation-path, d",
 						Usage: "Specify the derivation path for the wallet.\nOmit this flag (or leave it blank) for the default of \"m/44'/60'/0'/0/%d\" (where %d is the index).\nSet this to \"ledgerLive\" to use Ledger Live's path of \"m/44'/60'/%d/0/0\".\nSet this to \"mew\" to use MyEtherWallet's path of \"m/44'/60'/0'/%d\".\nFor custom paths, simply enter them here.",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") used to derive the wallet's seed from its mnemonic",
+					},
 					cli.UintFlag{
 						Name:  "wallet-index, i",
 						Usage: "Specify the index to use with the derivation path when recovering your wallet",
@@ -220,6 +242,10 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 						Name:  "skip-validator-key-recovery, k",
 						Usage: "Recover the node wallet, but do not regenerate its validator keys",
 					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "The optional BIP-39 passphrase (the \"25th word\") used to derive the wallet's seed from its mnemonic",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -243,6 +269,169 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "backup",
+				Usage:     "Split the node wallet's seed into Shamir backup shares",
+				UsageText: "rocketpool api wallet backup share-count threshold",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					shareCount, err := cliutils.ValidatePositiveUint("share count", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					threshold, err := cliutils.ValidatePositiveUint("threshold", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(backupWallet(c, int(shareCount), int(threshold)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "recover-from-shares",
+				Usage:     "Recover a node wallet from Shamir backup shares",
+				UsageText: "rocketpool api wallet recover-from-shares shares",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "skip-validator-key-recovery, k",
+						Usage: "Recover the node wallet, but do not regenerate its validator keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					shares, err := cliutils.ValidateBackupShares("shares", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(recoverWalletFromShares(c, shares))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "test-recover-from-shares",
+				Usage:     "Test recovery of a node wallet and its validator keys from Shamir backup shares without actually saving the recovered files",
+				UsageText: "rocketpool api wallet test-recover-from-shares shares",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "skip-validator-key-recovery, k",
+						Usage: "Recover the node wallet, but do not regenerate its validator keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					shares, err := cliutils.ValidateBackupShares("shares", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(testRecoverWalletFromShares(c, shares))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-validator-keys",
+				Usage:     "Export validator keys as EIP-2335 keystores encrypted with the given password",
+				UsageText: "rocketpool api wallet export-validator-keys password pubkeys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("keystore password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					pubkeys, err := cliutils.ValidatePubkeys("pubkeys", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportValidatorKeys(c, password, pubkeys))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "audit-keys",
+				Usage:     "Cross-reference the wallet's validator keys, the keys in each keystore, the node's minipools and their validators",
+				UsageText: "rocketpool api wallet audit-keys",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "lookahead",
+						Usage: "The number of keys past the wallet's key count to check",
+						Value: 10,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditValidatorKeys(c, c.Uint("lookahead")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rotate-keystore-passwords",
+				Usage:     "Re-encrypt every validator keystore with new random passwords and the node wallet with the node password, optionally changing the node password",
+				UsageText: "rocketpool api wallet rotate-keystore-passwords",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "new-password",
+						Usage: "A new node password to replace the current one with",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					newPassword := c.String("new-password")
+					if newPassword != "" {
+						if _, err := cliutils.ValidateNodePassword("new password", newPassword); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					api.PrintResponse(rotateKeystorePasswords(c, newPassword))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "export",
 				Aliases:   []string{"e"},
@@ -262,6 +451,90 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "set-watch-only",
+				Usage:     "Use an address without its keys as the node account, so its transactions can be exported and signed offline",
+				UsageText: "rocketpool api wallet set-watch-only address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setWatchOnlyAddress(c, &address))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "clear-watch-only",
+				Usage:     "Stop using a watch-only node account",
+				UsageText: "rocketpool api wallet clear-watch-only",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setWatchOnlyAddress(c, nil))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "sign-tx",
+				Usage:     "Sign an unsigned transaction with the node account",
+				UsageText: "rocketpool api wallet sign-tx unsigned-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					unsignedTx, err := cliutils.ValidateHexBytes("unsigned transaction", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(signTransaction(c, unsignedTx))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "broadcast",
+				Usage:     "Submit a transaction signed by the node account and add it to the transaction journal",
+				UsageText: "rocketpool api wallet broadcast signed-tx purpose",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					signedTx, err := cliutils.ValidateHexBytes("signed transaction", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastTransaction(c, signedTx, c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "estimate-gas-set-ens-name",
 				Usage:     "Estimate the gas required to set the name for the node wallet's ENS reverse record",
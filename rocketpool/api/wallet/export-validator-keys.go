@@ -0,0 +1,99 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func exportValidatorKeys(c *cli.Context, password string, pubkeys []types.ValidatorPubkey) (*api.ExportValidatorKeysResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportValidatorKeysResponse{}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Export the keys
+	encryptor := eth2ks.New()
+	for _, pubkey := range pubkeys {
+		key := api.ExportedValidatorKey{
+			Pubkey: pubkey,
+		}
+
+		// Get the minipool, making sure it belongs to the node
+		key.MinipoolAddress, err = minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the minipool for validator %s: %w", pubkey.Hex(), err)
+		}
+		if key.MinipoolAddress != (common.Address{}) {
+			mp, err := minipool.NewMinipool(rp, key.MinipoolAddress, nil)
+			if err != nil {
+				return nil, err
+			}
+			owner, err := mp.GetNodeAddress(nil)
+			if err != nil {
+				return nil, err
+			}
+			if owner != nodeAccount.Address {
+				return nil, fmt.Errorf("validator %s belongs to minipool %s, which isn't owned by this node", pubkey.Hex(), key.MinipoolAddress.Hex())
+			}
+			key.WithdrawalCredentials, err = minipool.GetMinipoolWithdrawalCredentials(rp, key.MinipoolAddress, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Get the private key and its derivation path
+		privateKey, err := w.GetValidatorKeyByPubkey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		key.DerivationPath, err = w.GetValidatorKeyPath(pubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		// Encrypt it
+		crypto, err := encryptor.Encrypt(privateKey.Marshal(), password)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting the key for validator %s: %w", pubkey.Hex(), err)
+		}
+		key.Keystore = api.ValidatorKeystore{
+			Crypto:  crypto,
+			Version: encryptor.Version(),
+			UUID:    uuid.New(),
+			Path:    key.DerivationPath,
+			Pubkey:  pubkey,
+		}
+		response.Keys = append(response.Keys, key)
+	}
+
+	// Return response
+	return &response, nil
+
+}
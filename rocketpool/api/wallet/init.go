@@ -41,7 +41,7 @@ func initWallet(c *cli.Context) (*api.InitWalletResponse, error) {
 	}
 
 	// Initialize wallet but don't save it
-	mnemonic, err := w.Initialize(path, 0)
+	mnemonic, err := w.Initialize(path, 0, c.String("passphrase"))
 	if err != nil {
 		return nil, err
 	}
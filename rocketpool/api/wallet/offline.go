@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func setWatchOnlyAddress(c *cli.Context, address *common.Address) (*api.SetWatchOnlyAddressResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetWatchOnlyAddressResponse{}
+
+	// Check the wallet
+	if address != nil && w.IsInitialized() {
+		return nil, errors.New("The node wallet is already initialized. Watch-only mode is only for nodes whose wallet is kept on an offline machine.")
+	}
+
+	// Save the address
+	if err := wallet.SaveWatchOnlyAddress(os.ExpandEnv(cfg.Smartnode.GetWatchOnlyAddressPath()), address); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func signTransaction(c *cli.Context, unsignedTx []byte) (*api.SignTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SignTransactionResponse{}
+
+	// Check the transaction is for this network
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(unsignedTx); err != nil {
+		return nil, fmt.Errorf("Error decoding transaction: %w", err)
+	}
+	if tx.ChainId().Cmp(w.GetChainID()) != 0 {
+		return nil, fmt.Errorf("The transaction is for chain ID %s, but the node wallet is configured for chain ID %s", tx.ChainId().String(), w.GetChainID().String())
+	}
+
+	// Sign it
+	signedTx, err := w.Sign(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	response.SignedTx = signedTx
+
+	// Return response
+	return &response, nil
+
+}
+
+func broadcastTransaction(c *cli.Context, signedTx []byte, purpose string) (*api.BroadcastTransactionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastTransactionResponse{}
+
+	// Check the transaction was signed by the node account
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(signedTx); err != nil {
+		return nil, fmt.Errorf("Error decoding transaction: %w", err)
+	}
+	if tx.ChainId().Cmp(w.GetChainID()) != 0 {
+		return nil, fmt.Errorf("The transaction is for chain ID %s, but the node is configured for chain ID %s", tx.ChainId().String(), w.GetChainID().String())
+	}
+	from, err := types.Sender(types.NewLondonSigner(tx.ChainId()), &tx)
+	if err != nil {
+		return nil, fmt.Errorf("Error recovering the transaction signer: %w", err)
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if from != nodeAccount.Address {
+		return nil, fmt.Errorf("The transaction was signed by %s, not the node account %s", from.Hex(), nodeAccount.Address.Hex())
+	}
+
+	// Make sure nothing else uses its nonce
+	nonceManager := w.GetNonceManager()
+	reservedNonce := false
+	if nonceManager != nil {
+		if err := nonceManager.ReserveNonce(from, tx.Nonce()); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: couldn't reserve nonce %d with the nonce manager: %s\n", tx.Nonce(), err.Error())
+		} else {
+			reservedNonce = true
+		}
+	}
+
+	// Submit it, releasing the nonce if it didn't go through
+	if err := ec.SendTransaction(context.Background(), &tx); err != nil && !transactions.IsAlreadyKnownError(err) {
+		if reservedNonce {
+			if releaseErr := nonceManager.ReleaseNonce(from, tx.Nonce()); releaseErr != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: couldn't release nonce %d with the nonce manager: %s\n", tx.Nonce(), releaseErr.Error())
+			}
+		}
+		return nil, fmt.Errorf("Error submitting transaction: %w", err)
+	}
+	response.TxHash = tx.Hash()
+
+	// Record it so the transaction monitor can follow it
+	if journal := w.GetTransactionJournal(); journal != nil {
+		if err := journal.RecordTransaction(&tx, from, purpose); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: couldn't record transaction %s in the transaction journal: %s\n", tx.Hash().Hex(), err.Error())
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
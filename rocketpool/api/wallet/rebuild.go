@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"errors"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
@@ -29,6 +31,18 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	// Response
 	response := api.RebuildWalletResponse{}
 
+	// Make sure the passphrase matches the one the wallet was created with, so the keys are derived from the expected seed
+	storedPassphrase, err := w.GetPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	if c.String("passphrase") != storedPassphrase {
+		if storedPassphrase == "" {
+			return nil, errors.New("the node wallet doesn't have a passphrase")
+		}
+		return nil, errors.New("the passphrase doesn't match the one the node wallet was created with")
+	}
+
 	// Get node account
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
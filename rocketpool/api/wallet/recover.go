@@ -62,7 +62,7 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	walletIndex := c.Uint("wallet-index")
 
 	// Recover wallet
-	if err := w.Recover(path, walletIndex, mnemonic); err != nil {
+	if err := w.Recover(path, walletIndex, mnemonic, c.String("passphrase")); err != nil {
 		return nil, err
 	}
 
@@ -119,7 +119,8 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 		return nil, errors.New("the wallet is already initialized")
 	}
 
-	// Try each derivation path across all of the iterations
+	// Try each derivation path across all of the iterations, using the passphrase if the wallet is protected by one
+	passphrase := c.String("passphrase")
 	paths := []string{
 		wallet.DefaultNodeKeyPath,
 		wallet.LedgerLiveNodeKeyPath,
@@ -132,7 +133,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 			if err != nil {
 				return nil, fmt.Errorf("error generating new wallet: %w", err)
 			}
-			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic)
+			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic, passphrase)
 			if err != nil {
 				return nil, fmt.Errorf("error recovering wallet with path [%s], index [%d]: %w", derivationPath, i, err)
 			}
@@ -160,7 +161,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	}
 
 	// Recover wallet
-	if err := w.Recover(response.DerivationPath, response.Index, mnemonic); err != nil {
+	if err := w.Recover(response.DerivationPath, response.Index, mnemonic, passphrase); err != nil {
 		return nil, err
 	}
 
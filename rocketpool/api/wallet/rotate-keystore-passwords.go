@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func rotateKeystorePasswords(c *cli.Context, newPassword string) (*api.RotateKeystorePasswordsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RotateKeystorePasswordsResponse{}
+
+	// Check the wallet has keystores to rotate
+	if !w.IsInitialized() {
+		return nil, errors.New("the node wallet is not initialized")
+	}
+
+	// Rotate the passwords
+	response.Keystores, response.SkippedKeystores, err = w.RotateKeystorePasswords(newPassword)
+	if err != nil {
+		return nil, err
+	}
+	response.PasswordChanged = (newPassword != "")
+	response.RestartRequired = w.IsValidatorRestartRequired()
+
+	// Return response
+	return &response, nil
+
+}
@@ -25,9 +25,14 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
 	response.WalletInitialized = w.IsInitialized()
+	response.HasPassphrase = w.HasPassphrase()
+	response.WatchOnly = w.IsWatchOnly()
+	if externalSigner := w.GetExternalSigner(); externalSigner != nil {
+		response.ExternalSignerUrl = externalSigner.GetUrl()
+	}
 
 	// Get accounts if initialized
-	if response.WalletInitialized {
+	if response.WalletInitialized || response.WatchOnly || response.ExternalSignerUrl != "" {
 
 		// Get node account
 		nodeAccount, err := w.GetNodeAccount()
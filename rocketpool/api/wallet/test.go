@@ -56,7 +56,7 @@ func testRecoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletRespo
 	walletIndex := c.Uint("wallet-index")
 
 	// Recover wallet
-	if err := w.TestRecovery(path, walletIndex, mnemonic); err != nil {
+	if err := w.TestRecovery(path, walletIndex, mnemonic, c.String("passphrase")); err != nil {
 		return nil, err
 	}
 
@@ -107,7 +107,8 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	// Response
 	response := api.SearchAndRecoverWalletResponse{}
 
-	// Try each derivation path across all of the iterations
+	// Try each derivation path across all of the iterations, using the passphrase if the wallet is protected by one
+	passphrase := c.String("passphrase")
 	paths := []string{
 		wallet.DefaultNodeKeyPath,
 		wallet.LedgerLiveNodeKeyPath,
@@ -120,7 +121,7 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 			if err != nil {
 				return nil, fmt.Errorf("error generating new wallet: %w", err)
 			}
-			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic)
+			err = recoveredWallet.TestRecovery(derivationPath, i, mnemonic, passphrase)
 			if err != nil {
 				return nil, fmt.Errorf("error recovering wallet with path [%s], index [%d]: %w", derivationPath, i, err)
 			}
@@ -148,7 +149,7 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	}
 
 	// Recover wallet
-	if err := w.TestRecovery(response.DerivationPath, response.Index, mnemonic); err != nil {
+	if err := w.TestRecovery(response.DerivationPath, response.Index, mnemonic, passphrase); err != nil {
 		return nil, err
 	}
 
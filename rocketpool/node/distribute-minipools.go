@@ -31,6 +31,7 @@ type distributeMinipools struct {
 	cfg                 *config.RocketPoolConfig
 	w                   *wallet.Wallet
 	rp                  *rocketpool.RocketPool
+	ec                  *services.ExecutionClientManager
 	bc                  beacon.Client
 	d                   *client.Client
 	gasThreshold        float64
@@ -58,6 +59,10 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -107,6 +112,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		cfg:                 cfg,
 		w:                   w,
 		rp:                  rp,
+		ec:                  ec,
 		bc:                  bc,
 		d:                   d,
 		gasThreshold:        gasThreshold,
@@ -217,7 +223,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := t.w.GetNodeAccountTransactorWithPurpose("node daemon distribute-minipools")
 	if err != nil {
 		return false, err
 	}
@@ -241,7 +247,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
 		if err != nil {
 			return false, err
 		}
@@ -28,6 +28,8 @@ type manageFeeRecipient struct {
 	rp  *rocketpool.RocketPool
 	d   *client.Client
 	bc  beacon.Client
+
+	staleFeeRecipientsWarned bool
 }
 
 // Create manage fee recipient task
@@ -104,6 +106,18 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		correctFeeRecipient = feeRecipientInfo.FeeDistributorAddress
 	}
 
+	// Set the fee recipient of each validator directly through the Keymanager API if it's enabled, which doesn't require a restart
+	settingsSynced := false
+	if km := m.w.GetKeymanager(); km != nil {
+		if err := m.syncValidatorSettings(km, state, nodeAccount.Address, correctFeeRecipient); err != nil {
+			m.log.Printlnf("WARNING: couldn't verify the per-validator settings through the Keymanager API: %s", err.Error())
+		} else {
+			settingsSynced = true
+		}
+	} else {
+		m.warnStaleFeeRecipients()
+	}
+
 	// Check if the VC is using the correct fee recipient
 	fileExists, correctAddress, err := rpsvc.CheckFeeRecipientFile(correctFeeRecipient, m.cfg)
 	if err != nil {
@@ -133,6 +147,12 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return nil
 	}
 
+	// The validators already use the correct fee recipient, so the file only needs to be correct for the next restart
+	if settingsSynced {
+		m.log.Println("Fee recipient files updated successfully! Your validators are already using the correct fee recipient, so the validator client doesn't need to be restarted.")
+		return nil
+	}
+
 	// Restart the VC
 	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
 	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
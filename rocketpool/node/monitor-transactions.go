@@ -0,0 +1,250 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The percentage that fees are raised by when replacing a stuck transaction.
+// Execution clients require at least 10% for both the max fee and priority fee.
+const feeBumpPercent int64 = 15
+
+// Monitor transactions task
+type monitorTransactions struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	ec             *services.ExecutionClientManager
+	journal        *transactions.Journal
+	timeout        time.Duration
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	disabled       bool
+}
+
+// Create monitor transactions task
+func newMonitorTransactions(c *cli.Context, logger log.ColorLogger) (*monitorTransactions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if monitoring is disabled
+	timeoutMinutes := cfg.Smartnode.StuckTxTimeout.Value.(uint64)
+	disabled := false
+	if timeoutMinutes == 0 {
+		logger.Println("Stuck transaction timeout is 0, disabling stuck transaction monitoring.")
+		disabled = true
+	}
+
+	// Return task
+	return &monitorTransactions{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		ec:             ec,
+		journal:        w.GetTransactionJournal(),
+		timeout:        time.Duration(timeoutMinutes) * time.Minute,
+		maxFee:         eth.GweiToWei(cfg.Smartnode.StuckTxMaxFee.Value.(float64)),
+		maxPriorityFee: eth.GweiToWei(cfg.Smartnode.StuckTxMaxPriorityFee.Value.(float64)),
+		disabled:       disabled,
+	}, nil
+
+}
+
+// Check the pending transactions in the journal, and rebroadcast or replace any that are stuck
+func (t *monitorTransactions) run() error {
+
+	// Check if monitoring is disabled
+	if t.disabled || t.journal == nil {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get pending transactions
+	pending, err := t.journal.GetPendingTransactions(nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error loading pending transactions from the journal: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Checking %d pending transaction(s)...", len(pending))
+
+	// Get the latest nonce that has been included in a block
+	latestNonce, err := t.ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the latest nonce of the node account: %w", err)
+	}
+
+	for _, record := range pending {
+
+		// Check if the transaction or any of its replacements have been included
+		receipt, err := t.getReceipt(record)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't check the status of transaction %s: %s", record.Hash.Hex(), err.Error())
+			continue
+		}
+		if receipt != nil {
+			status := transactions.TransactionStatus_Confirmed
+			if receipt.Status == types.ReceiptStatusFailed {
+				status = transactions.TransactionStatus_Failed
+			}
+			t.log.Printlnf("Transaction %s (%s) was included in block %d with status '%s'.", receipt.TxHash.Hex(), record.Purpose, receipt.BlockNumber.Uint64(), status)
+			if err := t.journal.SetTransactionStatus(record.From, record.Nonce, status, receipt); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The nonce was used by a transaction that isn't in the journal
+		if record.Nonce < latestNonce {
+			t.log.Printlnf("Transaction %s (%s) was dropped; its nonce %d was used by a different transaction.", record.Hash.Hex(), record.Purpose, record.Nonce)
+			if err := t.journal.SetTransactionStatus(record.From, record.Nonce, transactions.TransactionStatus_Dropped, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Ignore transactions that haven't been pending for long enough
+		if time.Since(record.LastBroadcastTime) < t.timeout {
+			continue
+		}
+
+		// Replace or rebroadcast the stuck transaction
+		if err := t.handleStuckTransaction(record); err != nil {
+			t.log.Printlnf("WARNING: couldn't resubmit stuck transaction %s: %s", record.Hash.Hex(), err.Error())
+		}
+
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the receipt for a transaction or any of the transactions it replaced, or nil if none have been included yet
+func (t *monitorTransactions) getReceipt(record *transactions.TransactionRecord) (*types.Receipt, error) {
+
+	hashes := append([]common.Hash{record.Hash}, record.PreviousHashes...)
+	for _, hash := range hashes {
+		receipt, err := t.ec.TransactionReceipt(context.Background(), hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+	}
+	return nil, nil
+
+}
+
+// Replace a stuck transaction with one that has higher fees, or rebroadcast it if the fee limits don't allow a replacement
+func (t *monitorTransactions) handleStuckTransaction(record *transactions.TransactionRecord) error {
+
+	// Get the current base fee
+	header, err := t.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest block header: %w", err)
+	}
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	// Get the bumped fees
+	maxFee := bumpFee(record.MaxFee)
+	maxPriorityFee := bumpFee(record.MaxPriorityFee)
+	minMaxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), maxPriorityFee)
+	if maxFee.Cmp(minMaxFee) < 0 {
+		maxFee = minMaxFee
+	}
+
+	// Check the fees against the limits
+	pendingTime := time.Since(record.SubmittedTime).Round(time.Second)
+	if maxFee.Cmp(t.maxFee) > 0 || maxPriorityFee.Cmp(t.maxPriorityFee) > 0 {
+		t.log.Printlnf("Transaction %s (%s) has been pending for %s, but replacing it would require a max fee of %.2f gwei and a priority fee of %.2f gwei which exceeds your limits. Rebroadcasting it instead...",
+			record.Hash.Hex(), record.Purpose, pendingTime, eth.WeiToGwei(maxFee), eth.WeiToGwei(maxPriorityFee))
+		maxFee = record.MaxFee
+		maxPriorityFee = record.MaxPriorityFee
+	} else {
+		t.log.Printlnf("Transaction %s (%s) has been pending for %s, replacing it with a max fee of %.2f gwei and a priority fee of %.2f gwei...",
+			record.Hash.Hex(), record.Purpose, pendingTime, eth.WeiToGwei(maxFee), eth.WeiToGwei(maxPriorityFee))
+	}
+
+	// Sign the transaction
+	unsignedTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   t.w.GetChainID(),
+		Nonce:     record.Nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       record.GasLimit,
+		To:        record.To,
+		Value:     record.Value,
+		Data:      record.Data,
+	})
+	signedTx, err := t.w.SignNodeTransaction(unsignedTx)
+	if err != nil {
+		return err
+	}
+
+	// Submit it and update the journal
+	if err := t.ec.SendTransaction(context.Background(), signedTx); err != nil && !transactions.IsAlreadyKnownError(err) {
+		return err
+	}
+	if err := t.journal.RecordTransaction(signedTx, record.From, ""); err != nil {
+		return err
+	}
+	t.log.Printlnf("Transaction has been resubmitted with hash %s.", signedTx.Hash().Hex())
+
+	// Return
+	return nil
+
+}
+
+// Raise a fee by the minimum amount required for a replacement transaction
+func bumpFee(fee *big.Int) *big.Int {
+	if fee == nil {
+		return big.NewInt(0)
+	}
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+feeBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+	return bumped.Add(bumped, big.NewInt(1))
+}
@@ -38,6 +38,9 @@ const (
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
 	DistributeMinipoolsColor     = color.FgHiGreen
+	MonitorTransactionsColor     = color.FgCyan
+	ScheduledTransactionsColor   = color.FgHiMagenta
+	RemoveValidatorKeysColor     = color.FgHiRed
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -137,6 +140,24 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	monitorTransactions, err := newMonitorTransactions(c, log.NewColorLogger(MonitorTransactionsColor))
+	if err != nil {
+		return err
+	}
+	submitScheduledTransactions, err := newSubmitScheduledTransactions(c, log.NewColorLogger(ScheduledTransactionsColor))
+	if err != nil {
+		return err
+	}
+	removeExitedValidatorKeys, err := newRemoveExitedValidatorKeys(c, log.NewColorLogger(RemoveValidatorKeysColor))
+	if err != nil {
+		return err
+	}
+
+	// A watch-only node can't sign the daemon's transactions, so those tasks are left to the operator
+	watchOnly := w.IsWatchOnly()
+	if watchOnly {
+		printWatchOnlyMessage(&errorLog)
+	}
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
@@ -189,27 +210,49 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+			if !watchOnly {
+				// Run the minipool stake check
+				if err := stakePrelaunchMinipools.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
 
-			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
-				errorLog.Println(err)
+				// Run the balance distribution check
+				if err := distributeMinipools.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the reduce bond check
+				if err := reduceBonds.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the minipool promotion check
+				if err := promoteMinipools.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
 			}
-			time.Sleep(taskCooldown)
 
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
+			// Run the exited validator key removal check
+			if err := removeExitedValidatorKeys.run(state); err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
-				errorLog.Println(err)
+			if !watchOnly {
+				// Run the scheduled transaction check
+				if err := submitScheduledTransactions.run(); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				// Run the stuck transaction check
+				if err := monitorTransactions.run(); err != nil {
+					errorLog.Println(err)
+				}
 			}
 
 			time.Sleep(tasksInterval)
@@ -232,6 +275,12 @@ func run(c *cli.Context) error {
 
 }
 
+// Explain which tasks are disabled because the node account is watch-only
+func printWatchOnlyMessage(logger *log.ColorLogger) {
+	logger.Println("WARNING: the node wallet is watch-only, so the node daemon can't sign transactions. It won't stake prelaunch minipools, distribute balances, reduce bonds, promote minipools, submit scheduled transactions or replace stuck ones.")
+	logger.Println("You must do these yourself by exporting the transactions with `rocketpool --export-unsigned <folder>` and signing them offline. In particular, run `rocketpool --export-unsigned <folder> minipool stake` for each minipool in prelaunch before its scrub period ends, or it can be dissolved.")
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -32,6 +32,7 @@ type promoteMinipools struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	ec             *services.ExecutionClientManager
 	d              *client.Client
 	gasThreshold   float64
 	maxFee         *big.Int
@@ -55,6 +56,10 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
 	d, err := services.GetDocker(c)
 	if err != nil {
 		return nil, err
@@ -89,6 +94,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		ec:             ec,
 		d:              d,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
@@ -197,7 +203,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := t.w.GetNodeAccountTransactorWithPurpose("node daemon promote-minipools")
 	if err != nil {
 		return false, err
 	}
@@ -217,7 +223,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
 		if err != nil {
 			return false, err
 		}
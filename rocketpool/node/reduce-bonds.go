@@ -35,6 +35,7 @@ type reduceBonds struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	ec             *services.ExecutionClientManager
 	d              *client.Client
 	gasThreshold   float64
 	maxFee         *big.Int
@@ -67,6 +68,10 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
 	d, err := services.GetDocker(c)
 	if err != nil {
 		return nil, err
@@ -101,6 +106,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		ec:             ec,
 		d:              d,
 		gasThreshold:   gasThreshold,
 		maxFee:         maxFee,
@@ -218,7 +224,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	t.log.Printlnf("Reducing bond for minipool %s...", mpd.MinipoolAddress.Hex())
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := t.w.GetNodeAccountTransactorWithPurpose("node daemon reduce-bonds")
 	if err != nil {
 		return false, err
 	}
@@ -250,7 +256,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
 		if err != nil {
 			return false, err
 		}
@@ -0,0 +1,110 @@
+package node
+
+import (
+	"fmt"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Remove exited validator keys task
+type removeExitedValidatorKeys struct {
+	c   *cli.Context
+	log log.ColorLogger
+	w   *wallet.Wallet
+}
+
+// Create remove exited validator keys task
+func newRemoveExitedValidatorKeys(c *cli.Context, logger log.ColorLogger) (*removeExitedValidatorKeys, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &removeExitedValidatorKeys{
+		c:   c,
+		log: logger,
+		w:   w,
+	}, nil
+
+}
+
+// Remove the keys of the node's exited or dissolved minipools from the Validator Client through its Keymanager API
+func (t *removeExitedValidatorKeys) run(state *state.NetworkState) error {
+
+	// Check if the Keymanager API is enabled
+	km := t.w.GetKeymanager()
+	if km == nil {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the keys loaded by the Validator Client
+	loadedKeys, err := km.ListValidatorKeys()
+	if err != nil {
+		return err
+	}
+	loaded := map[rptypes.ValidatorPubkey]bool{}
+	for _, key := range loadedKeys {
+		if !key.ReadOnly {
+			loaded[key.Pubkey] = true
+		}
+	}
+
+	// Find the loaded keys that no longer have any duties
+	pubkeys := []rptypes.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if !loaded[mpd.Pubkey] {
+			continue
+		}
+		if mpd.Status == rptypes.Dissolved {
+			pubkeys = append(pubkeys, mpd.Pubkey)
+			continue
+		}
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists {
+			continue
+		}
+		switch validator.Status {
+		case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_ExitedSlashed, beacon.ValidatorState_WithdrawalPossible, beacon.ValidatorState_WithdrawalDone:
+			pubkeys = append(pubkeys, mpd.Pubkey)
+		}
+	}
+	if len(pubkeys) == 0 {
+		return nil
+	}
+
+	// Remove them
+	t.log.Printlnf("Removing %d exited validator key(s) from the Validator Client...", len(pubkeys))
+	results, err := km.DeleteValidatorKeys(pubkeys)
+	if err != nil {
+		return fmt.Errorf("error removing exited validator keys: %w", err)
+	}
+	for i, result := range results {
+		switch result.Status {
+		case keymanager.DeleteStatus_Deleted, keymanager.DeleteStatus_NotActive, keymanager.DeleteStatus_NotFound:
+			t.log.Printlnf("Removed the key for validator %s.", pubkeys[i].Hex())
+		default:
+			t.log.Printlnf("WARNING: couldn't remove the key for validator %s: %s", pubkeys[i].Hex(), result.Message)
+		}
+	}
+
+	// Return
+	return nil
+
+}
@@ -34,6 +34,7 @@ type stakePrelaunchMinipools struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
+	ec             *services.ExecutionClientManager
 	bc             beacon.Client
 	d              *client.Client
 	gasThreshold   float64
@@ -58,6 +59,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
 	bc, err := services.GetBeaconClient(c)
 	if err != nil {
 		return nil, err
@@ -96,6 +101,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		cfg:            cfg,
 		w:              w,
 		rp:             rp,
+		ec:             ec,
 		bc:             bc,
 		d:              d,
 		gasThreshold:   gasThreshold,
@@ -141,7 +147,7 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 	t.log.Printlnf("%d minipool(s) are ready for staking...", len(minipools))
 
 	// Stake minipools
-	successCount := 0
+	stakedPubkeys := []rptypes.ValidatorPubkey{}
 	for _, mpd := range minipools {
 		success, err := t.stakeMinipool(mpd, state, opts)
 		if err != nil {
@@ -149,13 +155,15 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 			return err
 		}
 		if success {
-			successCount++
+			stakedPubkeys = append(stakedPubkeys, mpd.Pubkey)
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
-	if successCount > 0 {
-		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+	// Restart validator process if any minipools were staked successfully and their keys haven't been loaded already
+	if len(stakedPubkeys) > 0 {
+		if t.areKeysLoaded(stakedPubkeys) {
+			t.log.Println("The Validator Client has already loaded the keys for the staked minipools through its Keymanager API.")
+		} else if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
 			return err
 		}
 	}
@@ -165,6 +173,35 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 
 }
 
+// Check if the Validator Client has loaded all of the given keys through its Keymanager API
+func (t *stakePrelaunchMinipools) areKeysLoaded(pubkeys []rptypes.ValidatorPubkey) bool {
+
+	// Check if the Keymanager API is enabled
+	km := t.w.GetKeymanager()
+	if km == nil {
+		return false
+	}
+
+	// Get the loaded keys
+	loadedKeys, err := km.ListValidatorKeys()
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't check the keys loaded by the Validator Client: %s", err.Error())
+		return false
+	}
+	loaded := map[rptypes.ValidatorPubkey]bool{}
+	for _, key := range loadedKeys {
+		loaded[key.Pubkey] = true
+	}
+
+	for _, pubkey := range pubkeys {
+		if !loaded[pubkey] {
+			return false
+		}
+	}
+	return true
+
+}
+
 // Get prelaunch minipools
 func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Address, state *state.NetworkState, opts *bind.CallOpts) ([]*rpstate.NativeMinipoolDetails, error) {
 
@@ -242,7 +279,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Get transactor
-	opts, err := t.w.GetNodeAccountTransactor()
+	opts, err := t.w.GetNodeAccountTransactorWithPurpose("node daemon stake-prelaunch-minipools")
 	if err != nil {
 		return false, err
 	}
@@ -263,7 +300,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
 		if err != nil {
 			return false, err
 		}
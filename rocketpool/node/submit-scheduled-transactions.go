@@ -0,0 +1,193 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Submit scheduled transactions task
+type submitScheduledTransactions struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	ec        *services.ExecutionClientManager
+	scheduler *transactions.Scheduler
+}
+
+// Create submit scheduled transactions task
+func newSubmitScheduledTransactions(c *cli.Context, logger log.ColorLogger) (*submitScheduledTransactions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &submitScheduledTransactions{
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		ec:        ec,
+		scheduler: w.GetTransactionScheduler(),
+	}, nil
+
+}
+
+// Submit any scheduled transactions that are affordable at the current base fee, or whose deadline has passed
+func (t *submitScheduledTransactions) run() error {
+
+	// Check if the scheduler is available
+	if t.scheduler == nil {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the waiting transactions
+	waiting, err := t.scheduler.GetWaitingTransactions(nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error loading scheduled transactions: %w", err)
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	// Get the current base fee
+	header, err := t.ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest block header: %w", err)
+	}
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	// Log
+	t.log.Printlnf("Checking %d scheduled transaction(s) (current base fee: %.2f gwei)...", len(waiting), eth.WeiToGwei(baseFee))
+
+	for _, scheduled := range waiting {
+
+		// Get the max fee to submit with
+		maxFee := scheduled.MaxFee
+		if scheduled.CanSubmit(baseFee) {
+			t.log.Printlnf("The base fee is low enough to submit scheduled transaction %d (%s) with its max fee of %.2f gwei.", scheduled.ID, scheduled.Purpose, eth.WeiToGwei(maxFee))
+		} else if scheduled.IsDue() {
+			maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
+			if err != nil {
+				t.log.Printlnf("WARNING: scheduled transaction %d (%s) is past its deadline but the network max fee couldn't be retrieved: %s", scheduled.ID, scheduled.Purpose, err.Error())
+				continue
+			}
+			t.log.Printlnf("Scheduled transaction %d (%s) is past its deadline, submitting it with a max fee of %.2f gwei.", scheduled.ID, scheduled.Purpose, eth.WeiToGwei(maxFee))
+		} else {
+			continue
+		}
+
+		// Submit it
+		if err := t.submitTransaction(scheduled, maxFee); err != nil {
+			t.log.Printlnf("WARNING: couldn't submit scheduled transaction %d: %s", scheduled.ID, err.Error())
+		}
+
+	}
+
+	// Return
+	return nil
+
+}
+
+// Submit a scheduled transaction with a fresh nonce
+func (t *submitScheduledTransactions) submitTransaction(scheduled *transactions.ScheduledTransaction, maxFee *big.Int) error {
+
+	// Make sure the transaction is still valid, since the chain state may have changed since it was scheduled
+	_, err := t.ec.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:  scheduled.From,
+		To:    scheduled.To,
+		Value: scheduled.Value,
+		Data:  scheduled.Data,
+	})
+	if err != nil {
+		t.log.Printlnf("Scheduled transaction %d (%s) would revert, so it will not be submitted: %s", scheduled.ID, scheduled.Purpose, err.Error())
+		return t.scheduler.SetFailed(scheduled.ID, fmt.Errorf("transaction would revert: %w", err))
+	}
+
+	// Get the nonce
+	var nonce uint64
+	nonceManager := t.w.GetNonceManager()
+	if nonceManager != nil {
+		nonce, err = nonceManager.GetNextNonce(scheduled.From)
+	} else {
+		nonce, err = t.ec.PendingNonceAt(context.Background(), scheduled.From)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting nonce: %w", err)
+	}
+
+	// The priority fee can't be higher than the max fee
+	maxPriorityFee := scheduled.MaxPriorityFee
+	if maxPriorityFee == nil || maxPriorityFee.Cmp(maxFee) > 0 {
+		maxPriorityFee = maxFee
+	}
+
+	// Sign and submit the transaction
+	signedTx, err := t.w.SignNodeTransaction(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   t.w.GetChainID(),
+		Nonce:     nonce,
+		GasTipCap: maxPriorityFee,
+		GasFeeCap: maxFee,
+		Gas:       scheduled.GasLimit,
+		To:        scheduled.To,
+		Value:     scheduled.Value,
+		Data:      scheduled.Data,
+	}))
+	if err != nil {
+		return err
+	}
+	if err := t.ec.SendTransaction(context.Background(), signedTx); err != nil {
+		if nonceManager != nil {
+			nonceManager.ReleaseNonce(scheduled.From, nonce)
+		}
+		return err
+	}
+
+	// Record it so the transaction monitor can follow it
+	if journal := t.w.GetTransactionJournal(); journal != nil {
+		if err := journal.RecordTransaction(signedTx, scheduled.From, scheduled.Purpose); err != nil {
+			t.log.Printlnf("WARNING: couldn't record transaction %s in the transaction journal: %s", signedTx.Hash().Hex(), err.Error())
+		}
+	}
+	if err := t.scheduler.SetSubmitted(scheduled.ID, signedTx.Hash()); err != nil {
+		return err
+	}
+	t.log.Printlnf("Scheduled transaction %d has been submitted with hash %s.", scheduled.ID, signedTx.Hash().Hex())
+
+	// Return
+	return nil
+
+}
@@ -0,0 +1,146 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Make sure every one of the node's validators loaded by the Validator Client uses the correct fee recipient, along with
+// its configured graffiti and builder gas limit, correcting any that have drifted through the Keymanager API.
+// Returns an error if the fee recipient couldn't be verified for every validator.
+func (m *manageFeeRecipient) syncValidatorSettings(km *keymanager.Keystore, state *state.NetworkState, nodeAddress common.Address, feeRecipient common.Address) error {
+
+	// Get the keys loaded by the Validator Client
+	localKeys, err := km.ListValidatorKeys()
+	if err != nil {
+		return err
+	}
+	loaded := map[rptypes.ValidatorPubkey]bool{}
+	for _, key := range localKeys {
+		loaded[key.Pubkey] = true
+	}
+
+	// Not every client supports remote keys, so errors here just mean there aren't any
+	remoteKeys, _ := km.ListRemoteKeys()
+	for _, pubkey := range remoteKeys {
+		loaded[pubkey] = true
+	}
+
+	// Get the per-validator overrides
+	overrides, err := validator.LoadValidatorSettings(os.ExpandEnv(m.cfg.Smartnode.GetValidatorSettingsPath()))
+	if err != nil {
+		return err
+	}
+	defaultGasLimit := m.cfg.Smartnode.BuilderGasLimit.Value.(uint64)
+	manageGraffiti := (m.cfg.GraffitiWallWriter.GetEnabledParameter().Value != true)
+
+	// Check each validator
+	feeRecipientErrors := []string{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		pubkey := mpd.Pubkey
+		if !loaded[pubkey] {
+			continue
+		}
+		override := overrides[pubkey]
+
+		// Fee recipient
+		if err := m.syncFeeRecipient(km, pubkey, feeRecipient); err != nil {
+			feeRecipientErrors = append(feeRecipientErrors, err.Error())
+		}
+
+		// Graffiti, which is only managed for validators with an override so the Validator Client's default applies to the rest
+		if manageGraffiti && override.Graffiti != nil {
+			if err := m.syncGraffiti(km, pubkey, *override.Graffiti); err != nil {
+				m.log.Printlnf("WARNING: %s", err.Error())
+			}
+		}
+
+		// Builder gas limit
+		gasLimit := defaultGasLimit
+		if override.GasLimit != nil {
+			gasLimit = *override.GasLimit
+		}
+		if gasLimit != 0 {
+			if err := m.syncGasLimit(km, pubkey, gasLimit); err != nil {
+				m.log.Printlnf("WARNING: %s", err.Error())
+			}
+		}
+	}
+
+	if len(feeRecipientErrors) > 0 {
+		return fmt.Errorf("couldn't verify the fee recipient of %d validator(s):\n%s", len(feeRecipientErrors), strings.Join(feeRecipientErrors, "\n"))
+	}
+	return nil
+
+}
+
+// Make sure a validator uses the correct fee recipient
+func (m *manageFeeRecipient) syncFeeRecipient(km *keymanager.Keystore, pubkey rptypes.ValidatorPubkey, feeRecipient common.Address) error {
+	current, err := km.GetFeeRecipient(pubkey)
+	if err != nil {
+		return err
+	}
+	if current == feeRecipient {
+		return nil
+	}
+	m.log.Printlnf("Validator %s has the fee recipient %s instead of %s, correcting it...", pubkey.Hex(), current.Hex(), feeRecipient.Hex())
+	if err := km.SetFeeRecipient(pubkey, feeRecipient); err != nil {
+		return err
+	}
+
+	// The Validator Client keeps fee recipients set this way, so remember that they exist in case the Keymanager API is disabled
+	path := os.ExpandEnv(m.cfg.Smartnode.GetKeymanagerFeeRecipientsPath())
+	if err := os.WriteFile(path, []byte(feeRecipient.Hex()), 0644); err != nil {
+		m.log.Printlnf("WARNING: couldn't write %s: %s", path, err.Error())
+	}
+	return nil
+}
+
+// Warn that fee recipients set through the Keymanager API while it was enabled are still stored by the Validator Client,
+// where they take priority over the fee recipient file and won't be updated any more
+func (m *manageFeeRecipient) warnStaleFeeRecipients() {
+	if m.staleFeeRecipientsWarned {
+		return
+	}
+	path := os.ExpandEnv(m.cfg.Smartnode.GetKeymanagerFeeRecipientsPath())
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	m.log.Println("WARNING: the Keymanager API is disabled, but the node daemon previously set per-validator fee recipients through it. Your Validator Client keeps those and uses them instead of the fee recipient file, so they won't follow changes such as joining or leaving the Smoothing Pool.")
+	m.log.Printlnf("Re-enable the Keymanager API so the node daemon can keep them correct, or remove them with your Validator Client's Keymanager API (DELETE /eth/v1/validator/{pubkey}/feerecipient) and then delete %s.", path)
+	m.staleFeeRecipientsWarned = true
+}
+
+// Make sure a validator uses its configured graffiti
+func (m *manageFeeRecipient) syncGraffiti(km *keymanager.Keystore, pubkey rptypes.ValidatorPubkey, graffiti string) error {
+	current, err := km.GetGraffiti(pubkey)
+	if err != nil {
+		return err
+	}
+	if current == graffiti {
+		return nil
+	}
+	m.log.Printlnf("Validator %s has the graffiti '%s' instead of '%s', correcting it...", pubkey.Hex(), current, graffiti)
+	return km.SetGraffiti(pubkey, graffiti)
+}
+
+// Make sure a validator registers the configured gas limit with builders
+func (m *manageFeeRecipient) syncGasLimit(km *keymanager.Keystore, pubkey rptypes.ValidatorPubkey, gasLimit uint64) error {
+	current, err := km.GetGasLimit(pubkey)
+	if err != nil {
+		return err
+	}
+	if current == gasLimit {
+		return nil
+	}
+	m.log.Printlnf("Validator %s has the builder gas limit %d instead of %d, correcting it...", pubkey.Hex(), current, gasLimit)
+	return km.SetGasLimit(pubkey, gasLimit)
+}
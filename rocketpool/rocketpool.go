@@ -102,6 +102,18 @@ func main() {
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.Int64Flag{
+			Name:  "schedule-deadline",
+			Usage: "Add transactions to the node daemon's scheduler instead of submitting them, to be sent once the base fee is below their max fee or when this deadline (a Unix timestamp) passes",
+		},
+		cli.BoolFlag{
+			Name:  "export-unsigned",
+			Usage: "Return unsigned transactions alongside the API response so they can be signed offline, instead of signing and submitting them",
+		},
+		cli.BoolFlag{
+			Name:  "skip-trace",
+			Usage: "Only check simulated transactions for reverts instead of tracing them to get the balance changes they'd make",
+		},
 		cli.StringFlag{
 			Name:  "metricsAddress, m",
 			Usage: "Address to serve metrics on if enabled",
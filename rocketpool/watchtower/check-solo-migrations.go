@@ -2,7 +2,6 @@ package watchtower
 
 import (
 	"fmt"
-	"math/big"
 	"sync"
 	"time"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/migrations"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -21,13 +21,6 @@ import (
 	"github.com/urfave/cli"
 )
 
-const (
-	soloMigrationCheckThreshold float64 = 0.85 // Fraction of PromotionStakePeriod that can go before a minipool gets scrubbed for not having changed to 0x01
-	blsPrefix                   byte    = 0x00
-	elPrefix                    byte    = 0x01
-	migrationBalanceBuffer      float64 = 0.001
-)
-
 type checkSoloMigrations struct {
 	c                *cli.Context
 	log              log.ColorLogger
@@ -140,16 +133,13 @@ func (t *checkSoloMigrations) run(state *state.NetworkState, isAtlasDeployed boo
 func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) error {
 
 	t.printMessage(fmt.Sprintf("Checking for Beacon slot %d (EL block %d)", state.BeaconSlotNumber, state.ElBlockNumber))
-	oneGwei := eth.GweiToWei(1)
-	scrubThreshold := time.Duration(state.NetworkDetails.PromotionScrubPeriod.Seconds()*soloMigrationCheckThreshold) * time.Second
+	scrubPeriod := state.NetworkDetails.PromotionScrubPeriod
 
 	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
 	secondsForSlot := time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second
 	blockTime := genesisTime.Add(secondsForSlot)
 
 	// Go through each minipool
-	threshold := uint64(32000000000)
-	buffer := uint64(migrationBalanceBuffer * eth.WeiPerGwei)
 	for _, mpd := range state.MinipoolDetails {
 		if mpd.Status == types.Dissolved {
 			// Ignore minipools that are already dissolved
@@ -161,54 +151,10 @@ func (t *checkSoloMigrations) checkSoloMigrations(state *state.NetworkState) err
 			continue
 		}
 
-		// Scrub minipools that aren't seen on Beacon yet
-		validator := state.ValidatorDetails[mpd.Pubkey]
-		if !validator.Exists {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) did not exist on Beacon yet, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex()))
-		}
-
-		// Scrub minipools that are in the wrong state
-		if validator.Status != beacon.ValidatorState_ActiveOngoing {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool %s (pubkey %s) was in state %v, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex(), validator.Status))
-			continue
-		}
-
-		// Check the withdrawal credentials
-		withdrawalCreds := validator.WithdrawalCredentials
-		switch withdrawalCreds[0] {
-		case blsPrefix:
-			creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
-			remainingTime := creationTime.Add(scrubThreshold).Sub(blockTime)
-			if remainingTime < 0 {
-				t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("minipool timed out (created %s, current time %s, scrubbed after %s)", creationTime, blockTime, scrubThreshold))
-				continue
-			}
-			continue
-		case elPrefix:
-			if withdrawalCreds != mpd.WithdrawalCredentials {
-				t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("withdrawal credentials do not match (expected %s, actual %s)", mpd.WithdrawalCredentials.Hex(), withdrawalCreds.Hex()))
-				continue
-			}
-		default:
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("unexpected prefix in withdrawal credentials: %s", withdrawalCreds.Hex()))
-			continue
-		}
-
-		// Check the balance
-		creationBalanceGwei := big.NewInt(0).Div(mpd.PreMigrationBalance, oneGwei).Uint64()
-		currentBalance := validator.Balance
-
-		// Add the minipool balance to the Beacon balance in case it already got skimmed
-		minipoolBalanceGwei := big.NewInt(0).Div(mpd.Balance, oneGwei).Uint64()
-		currentBalance += minipoolBalanceGwei
-
-		if currentBalance < threshold {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the threshold of %d", currentBalance, threshold))
-			continue
-		}
-		if currentBalance < (creationBalanceGwei - buffer) {
-			t.scrubVacantMinipool(mpd.MinipoolAddress, fmt.Sprintf("current balance of %d is lower than the creation balance of %d, and below the acceptable buffer threshold of %d", currentBalance, creationBalanceGwei, buffer))
-			continue
+		// Scrub minipools that break any of the migration rules
+		check := migrations.CheckVacantMinipool(&mpd, state.ValidatorDetails[mpd.Pubkey], scrubPeriod, blockTime)
+		if check.ScrubReason != "" {
+			t.scrubVacantMinipool(mpd.MinipoolAddress, check.ScrubReason)
 		}
 
 	}
@@ -43,7 +43,7 @@ type processPenalties struct {
 	cfg            *config.RocketPoolConfig
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
-	ec             rocketpool.ExecutionClient
+	ec             *services.ExecutionClientManager
 	bc             beacon.Client
 	lock           *sync.Mutex
 	isRunning      bool
@@ -504,7 +504,7 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
 		if err != nil {
 			return err
 		}
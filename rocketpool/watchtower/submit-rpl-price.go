@@ -166,7 +166,7 @@ type submitRplPrice struct {
 	log       log.ColorLogger
 	errLog    log.ColorLogger
 	cfg       *config.RocketPoolConfig
-	ec        rocketpool.ExecutionClient
+	ec        *services.ExecutionClientManager
 	w         *wallet.Wallet
 	rp        *rocketpool.RocketPool
 	oio       *contracts.OneInchOracle
@@ -1010,7 +1010,7 @@ func (t *submitRplPrice) submitArbitrumPrice() error {
 	if index == indexToSubmit {
 
 		// Get the current network recommended max fee
-		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
+		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei(t.cfg, t.ec)
 		if err != nil {
 			return fmt.Errorf("error getting recommended base fee from the network for Arbitrum price submission: %w", err)
 		}
@@ -26,6 +26,7 @@ type Eth2Config struct {
 	SlotsPerEpoch                uint64
 	SecondsPerEpoch              uint64
 	EpochsPerSyncCommitteePeriod uint64
+	CapellaForkVersion           []byte
 }
 type Eth2DepositContract struct {
 	ChainID uint64
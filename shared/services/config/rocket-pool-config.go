@@ -34,6 +34,7 @@ const (
 	PrometheusContainerName   string = "prometheus"
 	ValidatorContainerName    string = "validator"
 	WatchtowerContainerName   string = "watchtower"
+	Web3SignerContainerName   string = "web3signer"
 
 	FeeRecipientFileEnvVar string = "FEE_RECIPIENT_FILE"
 	FeeRecipientEnvVar     string = "FEE_RECIPIENT"
@@ -121,6 +122,10 @@ type RocketPoolConfig struct {
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
 
+	// Web3Signer
+	EnableWeb3Signer config.Parameter  `yaml:"enableWeb3Signer,omitempty"`
+	Web3Signer       *Web3SignerConfig `yaml:"web3Signer,omitempty"`
+
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
 }
@@ -435,6 +440,18 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
 		},
+
+		EnableWeb3Signer: config.Parameter{
+			ID:                   "enableWeb3Signer",
+			Name:                 "Enable Web3Signer",
+			Description:          "Keep new validator keys in a Web3Signer instance instead of storing them on disk for your Validator Client. Your Validator Client will request signatures from Web3Signer as a remote signer.\n\nThis requires the Smartnode's \"Use Keymanager API\" setting, which is how new keys are registered with your Validator Client.\n\n[orange]NOTE: Keys created before enabling this will stay in your Validator Client's keystore.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator, config.ContainerID_Web3Signer},
+			EnvironmentVariables: []string{"ENABLE_WEB3SIGNER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 	// Set the defaults for choices
@@ -466,6 +483,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
+	cfg.Web3Signer = NewWeb3SignerConfig(cfg)
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
@@ -538,6 +556,7 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExporterMetricsPort,
 		&cfg.WatchtowerMetricsPort,
 		&cfg.EnableMevBoost,
+		&cfg.EnableWeb3Signer,
 	}
 }
 
@@ -569,6 +588,7 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
 		"native":             cfg.Native,
 		"mevBoost":           cfg.MevBoost,
+		"web3Signer":         cfg.Web3Signer,
 		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
 	}
 }
@@ -1007,6 +1027,12 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		}
 	}
 
+	// Web3Signer
+	if cfg.EnableWeb3Signer.Value == true {
+		config.AddParametersToEnvVars(cfg.Web3Signer.GetParameters(), envVars)
+		envVars[web3SignerUrlEnvVar] = cfg.Web3Signer.GetUrl()
+	}
+
 	// Addons
 	cfg.GraffitiWallWriter.UpdateEnvVars(envVars)
 
@@ -1132,6 +1158,33 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// In Docker mode, every API command runs in its own `docker exec` and the daemons run in containers that don't
+	// inherit anything from the host, so only the password file and Vault can provide the node password
+	if !cfg.IsNativeMode {
+		switch cfg.Smartnode.PasswordSource.Value.(config.PasswordSource) {
+		case config.PasswordSource_FileDescriptor, config.PasswordSource_Environment, config.PasswordSource_SystemdCredential, config.PasswordSource_Command:
+			errors = append(errors, fmt.Sprintf("The %s node password source is only available in Native mode, because the Smartnode's containers can't read file descriptors, environment variables, systemd credentials or programs from the host. Please use the password file or HashiCorp Vault.", cfg.Smartnode.PasswordSource.Value))
+		case config.PasswordSource_Vault:
+			if cfg.Smartnode.VaultTokenPath.Value.(string) == "" {
+				errors = append(errors, "The Smartnode's containers can't read the VAULT_TOKEN environment variable from the host, so Docker mode needs a Vault token file. Please set the Vault Token Path to a file in the Smartnode's data folder.")
+			}
+		}
+	}
+
+	// Ensure there's a Web3Signer URL
+	if cfg.EnableWeb3Signer.Value == true {
+		if cfg.IsNativeMode && cfg.Web3Signer.Mode.Value.(config.Mode) == config.Mode_Local {
+			errors = append(errors, "Native mode can't run a locally-managed Web3Signer instance. Please switch Web3Signer to externally-managed mode and enter its URL.")
+		} else if cfg.Web3Signer.Mode.Value.(config.Mode) == config.Mode_External && cfg.Web3Signer.ExternalUrl.Value.(string) == "" {
+			errors = append(errors, "You have Web3Signer enabled in external mode but don't have a URL set. Please enter the URL of your Web3Signer instance to use it.")
+		}
+
+		// New keys only go to Web3Signer, so the Validator Client has to be told about them through its Keymanager API
+		if cfg.Smartnode.UseKeymanagerApi.Value != true {
+			errors = append(errors, "You have Web3Signer enabled but the Smartnode's \"Use Keymanager API\" setting is disabled. New validator keys are only stored in Web3Signer, so your Validator Client would never load them; please enable the Keymanager API to use Web3Signer.")
+		}
+	}
+
 	return errors
 }
 
@@ -25,6 +25,14 @@ const (
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	TransactionJournalFile             string = "transactions.json"
+	NonceFile                          string = "nonces.json"
+	TransactionScheduleFile            string = "scheduled-transactions.json"
+	KeymanagerApiTokenFile             string = "keymanager-api-token"
+	ValidatorSettingsFile              string = "validator-settings.yml"
+	KeymanagerFeeRecipientsFile        string = "keymanager-fee-recipients"
+	WatchOnlyAddressFile               string = "watch-only-address"
+	SoloMigrationsFile                 string = "solo-migrations.json"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
@@ -36,6 +44,8 @@ const (
 // Defaults
 const (
 	defaultProjectName       string = "rocketpool"
+	defaultKeymanagerApiUrl  string = "http://validator:5062"
+	defaultBuilderGasLimit   uint64 = 30000000
 	WatchtowerMaxFeeDefault  uint64 = 200
 	WatchtowerPrioFeeDefault uint64 = 3
 )
@@ -69,12 +79,72 @@ type SmartnodeConfig struct {
 	// Manual priority fee override
 	PriorityFee config.Parameter `yaml:"priorityFee,omitempty"`
 
+	// The source of gas price suggestions
+	GasOracle config.Parameter `yaml:"gasOracle,omitempty"`
+
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// How long a transaction can be pending before the node daemon replaces it
+	StuckTxTimeout config.Parameter `yaml:"stuckTxTimeout,omitempty"`
+
+	// The most the node daemon is allowed to raise a stuck transaction's max fee to
+	StuckTxMaxFee config.Parameter `yaml:"stuckTxMaxFee,omitempty"`
+
+	// The most the node daemon is allowed to raise a stuck transaction's priority fee to
+	StuckTxMaxPriorityFee config.Parameter `yaml:"stuckTxMaxPriorityFee,omitempty"`
+
+	// Toggle for loading validator keys through the Validator Client's Keymanager API
+	UseKeymanagerApi config.Parameter `yaml:"useKeymanagerApi,omitempty"`
+
+	// The URL of the Validator Client's Keymanager API
+	KeymanagerApiUrl config.Parameter `yaml:"keymanagerApiUrl,omitempty"`
+
+	// The path of the Keymanager API's auth token
+	KeymanagerApiTokenPath config.Parameter `yaml:"keymanagerApiTokenPath,omitempty"`
+
+	// The gas limit validators register with block builders
+	BuilderGasLimit config.Parameter `yaml:"builderGasLimit,omitempty"`
+
+	// The URL of an external signer that holds the node account's key
+	NodeSignerUrl config.Parameter `yaml:"nodeSignerUrl,omitempty"`
+
+	// The node account to use on the external signer
+	NodeSignerAddress config.Parameter `yaml:"nodeSignerAddress,omitempty"`
+
+	// Where the node password comes from
+	PasswordSource config.Parameter `yaml:"passwordSource,omitempty"`
+
+	// The file descriptor to read the node password from
+	PasswordFileDescriptor config.Parameter `yaml:"passwordFileDescriptor,omitempty"`
+
+	// The environment variable to read the node password from
+	PasswordEnvVar config.Parameter `yaml:"passwordEnvVar,omitempty"`
+
+	// The systemd credential to read the node password from
+	PasswordCredentialName config.Parameter `yaml:"passwordCredentialName,omitempty"`
+
+	// The command that prints the node password
+	PasswordCommand config.Parameter `yaml:"passwordCommand,omitempty"`
+
+	// The address of the Vault server that stores the node password
+	VaultAddress config.Parameter `yaml:"vaultAddress,omitempty"`
+
+	// The Vault KV v2 secrets engine that stores the node password
+	VaultKvMount config.Parameter `yaml:"vaultKvMount,omitempty"`
+
+	// The path of the Vault secret that stores the node password
+	VaultSecretPath config.Parameter `yaml:"vaultSecretPath,omitempty"`
+
+	// The field of the Vault secret that stores the node password
+	VaultSecretField config.Parameter `yaml:"vaultSecretField,omitempty"`
+
+	// The path of the Vault token file
+	VaultTokenPath config.Parameter `yaml:"vaultTokenPath,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -265,6 +335,27 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		GasOracle: config.Parameter{
+			ID:                   "gasOracle",
+			Name:                 "Gas Price Oracle",
+			Description:          "Select where the Smartnode gets its gas price suggestions from, both for the CLI and for automatic transactions sent by the node and watchtower.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.GasOracle_Etherchain},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Etherchain",
+				Description: "Use the gas price suggestions from beaconcha.in's Etherchain service, falling back to Etherscan if it isn't available. This requires access to those third-party services, so it doesn't work on most test networks or on machines without internet access.",
+				Value:       config.GasOracle_Etherchain,
+			}, {
+				Name:        "Local Fee History",
+				Description: "Calculate gas price suggestions from the base fees and priority fees of recent blocks, using your Execution client's `eth_feeHistory` method. This doesn't rely on any third-party services.",
+				Value:       config.GasOracle_FeeHistory,
+			}},
+		},
+
 		AutoTxGasThreshold: config.Parameter{
 			ID:   "minipoolStakeGasThreshold",
 			Name: "Automatic TX Gas Threshold",
@@ -290,6 +381,259 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		StuckTxTimeout: config.Parameter{
+			ID:                   "stuckTxTimeout",
+			Name:                 "Stuck TX Timeout",
+			Description:          "The number of minutes a transaction sent by your node can remain pending before the Smartnode considers it stuck. Stuck transactions will be rebroadcast, and replaced with a higher max fee and priority fee if your limits below allow it.\n\nSet this to 0 to disable monitoring of stuck transactions.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(30)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StuckTxMaxFee: config.Parameter{
+			ID:                   "stuckTxMaxFee",
+			Name:                 "Stuck TX Max Fee Limit",
+			Description:          "The highest max fee (in gwei) that the Smartnode may use when replacing a stuck transaction. Each replacement raises the fees by at least the minimum amount required by the Execution client; if that would exceed this limit, the transaction will only be rebroadcast with its existing fees.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(150)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StuckTxMaxPriorityFee: config.Parameter{
+			ID:                   "stuckTxMaxPriorityFee",
+			Name:                 "Stuck TX Priority Fee Limit",
+			Description:          "The highest priority fee (in gwei) that the Smartnode may use when replacing a stuck transaction.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(10)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UseKeymanagerApi: config.Parameter{
+			ID:                   "useKeymanagerApi",
+			Name:                 "Use Keymanager API",
+			Description:          "Enable this to load and remove validator keys through your Validator Client's standard Keymanager API, so it doesn't need to be restarted when you create, import or close a minipool.\n\nYour Validator Client must have its Keymanager API enabled. If the Smartnode can't reach it, keys will be saved to disk for your Validator Client to load the next time it restarts instead.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiUrl: config.Parameter{
+			ID:                   "keymanagerApiUrl",
+			Name:                 "Keymanager API URL",
+			Description:          "The URL of your Validator Client's Keymanager API, including the port.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultKeymanagerApiUrl},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiTokenPath: config.Parameter{
+			ID:                   "keymanagerApiTokenPath",
+			Name:                 "Keymanager API Token Path",
+			Description:          "The path of the file containing the auth token for your Validator Client's Keymanager API. Leave this blank to use the `keymanager-api-token` file in your validator keys folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BuilderGasLimit: config.Parameter{
+			ID:                   "builderGasLimit",
+			Name:                 "Builder Gas Limit",
+			Description:          "The gas limit your validators register with block builders when using MEV-Boost. When the Keymanager API is enabled, the node daemon keeps each validator's gas limit set to this value (or its override, set with `rocketpool node set-validator-settings`).\n\nSet this to 0 to leave the gas limit up to your Validator Client.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultBuilderGasLimit},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeSignerUrl: config.Parameter{
+			ID:                   "nodeSignerUrl",
+			Name:                 "External Node Signer URL",
+			Description:          "The JSON-RPC URL of a Clef-compatible external signer that holds your node account's key, such as `http://192.168.1.10:8550`. When this is set, every transaction and message from the node account is signed by the external signer instead of the node wallet, subject to its own approval rules.\n\nLeave this blank to sign with the node wallet.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeSignerAddress: config.Parameter{
+			ID:                   "nodeSignerAddress",
+			Name:                 "External Node Signer Address",
+			Description:          "The address of the node account on the external signer. Leave this blank if the external signer only has one account.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordSource: config.Parameter{
+			ID:                   "passwordSource",
+			Name:                 "Node Password Source",
+			Description:          "Select where the Smartnode gets the node password that unlocks your node wallet and validator keys. Every source other than the password file lets you keep the password off the disk, but the Smartnode will need it whenever its daemons start.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.PasswordSource_File},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Password File",
+				Description: "Store the password in a plaintext file in the Smartnode's data folder. This is the default.",
+				Value:       config.PasswordSource_File,
+			}, {
+				Name:        "File Descriptor",
+				Description: "Read the password once from a file descriptor inherited from the process that starts the daemon, such as a pipe. This is only available in Native mode. The password can't be set by the Smartnode.",
+				Value:       config.PasswordSource_FileDescriptor,
+			}, {
+				Name:        "Environment Variable",
+				Description: "Read the password from an environment variable. This is only available in Native mode. The password can't be set by the Smartnode.",
+				Value:       config.PasswordSource_Environment,
+			}, {
+				Name:        "systemd Credential",
+				Description: "Read the password from a systemd credential, set with `LoadCredential=` or `LoadCredentialEncrypted=` in the service's unit file. This is only available in Native mode. The password can't be set by the Smartnode.",
+				Value:       config.PasswordSource_SystemdCredential,
+			}, {
+				Name:        "External Command",
+				Description: "Run a command that prints the password, such as `pass show rocketpool` or `gpg --quiet --decrypt password.gpg`. This is only available in Native mode. The password can't be set by the Smartnode.",
+				Value:       config.PasswordSource_Command,
+			}, {
+				Name:        "HashiCorp Vault",
+				Description: "Store the password in a HashiCorp Vault KV (version 2) secrets engine.",
+				Value:       config.PasswordSource_Vault,
+			}},
+		},
+
+		PasswordFileDescriptor: config.Parameter{
+			ID:                   "passwordFileDescriptor",
+			Name:                 "Password File Descriptor",
+			Description:          "The file descriptor to read the node password from, if the Node Password Source is File Descriptor.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(3)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordEnvVar: config.Parameter{
+			ID:                   "passwordEnvVar",
+			Name:                 "Password Environment Variable",
+			Description:          "The environment variable to read the node password from, if the Node Password Source is Environment Variable.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "ROCKETPOOL_PASSWORD"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordCredentialName: config.Parameter{
+			ID:                   "passwordCredentialName",
+			Name:                 "Password Credential Name",
+			Description:          "The name of the systemd credential to read the node password from, if the Node Password Source is systemd Credential.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "rocketpool-password"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordCommand: config.Parameter{
+			ID:                   "passwordCommand",
+			Name:                 "Password Command",
+			Description:          "The shell command that prints the node password, if the Node Password Source is External Command. A trailing newline is ignored.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultAddress: config.Parameter{
+			ID:                   "vaultAddress",
+			Name:                 "Vault Address",
+			Description:          "The URL of the HashiCorp Vault server that stores the node password, such as `https://vault.example.com:8200`, if the Node Password Source is HashiCorp Vault.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultKvMount: config.Parameter{
+			ID:                   "vaultKvMount",
+			Name:                 "Vault KV Mount",
+			Description:          "The path the KV (version 2) secrets engine that stores the node password is mounted at.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "secret"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultSecretPath: config.Parameter{
+			ID:                   "vaultSecretPath",
+			Name:                 "Vault Secret Path",
+			Description:          "The path of the secret that stores the node password, within the KV secrets engine.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "rocketpool/node-password"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultSecretField: config.Parameter{
+			ID:                   "vaultSecretField",
+			Name:                 "Vault Secret Field",
+			Description:          "The field of the secret that holds the node password.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "password"},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultTokenPath: config.Parameter{
+			ID:                   "vaultTokenPath",
+			Name:                 "Vault Token Path",
+			Description:          "The path of a file containing the token to authenticate with Vault, such as one written by Vault Agent. Leave this blank to use the `VAULT_TOKEN` environment variable instead, which is only available in Native mode; in Docker mode, the file must be in the Smartnode's data folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		RewardsTreeMode: config.Parameter{
 			ID:                   "rewardsTreeMode",
 			Name:                 "Rewards Tree Mode",
@@ -604,8 +948,28 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.DataPath,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
+		&cfg.GasOracle,
 		&cfg.AutoTxGasThreshold,
 		&cfg.DistributeThreshold,
+		&cfg.StuckTxTimeout,
+		&cfg.StuckTxMaxFee,
+		&cfg.StuckTxMaxPriorityFee,
+		&cfg.UseKeymanagerApi,
+		&cfg.KeymanagerApiUrl,
+		&cfg.KeymanagerApiTokenPath,
+		&cfg.BuilderGasLimit,
+		&cfg.NodeSignerUrl,
+		&cfg.NodeSignerAddress,
+		&cfg.PasswordSource,
+		&cfg.PasswordFileDescriptor,
+		&cfg.PasswordEnvVar,
+		&cfg.PasswordCredentialName,
+		&cfg.PasswordCommand,
+		&cfg.VaultAddress,
+		&cfg.VaultKvMount,
+		&cfg.VaultSecretPath,
+		&cfg.VaultSecretField,
+		&cfg.VaultTokenPath,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
 		&cfg.Web3StorageApiToken,
@@ -674,6 +1038,71 @@ func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	return filepath.Join(DaemonDataPath, WatchtowerFolder, "state.yml")
 }
 
+func (cfg *SmartnodeConfig) GetTransactionJournalPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), TransactionJournalFile)
+	}
+
+	return filepath.Join(DaemonDataPath, TransactionJournalFile)
+}
+
+func (cfg *SmartnodeConfig) GetSoloMigrationsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SoloMigrationsFile)
+	}
+
+	return filepath.Join(DaemonDataPath, SoloMigrationsFile)
+}
+
+func (cfg *SmartnodeConfig) GetNonceFilePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NonceFile)
+	}
+
+	return filepath.Join(DaemonDataPath, NonceFile)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerApiTokenPath() string {
+	tokenPath := cfg.KeymanagerApiTokenPath.Value.(string)
+	if tokenPath != "" {
+		return tokenPath
+	}
+
+	return filepath.Join(cfg.GetValidatorKeychainPath(), KeymanagerApiTokenFile)
+}
+
+func (cfg *SmartnodeConfig) GetValidatorSettingsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ValidatorSettingsFile)
+	}
+
+	return filepath.Join(DaemonDataPath, ValidatorSettingsFile)
+}
+
+func (cfg *SmartnodeConfig) GetTransactionSchedulePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), TransactionScheduleFile)
+	}
+
+	return filepath.Join(DaemonDataPath, TransactionScheduleFile)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerFeeRecipientsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), KeymanagerFeeRecipientsFile)
+	}
+
+	return filepath.Join(DaemonDataPath, KeymanagerFeeRecipientsFile)
+}
+
+func (cfg *SmartnodeConfig) GetWatchOnlyAddressPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), WatchOnlyAddressFile)
+	}
+
+	return filepath.Join(DaemonDataPath, WatchOnlyAddressFile)
+}
+
 func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "custom-keys")
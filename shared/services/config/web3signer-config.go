@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	web3SignerTag       string = "consensys/web3signer:23.6.0"
+	web3SignerUrlEnvVar string = "WEB3SIGNER_URL"
+)
+
+// Configuration for Web3Signer
+type Web3SignerConfig struct {
+	Title string `yaml:"-"`
+
+	// Ownership mode
+	Mode config.Parameter `yaml:"mode,omitempty"`
+
+	// The HTTP API port
+	Port config.Parameter `yaml:"port,omitempty"`
+
+	// The Docker Hub tag for Web3Signer
+	ContainerTag config.Parameter `yaml:"containerTag,omitempty"`
+
+	// Custom command line flags
+	AdditionalFlags config.Parameter `yaml:"additionalFlags,omitempty"`
+
+	// The URL of an external Web3Signer instance
+	ExternalUrl config.Parameter `yaml:"externalUrl"`
+
+	///////////////////////////
+	// Non-editable settings //
+	///////////////////////////
+
+	parentConfig *RocketPoolConfig `yaml:"-"`
+}
+
+// Generates a new Web3Signer configuration
+func NewWeb3SignerConfig(cfg *RocketPoolConfig) *Web3SignerConfig {
+	return &Web3SignerConfig{
+		Title: "Web3Signer Settings",
+
+		parentConfig: cfg,
+
+		Mode: config.Parameter{
+			ID:                   "mode",
+			Name:                 "Web3Signer Mode",
+			Description:          "Choose whether to let the Smartnode manage your Web3Signer instance (Locally Managed), or if you manage your own outside of the Smartnode stack (Externally Managed).",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.Mode_Local},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator, config.ContainerID_Web3Signer},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Locally Managed",
+				Description: "Allow the Smartnode to manage a Web3Signer instance for you",
+				Value:       config.Mode_Local,
+			}, {
+				Name:        "Externally Managed",
+				Description: "Use an existing Web3Signer instance that you manage on your own",
+				Value:       config.Mode_External,
+			}},
+		},
+
+		Port: config.Parameter{
+			ID:                   "port",
+			Name:                 "Port",
+			Description:          "The port that Web3Signer should serve its HTTP API on.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(9000)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator, config.ContainerID_Web3Signer},
+			EnvironmentVariables: []string{"WEB3SIGNER_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ContainerTag: config.Parameter{
+			ID:                   "containerTag",
+			Name:                 "Container Tag",
+			Description:          "The tag name of the Web3Signer container you want to use on Docker Hub.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: web3SignerTag},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Web3Signer},
+			EnvironmentVariables: []string{"WEB3SIGNER_CONTAINER_TAG"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+
+		AdditionalFlags: config.Parameter{
+			ID:                   "additionalFlags",
+			Name:                 "Additional Flags",
+			Description:          "Additional custom command line flags you want to pass to Web3Signer, to take advantage of other settings that the Smartnode's configuration doesn't cover.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Web3Signer},
+			EnvironmentVariables: []string{"WEB3SIGNER_ADDITIONAL_FLAGS"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExternalUrl: config.Parameter{
+			ID:                   "externalUrl",
+			Name:                 "External URL",
+			Description:          "The URL of your external Web3Signer instance. Its key manager API must be enabled (`--key-manager-api-enabled`), and it must be reachable from both the Smartnode and your Validator Client.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the config.Parameters for this config
+func (cfg *Web3SignerConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Mode,
+		&cfg.Port,
+		&cfg.ContainerTag,
+		&cfg.AdditionalFlags,
+		&cfg.ExternalUrl,
+	}
+}
+
+// The title for the config
+func (cfg *Web3SignerConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the URL of the Web3Signer instance, which the Smartnode and the Validator Client both use
+func (cfg *Web3SignerConfig) GetUrl() string {
+	if cfg.Mode.Value.(config.Mode) == config.Mode_Local {
+		return fmt.Sprintf("http://%s:%d", Web3SignerContainerName, cfg.Port.Value)
+	}
+	return strings.TrimSuffix(cfg.ExternalUrl.Value.(string), "/")
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Config
+const (
+	minipoolDelegateContractName string = "rocketMinipoolDelegate"
+)
+
+// Look up the name and ABI of a Rocket Pool contract by its address.
+// Network contracts are registered in RocketStorage; minipools are resolved to the minipool delegate.
+// Returns an empty name if the address doesn't belong to Rocket Pool.
+func ResolveContract(rp *rocketpool.RocketPool, address common.Address) (string, *abi.ABI, error) {
+
+	// Check for a network contract
+	nameKey := crypto.Keccak256Hash([]byte("contract.name"), address.Bytes())
+	name, err := rp.RocketStorage.GetString(nil, nameKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Check for a minipool
+	if name == "" {
+		isMinipool, err := minipool.GetMinipoolExists(rp, address, nil)
+		if err != nil {
+			return "", nil, err
+		}
+		if !isMinipool {
+			return "", nil, nil
+		}
+		name = minipoolDelegateContractName
+	}
+
+	// Get the ABI
+	contractAbi, err := rp.GetABI(name, nil)
+	if err != nil {
+		return name, nil, nil
+	}
+	return name, contractAbi, nil
+
+}
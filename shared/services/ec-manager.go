@@ -12,8 +12,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -25,6 +27,10 @@ type ExecutionClientManager struct {
 	fallbackEcUrl   string
 	primaryEc       *ethclient.Client
 	fallbackEc      *ethclient.Client
+	primaryRpc      *rpc.Client
+	fallbackRpc     *rpc.Client
+	simulator       *simulation.Simulator
+	sendHandler     func(tx *types.Transaction, err error)
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
@@ -64,17 +70,20 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	primaryRpc, err := rpc.Dial(primaryEcUrl)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
+	primaryEc := ethclient.NewClient(primaryRpc)
 
+	var fallbackRpc *rpc.Client
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackRpc, err = rpc.Dial(fallbackEcUrl)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
+		fallbackEc = ethclient.NewClient(fallbackRpc)
 	}
 
 	return &ExecutionClientManager{
@@ -82,6 +91,8 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		fallbackEcUrl: fallbackEcUrl,
 		primaryEc:     primaryEc,
 		fallbackEc:    fallbackEc,
+		primaryRpc:    primaryRpc,
+		fallbackRpc:   fallbackRpc,
 		logger:        log.NewColorLogger(color.FgYellow),
 		primaryReady:  true,
 		fallbackReady: fallbackEc != nil,
@@ -195,10 +206,27 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 // There is no guarantee that this is the true gas limit requirement as other
 // transactions may be added or removed by miners, but it should provide a basis
 // for setting a reasonable default.
+// If transaction simulation is enabled, the transaction is also simulated against the pending state; reverts
+// are returned as a RevertError with a decoded reason, and successful simulations are recorded.
 func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
 	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return client.EstimateGas(ctx, call)
 	})
+	if p.simulator != nil {
+		simResult, simErr := p.simulator.Simulate(ctx, call)
+		if simErr == nil {
+			if err != nil && simResult.Reverted {
+				return 0, &simulation.RevertError{
+					ContractName: simResult.ContractName,
+					Reason:       simResult.RevertReason,
+					Err:          err,
+				}
+			}
+			if err == nil && !simResult.Reverted {
+				p.simulator.Record(simResult)
+			}
+		}
+	}
 	if err != nil {
 		return 0, err
 	}
@@ -210,6 +238,18 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
+	if p.sendHandler != nil {
+		p.sendHandler(tx, err)
+	}
+	return err
+}
+
+// CallContext performs a raw JSON-RPC call with the given method and arguments, storing the result in the value
+// pointed to by result.
+func (p *ExecutionClientManager) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return nil, p.getRpcClient(client).CallContext(ctx, result, method, args...)
+	})
 	return err
 }
 
@@ -304,6 +344,17 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 	return tx, isPending, err
 }
 
+// FeeHistory retrieves the fee market history.
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
@@ -455,6 +506,32 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 }
 
+// Enable pre-flight simulation of every transaction that has its gas estimated
+func (p *ExecutionClientManager) EnableSimulation() *simulation.Simulator {
+	if p.simulator == nil {
+		p.simulator = simulation.NewSimulator(p)
+	}
+	return p.simulator
+}
+
+// Set a function that's given the result of every transaction sent through the manager
+func (p *ExecutionClientManager) SetTransactionSendHandler(handler func(tx *types.Transaction, err error)) {
+	p.sendHandler = handler
+}
+
+// Get the transaction simulator, or nil if simulation isn't enabled
+func (p *ExecutionClientManager) GetSimulator() *simulation.Simulator {
+	return p.simulator
+}
+
+// Get the raw RPC client for one of the managed Execution clients
+func (p *ExecutionClientManager) getRpcClient(client *ethclient.Client) *rpc.Client {
+	if client == p.fallbackEc {
+		return p.fallbackRpc
+	}
+	return p.primaryRpc
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
 
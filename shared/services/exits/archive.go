@@ -0,0 +1,113 @@
+package exits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Config
+const (
+	ArchiveVersion uint        = 1
+	ArchiveMode    os.FileMode = 0600
+)
+
+// A voluntary exit that was signed ahead of time, which can be broadcast later without the validator key
+type PresignedExit struct {
+	MinipoolAddress common.Address           `json:"minipoolAddress"`
+	ValidatorPubkey types.ValidatorPubkey    `json:"validatorPubkey"`
+	ValidatorIndex  uint64                   `json:"validatorIndex"`
+	Epoch           uint64                   `json:"epoch"`
+	Signature       types.ValidatorSignature `json:"signature"`
+}
+
+// An encrypted archive of pre-signed exits.
+// The exits are encrypted with the archive password using the same scheme as EIP-2335 keystores, so only the header is
+// readable without it.
+type Archive struct {
+	Version   uint                   `json:"version"`
+	Network   cfgtypes.Network       `json:"network"`
+	CreatedAt time.Time              `json:"createdAt"`
+	Count     int                    `json:"count"`
+	Crypto    map[string]interface{} `json:"crypto"`
+}
+
+// Encrypt a set of pre-signed exits into an archive
+func NewArchive(exits []PresignedExit, network cfgtypes.Network, password string) (*Archive, error) {
+	if len(exits) == 0 {
+		return nil, errors.New("there are no exits to archive")
+	}
+	plaintext, err := json.Marshal(exits)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode pre-signed exits: %w", err)
+	}
+	crypto, err := eth2ks.New().Encrypt(plaintext, password)
+	if err != nil {
+		return nil, fmt.Errorf("could not encrypt pre-signed exits: %w", err)
+	}
+	return &Archive{
+		Version:   ArchiveVersion,
+		Network:   network,
+		CreatedAt: time.Now().UTC(),
+		Count:     len(exits),
+		Crypto:    crypto,
+	}, nil
+}
+
+// Load an archive from a file
+func LoadArchive(path string) (*Archive, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read pre-signed exit archive from %s: %w", path, err)
+	}
+	var archive Archive
+	if err := json.Unmarshal(bytes, &archive); err != nil {
+		return nil, fmt.Errorf("could not decode pre-signed exit archive from %s: %w", path, err)
+	}
+	if archive.Version != ArchiveVersion {
+		return nil, fmt.Errorf("unsupported pre-signed exit archive version %d (expected %d)", archive.Version, ArchiveVersion)
+	}
+	if archive.Crypto == nil {
+		return nil, fmt.Errorf("%s doesn't contain any encrypted exits", path)
+	}
+	return &archive, nil
+}
+
+// Save an archive to a file
+func (archive *Archive) Save(path string) error {
+	bytes, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode pre-signed exit archive: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, ArchiveMode); err != nil {
+		return fmt.Errorf("could not write pre-signed exit archive to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Decrypt the exits in an archive, checking that they belong to the given network
+func (archive *Archive) Decrypt(network cfgtypes.Network, password string) ([]PresignedExit, error) {
+	if archive.Network != network {
+		return nil, fmt.Errorf("the pre-signed exits are for %s, but this node is configured for %s", archive.Network, network)
+	}
+	plaintext, err := eth2ks.New().Decrypt(archive.Crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt pre-signed exits; please check the archive password: %w", err)
+	}
+	var exits []PresignedExit
+	if err := json.Unmarshal(plaintext, &exits); err != nil {
+		return nil, fmt.Errorf("could not decode pre-signed exits: %w", err)
+	}
+	if len(exits) != archive.Count {
+		return nil, fmt.Errorf("the archive should contain %d exits, but %d were decrypted", archive.Count, len(exits))
+	}
+	return exits, nil
+}
@@ -0,0 +1,124 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// Config
+const (
+	// The number of recent blocks to sample
+	blockCount uint64 = 20
+)
+
+// The reward percentiles requested for each block, used for the slow, standard, fast and rapid priority fees
+var rewardPercentiles = []float64{10, 40, 60, 90}
+
+// The client function required to get the fee history
+type FeeHistoryClient interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+type GasFeeSuggestion struct {
+	BaseFeeWei *big.Int
+
+	RapidWei            *big.Int
+	RapidPriorityFeeWei *big.Int
+	RapidTime           string
+
+	FastWei            *big.Int
+	FastPriorityFeeWei *big.Int
+	FastTime           string
+
+	StandardWei            *big.Int
+	StandardPriorityFeeWei *big.Int
+	StandardTime           string
+
+	SlowWei            *big.Int
+	SlowPriorityFeeWei *big.Int
+	SlowTime           string
+}
+
+// Get gas prices based on the base fees and priority fees of recent blocks.
+// Each tier's max fee leaves room for the base fee to rise for a number of consecutive full blocks
+// (it can increase by up to 12.5% per block) on top of the priority fee paid at its reward percentile.
+func GetGasPrices(client FeeHistoryClient) (GasFeeSuggestion, error) {
+
+	// Get the fee history
+	history, err := client.FeeHistory(context.Background(), blockCount, nil, rewardPercentiles)
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("Could not get fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("fee history did not include any base fees")
+	}
+
+	// The last base fee is the one for the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	// Get the median priority fee at each percentile, ignoring empty blocks
+	priorityFees := make([]*big.Int, len(rewardPercentiles))
+	for i := range rewardPercentiles {
+		samples := []*big.Int{}
+		for j, rewards := range history.Reward {
+			if j < len(history.GasUsedRatio) && history.GasUsedRatio[j] == 0 {
+				continue
+			}
+			if i < len(rewards) && rewards[i] != nil {
+				samples = append(samples, rewards[i])
+			}
+		}
+		priorityFees[i] = median(samples)
+	}
+
+	suggestion := GasFeeSuggestion{
+		BaseFeeWei: baseFee,
+
+		RapidWei:            getMaxFee(baseFee, 6, priorityFees[3]),
+		RapidPriorityFeeWei: priorityFees[3],
+		RapidTime:           "15 Seconds",
+
+		FastWei:            getMaxFee(baseFee, 3, priorityFees[2]),
+		FastPriorityFeeWei: priorityFees[2],
+		FastTime:           "1 Minute",
+
+		StandardWei:            getMaxFee(baseFee, 1, priorityFees[1]),
+		StandardPriorityFeeWei: priorityFees[1],
+		StandardTime:           "3 Minutes",
+
+		SlowWei:            getMaxFee(baseFee, 0, priorityFees[0]),
+		SlowPriorityFeeWei: priorityFees[0],
+		SlowTime:           ">10 Minutes",
+	}
+
+	// Return
+	return suggestion, nil
+
+}
+
+// Get the max fee that covers the base fee rising for the given number of full blocks, plus the priority fee
+func getMaxFee(baseFee *big.Int, fullBlocks int, priorityFee *big.Int) *big.Int {
+	maxFee := new(big.Int).Set(baseFee)
+	for i := 0; i < fullBlocks; i++ {
+		increase := new(big.Int).Div(maxFee, big.NewInt(8))
+		maxFee.Add(maxFee, increase)
+	}
+	return maxFee.Add(maxFee, priorityFee)
+}
+
+// Get the median of a set of values, or 0 if there aren't any
+func median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	return new(big.Int).Set(sorted[len(sorted)/2])
+}
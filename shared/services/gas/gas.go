@@ -4,14 +4,20 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
+	"github.com/urfave/cli"
 )
 
 const colorReset string = "\033[0m"
@@ -28,6 +34,9 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
 	}
 
+	// Show what the transaction will do
+	printSimulations(rp.TakeSimulations())
+
 	// Get the current settings from the CLI arguments
 	maxFeeGwei, maxPriorityFeeGwei, gasLimit := rp.GetGasSettings()
 
@@ -40,6 +49,7 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 	}
 
 	// Get the priority fee - prioritize the CLI arguments, default to the config file setting
+	priorityFeeRequested := maxPriorityFeeGwei != 0
 	if maxPriorityFeeGwei == 0 {
 		maxPriorityFee := eth.GweiToWei(cfg.Smartnode.PriorityFee.Value.(float64))
 		if maxPriorityFee == nil || maxPriorityFee.Uint64() == 0 {
@@ -66,12 +76,28 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 		fmt.Printf("Total cost: %.4f to %.4f ETH%s\n", lowLimit, highLimit, colorReset)
 
 	} else {
-		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
+		// Get the local fee history suggestions if selected
+		var feeHistoryData *feehistory.GasFeeSuggestion
+		if cfg.Smartnode.GasOracle.Value.(cfgtypes.GasOracle) == cfgtypes.GasOracle_FeeHistory {
+			response, err := rp.GetFeeHistoryGasPrices()
+			if err == nil {
+				feeHistoryData = &response.Suggestion
+			} else {
+				fmt.Printf("%sWarning: couldn't get gas estimates from your Execution client's fee history - %s\nFalling back to Etherchain%s\n", colorYellow, err.Error(), colorReset)
+			}
+		}
+
+		if headless && feeHistoryData != nil {
+			maxFeeGwei, maxPriorityFeeGwei = getFeeHistoryTierFees(feeHistoryData.RapidWei, feeHistoryData.RapidPriorityFeeWei, maxPriorityFeeGwei, priorityFeeRequested)
+		} else if headless {
+			maxFeeWei, err := getThirdPartyMaxFeeWei()
 			if err != nil {
 				return err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
+		} else if feeHistoryData != nil {
+			// Print the fee history data and ask for an amount
+			maxFeeGwei, maxPriorityFeeGwei = handleFeeHistoryGasPrices(*feeHistoryData, gasInfo, maxPriorityFeeGwei, priorityFeeRequested, gasLimit)
 		} else {
 			// Try to get the latest gas prices from Etherchain
 			etherchainData, err := etherchain.GetGasPrices()
@@ -123,8 +149,102 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 }
 
-// Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
+// The flags for scheduling a transaction with the node daemon instead of submitting it, for commands that use AssignMaxFeeOrSchedule
+var ScheduleFlags = []cli.Flag{
+	cli.Float64Flag{
+		Name:  "when-gas-below",
+		Usage: "Schedule the transaction instead of submitting it now; the node daemon will submit it once the network's base fee allows this max fee (in gwei)",
+	},
+	cli.StringFlag{
+		Name:  "deadline",
+		Usage: "When used with --when-gas-below, the amount of time (e.g. '48h') after which the node daemon will submit the transaction regardless of the network fees",
+		Value: "72h",
+	},
+}
+
+// Assign the max fee and gas limit for a transaction, or schedule it with the node daemon if `--when-gas-below` was provided.
+// Returns true if the transaction will be scheduled instead of submitted.
+func AssignMaxFeeOrSchedule(c *cli.Context, gasInfo rocketpool.GasInfo, rp *rpsvc.Client) (bool, error) {
+
+	// Submit the transaction normally if scheduling wasn't requested
+	if !c.IsSet("when-gas-below") {
+		return false, AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	}
+
+	// Get the target fee and deadline
+	targetGwei := c.Float64("when-gas-below")
+	if targetGwei <= 0 {
+		return false, fmt.Errorf("Invalid --when-gas-below value %f; it must be greater than 0.", targetGwei)
+	}
+	deadlineDuration, err := time.ParseDuration(c.String("deadline"))
+	if err != nil {
+		return false, fmt.Errorf("Invalid deadline '%s': %w", c.String("deadline"), err)
+	}
+	deadline := time.Now().Add(deadlineDuration)
+
+	// Use the target as the max fee so the priority fee and balance checks still apply
+	_, maxPriorityFeeGwei, gasLimit := rp.GetGasSettings()
+	rp.AssignGasSettings(targetGwei, maxPriorityFeeGwei, gasLimit)
+	if err := AssignMaxFeeAndLimit(gasInfo, rp, true); err != nil {
+		return false, err
+	}
+	rp.ScheduleTransactions(deadline)
+
+	fmt.Printf("%sThis transaction will be scheduled instead of submitted right away. The node daemon will submit it once the network's base fee allows a max fee of %.2f gwei, or at the current network fee once the deadline of %s passes.%s\n\n", colorBlue, targetGwei, deadline.Format(time.RFC1123), colorReset)
+	return true, nil
+
+}
+
+// Print the balance changes from the pre-flight simulations of the transactions about to be submitted
+func printSimulations(simulations []*simulation.Result) {
+	for _, result := range simulations {
+		target := "the transaction"
+		if result.ContractName != "" {
+			target = fmt.Sprintf("the transaction to %s", result.ContractName)
+		}
+		if !result.Traced {
+			if result.TraceFailed {
+				fmt.Printf("%sSimulating %s against the %s block succeeded, but your Execution client couldn't trace it, so the balance changes it will make can't be shown.%s\n\n", colorYellow, target, result.Block, colorReset)
+			}
+			continue
+		}
+		if len(result.BalanceChanges) == 0 {
+			fmt.Printf("Simulating %s against the %s block succeeded; it will not move any ETH or tokens (excluding gas).\n\n", target, result.Block)
+			continue
+		}
+
+		fmt.Printf("Simulating %s against the %s block succeeded; it will make the following balance changes (excluding gas):\n", target, result.Block)
+		for _, change := range result.BalanceChanges {
+			account := change.Address.Hex()
+			if change.Label != "" {
+				account = fmt.Sprintf("%s (%s)", change.Label, account)
+			}
+			amount := new(big.Float).Quo(new(big.Float).SetInt(change.Amount), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(change.Decimals)), nil)))
+			fmt.Printf("\t%s: %+.6f %s\n", account, amount, change.Asset)
+		}
+		fmt.Println()
+	}
+}
+
+// Print a notice for a transaction that was scheduled instead of submitted
+func PrintScheduledTransaction() {
+	fmt.Println("The transaction has been scheduled. The node daemon will submit it when the base fee is low enough or its deadline passes; you can follow it with `rocketpool node scheduled-transactions`.")
+}
+
+// Get the suggested max fee for service operations from the oracle selected in the config
+func GetHeadlessMaxFeeWei(cfg *config.RocketPoolConfig, ec feehistory.FeeHistoryClient) (*big.Int, error) {
+	if cfg.Smartnode.GasOracle.Value.(cfgtypes.GasOracle) == cfgtypes.GasOracle_FeeHistory {
+		feeHistoryData, err := feehistory.GetGasPrices(ec)
+		if err == nil {
+			return feeHistoryData.RapidWei, nil
+		}
+		fmt.Printf("%sWarning: couldn't get gas estimates from your Execution client's fee history - %s\nFalling back to Etherchain%s\n", colorYellow, err.Error(), colorReset)
+	}
+	return getThirdPartyMaxFeeWei()
+}
+
+// Get the suggested max fee for service operations from Etherchain, or Etherscan if it isn't available
+func getThirdPartyMaxFeeWei() (*big.Int, error) {
 	etherchainData, err := etherchain.GetGasPrices()
 	if err == nil {
 		return etherchainData.RapidWei, nil
@@ -232,6 +352,97 @@ func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInf
 
 }
 
+func handleFeeHistoryGasPrices(gasSuggestion feehistory.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, priorityFeeRequested bool, gasLimit uint64) (float64, float64) {
+
+	fmt.Printf("%sThe current base fee is %.2f gwei. Recent blocks had priority fees of %.2f gwei (slow) to %.2f gwei (rapid).%s\n",
+		colorBlue, eth.WeiToGwei(gasSuggestion.BaseFeeWei), eth.WeiToGwei(gasSuggestion.SlowPriorityFeeWei), eth.WeiToGwei(gasSuggestion.RapidPriorityFeeWei), colorReset)
+
+	// Each tier's max fee already includes its priority fee
+	type feeHistoryTier struct {
+		time            string
+		maxFeeGwei      float64
+		priorityFeeGwei float64
+	}
+	tiers := []feeHistoryTier{}
+	for _, tier := range []struct {
+		time        string
+		maxFee      *big.Int
+		priorityFee *big.Int
+	}{
+		{gasSuggestion.RapidTime, gasSuggestion.RapidWei, gasSuggestion.RapidPriorityFeeWei},
+		{gasSuggestion.FastTime, gasSuggestion.FastWei, gasSuggestion.FastPriorityFeeWei},
+		{gasSuggestion.StandardTime, gasSuggestion.StandardWei, gasSuggestion.StandardPriorityFeeWei},
+		{gasSuggestion.SlowTime, gasSuggestion.SlowWei, gasSuggestion.SlowPriorityFeeWei},
+	} {
+		maxFeeGwei, priorityFeeGwei := getFeeHistoryTierFees(tier.maxFee, tier.priorityFee, priorityFee, priorityFeeRequested)
+		tiers = append(tiers, feeHistoryTier{
+			time:            tier.time,
+			maxFeeGwei:      math.RoundUp(maxFeeGwei, 0),
+			priorityFeeGwei: priorityFeeGwei,
+		})
+	}
+
+	fmt.Printf("%s+===================== Suggested Gas Prices =====================+\n", colorBlue)
+	fmt.Println("| Avg Wait Time |  Max Fee  | Priority Fee |    Total Gas Cost    |")
+	for _, tier := range tiers {
+		var lowLimit float64
+		var highLimit float64
+		if gasLimit == 0 {
+			lowLimit = tier.maxFeeGwei / eth.WeiPerGwei * float64(gasInfo.EstGasLimit)
+			highLimit = tier.maxFeeGwei / eth.WeiPerGwei * float64(gasInfo.SafeGasLimit)
+		} else {
+			lowLimit = tier.maxFeeGwei / eth.WeiPerGwei * float64(gasLimit)
+			highLimit = lowLimit
+		}
+		fmt.Printf("| %-13s | %-9s | %-12s | %.4f to %.4f ETH |\n",
+			tier.time, fmt.Sprintf("%d gwei", int(tier.maxFeeGwei)), fmt.Sprintf("%.2f gwei", tier.priorityFeeGwei), lowLimit, highLimit)
+	}
+	fmt.Printf("+================================================================+\n\n%s", colorReset)
+
+	// Default to the fast tier
+	fast := tiers[1]
+	for {
+		desiredPrice := cliutils.Prompt(
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fast.maxFeeGwei)),
+			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
+			"Not a valid gas price, try again:")
+
+		if desiredPrice == "" {
+			return fast.maxFeeGwei, fast.priorityFeeGwei
+		}
+
+		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
+		if err != nil {
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
+			continue
+		}
+		if desiredPriceFloat <= 0 {
+			fmt.Println("Max fee must be greater than zero.")
+			continue
+		}
+
+		// Use the fast tier's priority fee with a custom max fee, as long as it fits
+		if fast.priorityFeeGwei > desiredPriceFloat {
+			return desiredPriceFloat, desiredPriceFloat
+		}
+		return desiredPriceFloat, fast.priorityFeeGwei
+	}
+
+}
+
+// Get the max fee and priority fee in gwei for a fee history tier. The tier's own priority fee is used unless one was
+// requested explicitly, or recent blocks didn't have any to sample.
+func getFeeHistoryTierFees(tierMaxFee *big.Int, tierPriorityFee *big.Int, priorityFee float64, priorityFeeRequested bool) (float64, float64) {
+	if priorityFeeRequested || tierPriorityFee == nil || tierPriorityFee.Sign() == 0 {
+		baseFeeGwei := eth.WeiToGwei(tierMaxFee)
+		if tierPriorityFee != nil {
+			baseFeeGwei -= eth.WeiToGwei(tierPriorityFee)
+		}
+		return baseFeeGwei + priorityFee, priorityFee
+	}
+	return eth.WeiToGwei(tierMaxFee), eth.WeiToGwei(tierPriorityFee)
+}
+
 func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
 	fastGwei := math.RoundUp(gasSuggestion.FastGwei+priorityFee, 0)
@@ -0,0 +1,97 @@
+package migrations
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The rules the Oracle DAO uses to scrub solo migrations
+const (
+	SoloMigrationCheckThreshold float64 = 0.85 // Fraction of PromotionScrubPeriod that can go before a minipool gets scrubbed for not having changed to 0x01
+	MigrationBalanceBuffer      float64 = 0.001
+	MigrationBalanceThreshold   uint64  = 32000000000
+	blsPrefix                   byte    = 0x00
+	elPrefix                    byte    = 0x01
+)
+
+// The result of checking a vacant minipool against the solo migration rules
+type VacantMinipoolCheck struct {
+	// The reason the Oracle DAO will scrub the minipool, if it breaks any of the rules
+	ScrubReason string
+
+	// True if the validator's withdrawal credentials have been changed to the minipool address
+	CredsChanged bool
+
+	// The time by which the withdrawal credentials must be changed before the minipool is scrubbed
+	CredsDeadline time.Time
+
+	// The time at which the minipool can be promoted
+	PromotionTime time.Time
+}
+
+// Check a vacant minipool and its validator against the rules the Oracle DAO uses to scrub solo migrations
+func CheckVacantMinipool(mpd *rpstate.NativeMinipoolDetails, validator beacon.ValidatorStatus, scrubPeriod time.Duration, blockTime time.Time) VacantMinipoolCheck {
+
+	creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
+	scrubThreshold := time.Duration(scrubPeriod.Seconds()*SoloMigrationCheckThreshold) * time.Second
+	check := VacantMinipoolCheck{
+		CredsDeadline: creationTime.Add(scrubThreshold),
+		PromotionTime: creationTime.Add(scrubPeriod),
+	}
+
+	// Minipools must be active on Beacon
+	if !validator.Exists {
+		check.ScrubReason = fmt.Sprintf("minipool %s (pubkey %s) did not exist on Beacon yet, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex())
+		return check
+	}
+	if validator.Status != beacon.ValidatorState_ActiveOngoing {
+		check.ScrubReason = fmt.Sprintf("minipool %s (pubkey %s) was in state %v, but is required to be active_ongoing for migration", mpd.MinipoolAddress.Hex(), mpd.Pubkey.Hex(), validator.Status)
+		return check
+	}
+
+	// Check the withdrawal credentials
+	withdrawalCreds := validator.WithdrawalCredentials
+	switch withdrawalCreds[0] {
+	case blsPrefix:
+		if blockTime.After(check.CredsDeadline) {
+			check.ScrubReason = fmt.Sprintf("minipool timed out (created %s, current time %s, scrubbed after %s)", creationTime, blockTime, scrubThreshold)
+		}
+		return check
+	case elPrefix:
+		if withdrawalCreds != mpd.WithdrawalCredentials {
+			check.ScrubReason = fmt.Sprintf("withdrawal credentials do not match (expected %s, actual %s)", mpd.WithdrawalCredentials.Hex(), withdrawalCreds.Hex())
+			return check
+		}
+		check.CredsChanged = true
+	default:
+		check.ScrubReason = fmt.Sprintf("unexpected prefix in withdrawal credentials: %s", withdrawalCreds.Hex())
+		return check
+	}
+
+	// Check the balance
+	oneGwei := eth.GweiToWei(1)
+	buffer := uint64(MigrationBalanceBuffer * eth.WeiPerGwei)
+	creationBalanceGwei := big.NewInt(0).Div(mpd.PreMigrationBalance, oneGwei).Uint64()
+	currentBalance := validator.Balance
+
+	// Add the minipool balance to the Beacon balance in case it already got skimmed
+	minipoolBalanceGwei := big.NewInt(0).Div(mpd.Balance, oneGwei).Uint64()
+	currentBalance += minipoolBalanceGwei
+
+	if currentBalance < MigrationBalanceThreshold {
+		check.ScrubReason = fmt.Sprintf("current balance of %d is lower than the threshold of %d", currentBalance, MigrationBalanceThreshold)
+		return check
+	}
+	if currentBalance < (creationBalanceGwei - buffer) {
+		check.ScrubReason = fmt.Sprintf("current balance of %d is lower than the creation balance of %d, and below the acceptable buffer threshold of %d", currentBalance, creationBalanceGwei, buffer)
+		return check
+	}
+
+	return check
+
+}
@@ -0,0 +1,179 @@
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+)
+
+// Config
+const (
+	FileMode              = 0600
+	DirMode               = 0700
+	MigrationsFileVersion = 1
+)
+
+// The stages of a solo validator's migration into a minipool
+type SoloMigrationStage string
+
+const (
+	SoloMigrationStage_CreateMinipool        SoloMigrationStage = "create-minipool"
+	SoloMigrationStage_ChangeWithdrawalCreds SoloMigrationStage = "change-withdrawal-creds"
+	SoloMigrationStage_ImportKey             SoloMigrationStage = "import-key"
+	SoloMigrationStage_ScrubCheck            SoloMigrationStage = "scrub-check"
+	SoloMigrationStage_Promote               SoloMigrationStage = "promote"
+	SoloMigrationStage_Complete              SoloMigrationStage = "complete"
+	SoloMigrationStage_Failed                SoloMigrationStage = "failed"
+)
+
+// Check if the stage is the end of a migration
+func (s SoloMigrationStage) IsFinished() bool {
+	return s == SoloMigrationStage_Complete || s == SoloMigrationStage_Failed
+}
+
+// A stage a migration entered, and when
+type SoloMigrationTransition struct {
+	Stage SoloMigrationStage `json:"stage"`
+	Time  time.Time          `json:"time"`
+}
+
+// A solo validator being migrated into a minipool
+type SoloMigration struct {
+	Pubkey          types.ValidatorPubkey     `json:"pubkey"`
+	MinipoolAddress common.Address            `json:"minipoolAddress"`
+	ImportKey       bool                      `json:"importKey"`
+	Stage           SoloMigrationStage        `json:"stage"`
+	History         []SoloMigrationTransition `json:"history"`
+	StartedTime     time.Time                 `json:"startedTime"`
+}
+
+// Move the migration to a new stage, returning true if it changed
+func (m *SoloMigration) SetStage(stage SoloMigrationStage, now time.Time) bool {
+	if m.Stage == stage {
+		return false
+	}
+	m.Stage = stage
+	m.History = append(m.History, SoloMigrationTransition{
+		Stage: stage,
+		Time:  now,
+	})
+	return true
+}
+
+// Get the time the migration entered its current stage
+func (m *SoloMigration) GetStageTime() time.Time {
+	if len(m.History) == 0 {
+		return m.StartedTime
+	}
+	return m.History[len(m.History)-1].Time
+}
+
+// The serialized migrations file
+type migrationsFile struct {
+	Version    uint             `json:"version"`
+	Migrations []*SoloMigration `json:"migrations"`
+}
+
+// Persistent, process-safe record of the solo migrations the node is working through
+type SoloMigrationStore struct {
+	path string
+}
+
+// Create a new solo migration store backed by the file at the given path
+func NewSoloMigrationStore(path string) *SoloMigrationStore {
+	return &SoloMigrationStore{
+		path: path,
+	}
+}
+
+// Get all of the migrations, sorted from oldest to newest
+func (s *SoloMigrationStore) GetMigrations() ([]*SoloMigration, error) {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return []*SoloMigration{}, nil
+	}
+	unlock, err := transactions.LockFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return s.load()
+}
+
+// Atomically read, modify and save the migrations
+func (s *SoloMigrationStore) Update(updater func(migrations []*SoloMigration) ([]*SoloMigration, error)) error {
+
+	// Make sure the folder exists
+	if err := os.MkdirAll(filepath.Dir(s.path), DirMode); err != nil {
+		return fmt.Errorf("error creating solo migrations folder: %w", err)
+	}
+
+	// Lock the file
+	unlock, err := transactions.LockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Load and update the migrations
+	migrations, err := s.load()
+	if err != nil {
+		return err
+	}
+	migrations, err = updater(migrations)
+	if err != nil {
+		return err
+	}
+	return s.save(migrations)
+
+}
+
+// Load the migrations from disk
+func (s *SoloMigrationStore) load() ([]*SoloMigration, error) {
+	bytes, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return []*SoloMigration{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading solo migrations: %w", err)
+	}
+
+	var file migrationsFile
+	if err := json.Unmarshal(bytes, &file); err != nil {
+		return nil, fmt.Errorf("error deserializing solo migrations: %w", err)
+	}
+	if file.Migrations == nil {
+		file.Migrations = []*SoloMigration{}
+	}
+	return file.Migrations, nil
+}
+
+// Save the migrations to disk
+func (s *SoloMigrationStore) save(migrations []*SoloMigration) error {
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].StartedTime.Before(migrations[j].StartedTime)
+	})
+
+	bytes, err := json.Marshal(migrationsFile{
+		Version:    MigrationsFileVersion,
+		Migrations: migrations,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing solo migrations: %w", err)
+	}
+
+	// Write to a temp file and move it into place so readers never see a partial file
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing solo migrations: %w", err)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		return fmt.Errorf("error saving solo migrations: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,7 @@ package passwords
 import (
 	"errors"
 	"fmt"
-	"os"
+	"sync"
 )
 
 // Config
@@ -14,34 +14,51 @@ const (
 
 // Password manager
 type PasswordManager struct {
-	passwordPath string
+	provider    PasswordProvider
+	passwordSet bool
+	lock        sync.Mutex
 }
 
-// Create new password manager
+// Create new password manager that stores the password in a plaintext file
 func NewPasswordManager(passwordPath string) *PasswordManager {
+	return NewPasswordManagerWithProvider(NewFileProvider(passwordPath))
+}
+
+// Create new password manager that gets the password from a provider
+func NewPasswordManagerWithProvider(provider PasswordProvider) *PasswordManager {
 	return &PasswordManager{
-		passwordPath: passwordPath,
+		provider: provider,
 	}
 }
 
+// Get a description of where the password comes from
+func (pm *PasswordManager) GetSourceName() string {
+	return pm.provider.Name()
+}
+
 // Check if the password has been set
+// Once the password has been found it's remembered, so providers that fetch it remotely aren't queried every time
 func (pm *PasswordManager) IsPasswordSet() bool {
-	_, err := os.ReadFile(pm.passwordPath)
+	pm.lock.Lock()
+	passwordSet := pm.passwordSet
+	pm.lock.Unlock()
+	if passwordSet {
+		return true
+	}
+	_, err := pm.GetPassword()
 	return (err == nil)
 }
 
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
-
-	// Read from disk
-	password, err := os.ReadFile(pm.passwordPath)
-	if err != nil {
-		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	password, err := pm.provider.GetPassword()
+	if errors.Is(err, ErrPasswordNotSet) {
+		return "", fmt.Errorf("Could not get password from %s: %w", pm.provider.Name(), err)
 	}
-
-	// Return
-	return string(password), nil
-
+	if err == nil {
+		pm.setPasswordSet(true)
+	}
+	return password, err
 }
 
 // Set the password
@@ -52,34 +69,61 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return errors.New("Password is already set")
 	}
 
-	// Check password length
-	if len(password) < MinPasswordLength {
-		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
-	}
+	// Store it
+	return pm.storePassword(password)
 
-	// Write to disk
-	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
-		return fmt.Errorf("Could not write password to disk: %w", err)
+}
+
+// Get the path of the file the password is stored in, or an empty string if it isn't stored in a plaintext file
+func (pm *PasswordManager) GetPasswordFilePath() string {
+	if fileProvider, ok := pm.provider.(*FileProvider); ok {
+		return fileProvider.path
 	}
+	return ""
+}
 
-	// Return
+// Check that a password can be used as the node password
+func (pm *PasswordManager) CheckPassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
+	}
 	return nil
+}
 
+// Replace the password that's already set
+func (pm *PasswordManager) ChangePassword(password string) error {
+	return pm.storePassword(password)
 }
 
 // Delete the password
 func (pm *PasswordManager) DeletePassword() error {
+	pm.setPasswordSet(false)
+	return pm.provider.DeletePassword()
+}
 
-	// Check if it exists
-	_, err := os.Stat(pm.passwordPath)
-	if os.IsNotExist(err) {
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error checking password file path: %w", err)
+// Check the password's length and write it to the provider
+func (pm *PasswordManager) storePassword(password string) error {
+
+	// Check password length
+	if err := pm.CheckPassword(password); err != nil {
+		return err
 	}
 
-	// Delete it
-	err = os.Remove(pm.passwordPath)
+	// Store it
+	err := pm.provider.SetPassword(password)
+	if errors.Is(err, ErrReadOnlyProvider) {
+		return fmt.Errorf("The node password comes from %s, which the Smartnode can't write to; please store the password there yourself", pm.provider.Name())
+	}
+	if err == nil {
+		pm.setPasswordSet(true)
+	}
 	return err
 
 }
+
+// Record whether the password is known to be set
+func (pm *PasswordManager) setPasswordSet(passwordSet bool) {
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	pm.passwordSet = passwordSet
+}
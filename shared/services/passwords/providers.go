@@ -0,0 +1,471 @@
+package passwords
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config
+const (
+	SystemdCredentialsDirEnvVar string = "CREDENTIALS_DIRECTORY"
+	VaultTokenEnvVar            string = "VAULT_TOKEN"
+	vaultTimeout                       = 10 * time.Second
+)
+
+// A source for the node password
+type PasswordProvider interface {
+	// A description of where the password comes from, for error messages
+	Name() string
+
+	// Get the password; returns ErrPasswordNotSet if the source doesn't have one
+	GetPassword() (string, error)
+
+	// Store the password; returns ErrReadOnlyProvider if the source can't be written to
+	SetPassword(password string) error
+
+	// Remove the password from the source, if possible
+	DeletePassword() error
+}
+
+// Errors returned by providers
+var (
+	ErrPasswordNotSet   = errors.New("the password has not been set")
+	ErrReadOnlyProvider = errors.New("the password source is read-only")
+)
+
+// ====================
+// === File on disk ===
+// ====================
+
+// Reads and writes the password as a plaintext file
+type FileProvider struct {
+	path string
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{
+		path: path,
+	}
+}
+
+func (p *FileProvider) Name() string {
+	return fmt.Sprintf("the password file (%s)", p.path)
+}
+
+func (p *FileProvider) GetPassword() (string, error) {
+	password, err := os.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		return "", ErrPasswordNotSet
+	} else if err != nil {
+		return "", fmt.Errorf("Could not read password from disk: %w", err)
+	}
+	return string(password), nil
+}
+
+func (p *FileProvider) SetPassword(password string) error {
+	if err := os.WriteFile(p.path, []byte(password), FileMode); err != nil {
+		return fmt.Errorf("Could not write password to disk: %w", err)
+	}
+	return nil
+}
+
+func (p *FileProvider) DeletePassword() error {
+	_, err := os.Stat(p.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking password file path: %w", err)
+	}
+	return os.Remove(p.path)
+}
+
+// =======================
+// === File descriptor ===
+// =======================
+
+// Reads the password once from an inherited file descriptor, such as a pipe set up by the process that launched the daemon.
+// The password is kept in memory afterwards, since the descriptor can only be read once.
+type FileDescriptorProvider struct {
+	fd       uintptr
+	password *string
+	err      error
+	once     sync.Once
+}
+
+func NewFileDescriptorProvider(fd uintptr) *FileDescriptorProvider {
+	return &FileDescriptorProvider{
+		fd: fd,
+	}
+}
+
+func (p *FileDescriptorProvider) Name() string {
+	return fmt.Sprintf("file descriptor %d", p.fd)
+}
+
+func (p *FileDescriptorProvider) GetPassword() (string, error) {
+	p.once.Do(func() {
+		file := os.NewFile(p.fd, fmt.Sprintf("password-fd-%d", p.fd))
+		if file == nil {
+			p.err = fmt.Errorf("file descriptor %d is invalid", p.fd)
+			return
+		}
+		defer file.Close()
+		bytes, err := io.ReadAll(file)
+		if err != nil {
+			p.err = fmt.Errorf("Could not read password from file descriptor %d: %w", p.fd, err)
+			return
+		}
+		password := trimPassword(string(bytes))
+		p.password = &password
+	})
+	if p.err != nil {
+		return "", p.err
+	}
+	if *p.password == "" {
+		return "", ErrPasswordNotSet
+	}
+	return *p.password, nil
+}
+
+func (p *FileDescriptorProvider) SetPassword(password string) error {
+	return ErrReadOnlyProvider
+}
+
+func (p *FileDescriptorProvider) DeletePassword() error {
+	return nil
+}
+
+// ============================
+// === Environment variable ===
+// ============================
+
+// Reads the password from an environment variable
+type EnvironmentProvider struct {
+	name string
+}
+
+func NewEnvironmentProvider(name string) *EnvironmentProvider {
+	return &EnvironmentProvider{
+		name: name,
+	}
+}
+
+func (p *EnvironmentProvider) Name() string {
+	return fmt.Sprintf("the %s environment variable", p.name)
+}
+
+func (p *EnvironmentProvider) GetPassword() (string, error) {
+	password, exists := os.LookupEnv(p.name)
+	if !exists || password == "" {
+		return "", ErrPasswordNotSet
+	}
+	return password, nil
+}
+
+func (p *EnvironmentProvider) SetPassword(password string) error {
+	return ErrReadOnlyProvider
+}
+
+func (p *EnvironmentProvider) DeletePassword() error {
+	return nil
+}
+
+// ==========================
+// === systemd credential ===
+// ==========================
+
+// Reads the password from a systemd credential (LoadCredential= or LoadCredentialEncrypted= in the unit file), which
+// systemd decrypts into a private, non-swappable directory for the lifetime of the service
+type SystemdCredentialProvider struct {
+	name string
+}
+
+func NewSystemdCredentialProvider(name string) *SystemdCredentialProvider {
+	return &SystemdCredentialProvider{
+		name: name,
+	}
+}
+
+func (p *SystemdCredentialProvider) Name() string {
+	return fmt.Sprintf("the %s systemd credential", p.name)
+}
+
+func (p *SystemdCredentialProvider) GetPassword() (string, error) {
+	dir := os.Getenv(SystemdCredentialsDirEnvVar)
+	if dir == "" {
+		return "", fmt.Errorf("%s is not set; the process must be started by systemd with the %s credential", SystemdCredentialsDirEnvVar, p.name)
+	}
+	password, err := os.ReadFile(filepath.Join(dir, p.name))
+	if os.IsNotExist(err) {
+		return "", ErrPasswordNotSet
+	} else if err != nil {
+		return "", fmt.Errorf("Could not read the %s systemd credential: %w", p.name, err)
+	}
+	return trimPassword(string(password)), nil
+}
+
+func (p *SystemdCredentialProvider) SetPassword(password string) error {
+	return ErrReadOnlyProvider
+}
+
+func (p *SystemdCredentialProvider) DeletePassword() error {
+	return nil
+}
+
+// ========================
+// === External command ===
+// ========================
+
+// Runs a shell command, such as `pass show rocketpool` or `gpg --decrypt password.gpg`, and uses its output as the password
+type CommandProvider struct {
+	command string
+}
+
+func NewCommandProvider(command string) *CommandProvider {
+	return &CommandProvider{
+		command: command,
+	}
+}
+
+func (p *CommandProvider) Name() string {
+	return fmt.Sprintf("the password command (%s)", p.command)
+}
+
+func (p *CommandProvider) GetPassword() (string, error) {
+	cmd := exec.Command("sh", "-c", p.command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("error running password command: %w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+	password := trimPassword(string(output))
+	if password == "" {
+		return "", ErrPasswordNotSet
+	}
+	return password, nil
+}
+
+func (p *CommandProvider) SetPassword(password string) error {
+	return ErrReadOnlyProvider
+}
+
+func (p *CommandProvider) DeletePassword() error {
+	return nil
+}
+
+// =============================
+// === HashiCorp Vault KV v2 ===
+// =============================
+
+// Reads and writes the password in a HashiCorp Vault KV (version 2) secrets engine
+type VaultProvider struct {
+	address   string
+	mount     string
+	path      string
+	field     string
+	tokenPath string
+	client    *http.Client
+}
+
+// The body of a KV v2 read or write
+type vaultKvData struct {
+	Data     map[string]interface{} `json:"data"`
+	Metadata *vaultKvMetadata       `json:"metadata,omitempty"`
+	Options  *vaultKvOptions        `json:"options,omitempty"`
+}
+
+// The metadata of a KV v2 secret version
+type vaultKvMetadata struct {
+	Version uint64 `json:"version"`
+}
+
+// The options of a KV v2 write
+type vaultKvOptions struct {
+	Cas uint64 `json:"cas"`
+}
+
+// The body of a Vault error
+type vaultErrors struct {
+	Errors []string `json:"errors"`
+}
+
+// Create a Vault provider. If the token path is blank, the token is read from the VAULT_TOKEN environment variable.
+func NewVaultProvider(address string, mount string, path string, field string, tokenPath string) *VaultProvider {
+	return &VaultProvider{
+		address:   strings.TrimSuffix(address, "/"),
+		mount:     strings.Trim(mount, "/"),
+		path:      strings.Trim(path, "/"),
+		field:     field,
+		tokenPath: tokenPath,
+		client:    &http.Client{Timeout: vaultTimeout},
+	}
+}
+
+func (p *VaultProvider) Name() string {
+	return fmt.Sprintf("the %s field of Vault secret %s/%s at %s", p.field, p.mount, p.path, p.address)
+}
+
+func (p *VaultProvider) GetPassword() (string, error) {
+	secret, err := p.readSecret()
+	if err != nil {
+		return "", err
+	}
+	value, exists := secret.Data[p.field]
+	if !exists {
+		return "", ErrPasswordNotSet
+	}
+	password, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("the %s field of Vault secret %s/%s is not a string", p.field, p.mount, p.path)
+	}
+	return password, nil
+}
+
+// The secret can hold other fields, so only the password field is changed
+func (p *VaultProvider) SetPassword(password string) error {
+	return p.updateSecret(func(data map[string]interface{}) {
+		data[p.field] = password
+	})
+}
+
+// The secret can hold other fields, so only the password field is removed, and the secret is only deleted if it's then empty
+func (p *VaultProvider) DeletePassword() error {
+	secret, err := p.readSecret()
+	if err != nil {
+		return err
+	}
+	if _, exists := secret.Data[p.field]; !exists {
+		return nil
+	}
+	if len(secret.Data) == 1 {
+		_, err := p.request(http.MethodDelete, "metadata", nil, nil)
+		return err
+	}
+	return p.updateSecret(func(data map[string]interface{}) {
+		delete(data, p.field)
+	})
+}
+
+// Read the latest version of the secret. Its data is empty if the secret doesn't exist or that version was deleted.
+func (p *VaultProvider) readSecret() (*vaultKvData, error) {
+	var response struct {
+		Data vaultKvData `json:"data"`
+	}
+	if _, err := p.request(http.MethodGet, "data", nil, &response); err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// Modify the latest version of the secret and write it back. The write is check-and-set against the version that was
+// read, so it fails instead of discarding a change someone else made in between.
+func (p *VaultProvider) updateSecret(modify func(data map[string]interface{})) error {
+	secret, err := p.readSecret()
+	if err != nil {
+		return err
+	}
+	data := secret.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	version := uint64(0)
+	if secret.Metadata != nil {
+		version = secret.Metadata.Version
+	}
+	modify(data)
+	body := vaultKvData{
+		Data:    data,
+		Options: &vaultKvOptions{Cas: version},
+	}
+	_, err = p.request(http.MethodPost, "data", body, nil)
+	return err
+}
+
+// Send a request to the KV v2 API, returning false if the secret doesn't exist
+func (p *VaultProvider) request(method string, endpoint string, body interface{}, result interface{}) (bool, error) {
+	token, err := p.getToken()
+	if err != nil {
+		return false, err
+	}
+
+	// Build the request
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return false, fmt.Errorf("error encoding Vault request: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+	requestUrl := fmt.Sprintf("%s/v1/%s/%s/%s", p.address, url.PathEscape(p.mount), endpoint, p.path)
+	request, err := http.NewRequest(method, requestUrl, bodyReader)
+	if err != nil {
+		return false, fmt.Errorf("error creating Vault request: %w", err)
+	}
+	request.Header.Set("X-Vault-Token", token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	// Send it
+	response, err := p.client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("error contacting Vault at %s: %w", p.address, err)
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, fmt.Errorf("error reading Vault response: %w", err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		// A deleted secret version still has its metadata in the body
+		if result != nil {
+			_ = json.Unmarshal(responseBytes, result)
+		}
+		return false, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var vaultErr vaultErrors
+		_ = json.Unmarshal(responseBytes, &vaultErr)
+		return false, fmt.Errorf("Vault returned status %d: %s", response.StatusCode, strings.Join(vaultErr.Errors, "; "))
+	}
+	if result != nil {
+		if err := json.Unmarshal(responseBytes, result); err != nil {
+			return false, fmt.Errorf("error decoding Vault response: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// Get the Vault token
+func (p *VaultProvider) getToken() (string, error) {
+	if p.tokenPath == "" {
+		token := os.Getenv(VaultTokenEnvVar)
+		if token == "" {
+			return "", fmt.Errorf("no Vault token file is configured and %s is not set", VaultTokenEnvVar)
+		}
+		return token, nil
+	}
+	token, err := os.ReadFile(p.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading Vault token from %s: %w", p.tokenPath, err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+// Remove the trailing newline that files and command output usually end with
+func trimPassword(password string) string {
+	return strings.TrimRight(password, "\r\n")
+}
@@ -44,6 +44,13 @@ func RequireNodePassword(c *cli.Context) error {
 }
 
 func RequireNodeWallet(c *cli.Context) error {
+	externalAccount, err := getNodeAccountExternal(c)
+	if err != nil {
+		return err
+	}
+	if externalAccount {
+		return nil
+	}
 	if err := RequireNodePassword(c); err != nil {
 		return err
 	}
@@ -176,6 +183,13 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 }
 
 func WaitNodeWallet(c *cli.Context, verbose bool) error {
+	externalAccount, err := getNodeAccountExternal(c)
+	if err != nil {
+		return err
+	}
+	if externalAccount {
+		return nil
+	}
 	if err := WaitNodePassword(c, verbose); err != nil {
 		return err
 	}
@@ -267,6 +281,15 @@ func getNodeWalletInitialized(c *cli.Context) (bool, error) {
 	return w.GetInitialized()
 }
 
+// Check if the node account's key is kept outside of the node wallet, either by an external signer or on an offline machine
+func getNodeAccountExternal(c *cli.Context) (bool, error) {
+	w, err := GetWallet(c)
+	if err != nil {
+		return false, err
+	}
+	return (w.IsWatchOnly() || w.GetExternalSigner() != nil), nil
+}
+
 // Check if the RocketStorage contract is loaded
 func getRocketStorageLoaded(c *cli.Context) (bool, error) {
 	cfg, err := GetConfig(c)
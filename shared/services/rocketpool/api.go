@@ -2,15 +2,27 @@ package rocketpool
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// Returned when waiting for a transaction that was exported for offline signing, so there's nothing to wait for
+var ErrTransactionExported = errors.New("The transaction was exported for offline signing instead of being submitted")
+
 // Wait for a transaction
 func (c *Client) WaitForTransaction(txHash common.Hash) (api.APIResponse, error) {
+	// Exported transactions haven't been submitted, so there's nothing to wait for
+	if files := c.TakeExportedTransactions(); len(files) > 0 {
+		return api.APIResponse{}, fmt.Errorf("%w. It was saved to %s; sign it with `rocketpool wallet sign-tx` and submit it with `rocketpool wallet broadcast`.", ErrTransactionExported, strings.Join(files, ", "))
+	}
+	if c.IsExportedTransaction(txHash) {
+		return api.APIResponse{}, ErrTransactionExported
+	}
 	responseBytes, err := c.callAPI(fmt.Sprintf("wait %s", txHash.String()))
 	if err != nil {
 		return api.APIResponse{}, fmt.Errorf("Error waiting for tx: %w", err)
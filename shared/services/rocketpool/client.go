@@ -2,6 +2,7 @@ package rocketpool
 
 import (
 	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -23,10 +24,13 @@ import (
 
 	"github.com/alessio/shellescape"
 	"github.com/blang/semver/v4"
+	"github.com/ethereum/go-ethereum/common"
 	externalip "github.com/glendc/go-external-ip"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
@@ -87,6 +91,12 @@ type Client struct {
 	maxPrioFee         float64
 	gasLimit           uint64
 	customNonce        *big.Int
+	scheduleDeadline   time.Time
+	simulations        []*simulation.Result
+	exportDir          string
+	skipTrace          bool
+	exportedFiles      []string
+	exportedHashes     map[common.Hash]bool
 	client             *ssh.Client
 	originalMaxFee     float64
 	originalMaxPrioFee float64
@@ -98,13 +108,23 @@ type Client struct {
 
 // Create new Rocket Pool client from CLI context
 func NewClientFromCtx(c *cli.Context) (*Client, error) {
-	return NewClient(c.GlobalString("config-path"),
+	client, err := NewClient(c.GlobalString("config-path"),
 		c.GlobalString("daemon-path"),
 		c.GlobalFloat64("maxFee"),
 		c.GlobalFloat64("maxPrioFee"),
 		c.GlobalUint64("gasLimit"),
 		c.GlobalString("nonce"),
 		c.GlobalBool("debug"))
+	if err != nil {
+		return nil, err
+	}
+	if exportDir := c.GlobalString("export-unsigned"); exportDir != "" {
+		err = client.ExportUnsignedTransactions(exportDir)
+	}
+	if c.GlobalBool("skip-trace") {
+		client.SkipTransactionTraces()
+	}
+	return client, err
 }
 
 // Create new Rocket Pool client
@@ -1079,6 +1099,80 @@ func (c *Client) AssignGasSettings(maxFee float64, maxPrioFee float64, gasLimit
 	c.gasLimit = gasLimit
 }
 
+// Have the API add transactions to the node daemon's scheduler instead of submitting them.
+// They'll be submitted once the base fee is low enough for their max fee, or once the deadline passes.
+func (c *Client) ScheduleTransactions(deadline time.Time) {
+	c.scheduleDeadline = deadline
+}
+
+// Check if transactions are being scheduled instead of submitted
+func (c *Client) IsSchedulingTransactions() bool {
+	return !c.scheduleDeadline.IsZero()
+}
+
+// Have the API export unsigned transactions instead of submitting them, saving them to files in the given folder so they
+// can be signed offline with `rocketpool wallet sign-tx`
+func (c *Client) ExportUnsignedTransactions(dir string) error {
+	expandedDir, err := homedir.Expand(dir)
+	if err != nil {
+		return fmt.Errorf("error expanding unsigned transaction folder: %w", err)
+	}
+	if err := os.MkdirAll(expandedDir, 0700); err != nil {
+		return fmt.Errorf("error creating unsigned transaction folder: %w", err)
+	}
+	c.exportDir = expandedDir
+	return nil
+}
+
+// Have the API only check transactions for reverts instead of tracing the balance changes they'll make
+func (c *Client) SkipTransactionTraces() {
+	c.skipTrace = true
+}
+
+// Check if unsigned transactions are being exported instead of submitted
+func (c *Client) IsExportingTransactions() bool {
+	return c.exportDir != ""
+}
+
+// Check if the given transaction hash belongs to an unsigned transaction that was exported instead of submitted
+func (c *Client) IsExportedTransaction(hash common.Hash) bool {
+	return c.exportedHashes[hash]
+}
+
+// Get the files the unsigned transactions exported since the last call were saved to, and clear them
+func (c *Client) TakeExportedTransactions() []string {
+	files := c.exportedFiles
+	c.exportedFiles = nil
+	return files
+}
+
+// Save unsigned transactions returned by the API to the export folder
+func (c *Client) saveExportedTransactions(unsignedTxs []*transactions.OfflineTransaction) error {
+	for _, offlineTx := range unsignedTxs {
+		tx, err := offlineTx.GetUnsignedTransaction()
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(c.exportDir, fmt.Sprintf("unsigned-tx-%d-%s.json", offlineTx.Nonce, tx.Hash().Hex()[2:10]))
+		if err := offlineTx.Save(path); err != nil {
+			return err
+		}
+		c.exportedFiles = append(c.exportedFiles, path)
+		if c.exportedHashes == nil {
+			c.exportedHashes = map[common.Hash]bool{}
+		}
+		c.exportedHashes[tx.Hash()] = true
+	}
+	return nil
+}
+
+// Get the transaction simulation results returned by the most recent API call that simulated transactions, and clear them
+func (c *Client) TakeSimulations() []*simulation.Result {
+	simulations := c.simulations
+	c.simulations = nil
+	return simulations
+}
+
 // Set the flags for ignoring EC and CC sync checks and forcing fallbacks to prevent unnecessary duplication of effort by the API during CLI commands
 func (c *Client) SetClientStatusFlags(ignoreSyncCheck bool, forceFallbacks bool) {
 	c.ignoreSyncCheck = ignoreSyncCheck
@@ -1578,6 +1672,21 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.MevBoostContainerName+composeFileSuffix))
 	}
 
+	// Check Web3Signer
+	if cfg.EnableWeb3Signer.Value == true && cfg.Web3Signer.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		contents, err = envsubst.ReadFile(filepath.Join(templatesFolder, config.Web3SignerContainerName+templateSuffix))
+		if err != nil {
+			return []string{}, fmt.Errorf("error reading and substituting Web3Signer container template: %w", err)
+		}
+		web3SignerComposePath := filepath.Join(runtimeFolder, config.Web3SignerContainerName+composeFileSuffix)
+		err = os.WriteFile(web3SignerComposePath, contents, 0664)
+		if err != nil {
+			return []string{}, fmt.Errorf("could not write Web3Signer container file to %s: %w", web3SignerComposePath, err)
+		}
+		deployedContainers = append(deployedContainers, web3SignerComposePath)
+		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.Web3SignerContainerName+composeFileSuffix))
+	}
+
 	// Create the custom keys dir
 	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
 	if err != nil {
@@ -1754,6 +1863,26 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 	c.maxPrioFee = c.originalMaxPrioFee
 	c.gasLimit = c.originalGasLimit
 
+	// Keep any transaction simulation results so they can be shown before the transaction is confirmed
+	if err == nil {
+		var simulationResponse struct {
+			Simulations []*simulation.Result `json:"simulations"`
+		}
+		if json.Unmarshal(output, &simulationResponse) == nil && len(simulationResponse.Simulations) > 0 {
+			c.simulations = simulationResponse.Simulations
+		}
+	}
+
+	// Save any unsigned transactions that were exported for offline signing
+	if err == nil && c.exportDir != "" {
+		var exportResponse struct {
+			UnsignedTransactions []*transactions.OfflineTransaction `json:"unsignedTransactions"`
+		}
+		if json.Unmarshal(output, &exportResponse) == nil && len(exportResponse.UnsignedTransactions) > 0 {
+			err = c.saveExportedTransactions(exportResponse.UnsignedTransactions)
+		}
+	}
+
 	return output, err
 }
 
@@ -1775,6 +1904,15 @@ func (c *Client) getGasOpts() string {
 	opts += fmt.Sprintf("--maxFee %f ", c.maxFee)
 	opts += fmt.Sprintf("--maxPrioFee %f ", c.maxPrioFee)
 	opts += fmt.Sprintf("--gasLimit %d ", c.gasLimit)
+	if !c.scheduleDeadline.IsZero() {
+		opts += fmt.Sprintf("--schedule-deadline %d ", c.scheduleDeadline.Unix())
+	}
+	if c.exportDir != "" {
+		opts += "--export-unsigned "
+	}
+	if c.skipTrace {
+		opts += "--skip-trace "
+	}
 	return opts
 }
 
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
@@ -216,6 +220,38 @@ func (c *Client) ExitMinipool(address common.Address) (api.ExitMinipoolResponse,
 	return response, nil
 }
 
+// Sign a voluntary exit for a minipool without broadcasting it
+func (c *Client) PresignExit(address common.Address) (api.PresignExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool presign-exit %s", address.Hex()))
+	if err != nil {
+		return api.PresignExitResponse{}, fmt.Errorf("Could not pre-sign exit: %w", err)
+	}
+	var response api.PresignExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PresignExitResponse{}, fmt.Errorf("Could not decode pre-sign exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PresignExitResponse{}, fmt.Errorf("Could not pre-sign exit: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a pre-signed voluntary exit
+func (c *Client) BroadcastExit(validatorIndex uint64, epoch uint64, signature types.ValidatorSignature) (api.BroadcastExitResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool broadcast-exit %d %d %s", validatorIndex, epoch, signature.Hex()))
+	if err != nil {
+		return api.BroadcastExitResponse{}, fmt.Errorf("Could not broadcast exit: %w", err)
+	}
+	var response api.BroadcastExitResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastExitResponse{}, fmt.Errorf("Could not decode broadcast exit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastExitResponse{}, fmt.Errorf("Could not broadcast exit: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check all of the node's minipools for closure eligibility, and return the details of the closeable ones
 func (c *Client) GetMinipoolCloseDetailsForNode() (api.GetMinipoolCloseDetailsForNodeResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-minipool-close-details-for-node")
@@ -424,6 +460,70 @@ func (c *Client) ReduceBondAmount(address common.Address) (api.ReduceBondAmountR
 	return response, nil
 }
 
+// Simulate reducing the bonds of the given minipools, or all of the node's 16 ETH minipools if none are given
+func (c *Client) PlanBondReduction(addresses []common.Address) (api.PlanBondReductionResponse, error) {
+	addressesArg := "all"
+	if len(addresses) > 0 {
+		addressStrings := make([]string, len(addresses))
+		for i, address := range addresses {
+			addressStrings[i] = address.Hex()
+		}
+		addressesArg = strings.Join(addressStrings, ",")
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool plan-bond-reduction %s", addressesArg))
+	if err != nil {
+		return api.PlanBondReductionResponse{}, fmt.Errorf("Could not plan bond reduction: %w", err)
+	}
+	var response api.PlanBondReductionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PlanBondReductionResponse{}, fmt.Errorf("Could not decode plan bond reduction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PlanBondReductionResponse{}, fmt.Errorf("Could not plan bond reduction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the lifecycle timeline of a minipool, scanning from the given block (or from the node's registration if 0)
+func (c *Client) GetMinipoolTimeline(address common.Address, startBlock uint64) (api.MinipoolTimelineResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool timeline %s %d", address.Hex(), startBlock))
+	if err != nil {
+		return api.MinipoolTimelineResponse{}, fmt.Errorf("Could not get minipool timeline: %w", err)
+	}
+	var response api.MinipoolTimelineResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolTimelineResponse{}, fmt.Errorf("Could not decode minipool timeline response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolTimelineResponse{}, fmt.Errorf("Could not get minipool timeline: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's earnings from the given minipools (or all of its minipools if none are given) over a time range
+func (c *Client) GetMinipoolIncomeStatement(addresses []common.Address, startTime time.Time, endTime time.Time) (api.MinipoolIncomeStatementResponse, error) {
+	addressesArg := "all"
+	if len(addresses) > 0 {
+		addressStrings := make([]string, len(addresses))
+		for i, address := range addresses {
+			addressStrings[i] = address.Hex()
+		}
+		addressesArg = strings.Join(addressStrings, ",")
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool income-statement %s %d %d", addressesArg, startTime.Unix(), endTime.Unix()))
+	if err != nil {
+		return api.MinipoolIncomeStatementResponse{}, fmt.Errorf("Could not get minipool income statement: %w", err)
+	}
+	var response api.MinipoolIncomeStatementResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolIncomeStatementResponse{}, fmt.Errorf("Could not decode minipool income statement response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolIncomeStatementResponse{}, fmt.Errorf("Could not get minipool income statement: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the balance distribution details for all of the node's minipools
 func (c *Client) GetDistributeBalanceDetails() (api.GetDistributeBalanceDetailsResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-distribute-balance-details")
@@ -457,17 +557,17 @@ func (c *Client) DistributeBalance(address common.Address) (api.DistributeBalanc
 }
 
 // Import a validator private key for a vacant minipool
-func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ChangeWithdrawalCredentialsResponse, error) {
+func (c *Client) ImportKey(address common.Address, mnemonic string) (api.ImportKeyResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool import-key %s", address.Hex()), mnemonic)
 	if err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %w", err)
 	}
-	var response api.ChangeWithdrawalCredentialsResponse
+	var response api.ImportKeyResponse
 	if err := json.Unmarshal(responseBytes, &response); err != nil {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not decode import-key response: %w", err)
 	}
 	if response.Error != "" {
-		return api.ChangeWithdrawalCredentialsResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
+		return api.ImportKeyResponse{}, fmt.Errorf("Could not import validator key: %s", response.Error)
 	}
 	return response, nil
 }
@@ -503,3 +603,119 @@ func (c *Client) ChangeWithdrawalCredentials(address common.Address, mnemonic st
 	}
 	return response, nil
 }
+
+// Sign withdrawal creds changes for a list of validators using the mnemonic their withdrawal keys were derived from
+func (c *Client) SignWithdrawalCredsChanges(mnemonic string, validatorIndices []uint64) (api.SignWithdrawalCredsChangesResponse, error) {
+	indexStrings := make([]string, len(validatorIndices))
+	for i, index := range validatorIndices {
+		indexStrings[i] = strconv.FormatUint(index, 10)
+	}
+	responseBytes, err := c.callAPI("minipool sign-withdrawal-creds-changes", mnemonic, strings.Join(indexStrings, ","))
+	if err != nil {
+		return api.SignWithdrawalCredsChangesResponse{}, fmt.Errorf("Could not sign withdrawal creds changes: %w", err)
+	}
+	var response api.SignWithdrawalCredsChangesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignWithdrawalCredsChangesResponse{}, fmt.Errorf("Could not decode sign-withdrawal-creds-changes response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignWithdrawalCredsChangesResponse{}, fmt.Errorf("Could not sign withdrawal creds changes: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether a signed withdrawal creds change can be submitted
+func (c *Client) CanSubmitWithdrawalCredsChange(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) (api.CanSubmitWithdrawalCredsChangeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-submit-withdrawal-creds-change %d %s %s %s", validatorIndex, fromBlsPubkey.Hex(), toExecutionAddress.Hex(), signature.Hex()))
+	if err != nil {
+		return api.CanSubmitWithdrawalCredsChangeResponse{}, fmt.Errorf("Could not get can-submit-withdrawal-creds-change status: %w", err)
+	}
+	var response api.CanSubmitWithdrawalCredsChangeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSubmitWithdrawalCredsChangeResponse{}, fmt.Errorf("Could not decode can-submit-withdrawal-creds-change response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSubmitWithdrawalCredsChangeResponse{}, fmt.Errorf("Could not get can-submit-withdrawal-creds-change status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Submit a signed withdrawal creds change to the Beacon Chain
+func (c *Client) SubmitWithdrawalCredsChange(validatorIndex uint64, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) (api.SubmitWithdrawalCredsChangeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool submit-withdrawal-creds-change %d %s %s %s", validatorIndex, fromBlsPubkey.Hex(), toExecutionAddress.Hex(), signature.Hex()))
+	if err != nil {
+		return api.SubmitWithdrawalCredsChangeResponse{}, fmt.Errorf("Could not submit withdrawal creds change: %w", err)
+	}
+	var response api.SubmitWithdrawalCredsChangeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SubmitWithdrawalCredsChangeResponse{}, fmt.Errorf("Could not decode submit-withdrawal-creds-change response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SubmitWithdrawalCredsChangeResponse{}, fmt.Errorf("Could not submit withdrawal creds change: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the addresses of the node's minipools that match a selector
+func (c *Client) SelectMinipools(selector string) (api.SelectMinipoolsResponse, error) {
+	responseBytes, err := c.callAPI("minipool select", selector)
+	if err != nil {
+		return api.SelectMinipoolsResponse{}, fmt.Errorf("Could not select minipools: %w", err)
+	}
+	var response api.SelectMinipoolsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SelectMinipoolsResponse{}, fmt.Errorf("Could not decode select minipools response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SelectMinipoolsResponse{}, fmt.Errorf("Could not select minipools: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the current stage of each solo validator migration
+func (c *Client) GetSoloMigrations() (api.SoloMigrationsResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-solo-migrations")
+	if err != nil {
+		return api.SoloMigrationsResponse{}, fmt.Errorf("Could not get solo migrations: %w", err)
+	}
+	var response api.SoloMigrationsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SoloMigrationsResponse{}, fmt.Errorf("Could not decode get-solo-migrations response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SoloMigrationsResponse{}, fmt.Errorf("Could not get solo migrations: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Start tracking a solo validator's migration into a minipool
+func (c *Client) StartSoloMigration(pubkey types.ValidatorPubkey, importKey bool) (api.StartSoloMigrationResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool start-solo-migration %s %t", pubkey.Hex(), importKey))
+	if err != nil {
+		return api.StartSoloMigrationResponse{}, fmt.Errorf("Could not start solo migration: %w", err)
+	}
+	var response api.StartSoloMigrationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.StartSoloMigrationResponse{}, fmt.Errorf("Could not decode start-solo-migration response: %w", err)
+	}
+	if response.Error != "" {
+		return api.StartSoloMigrationResponse{}, fmt.Errorf("Could not start solo migration: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Stop tracking a solo validator's migration
+func (c *Client) ForgetSoloMigration(pubkey types.ValidatorPubkey) (api.ForgetSoloMigrationResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool forget-solo-migration %s", pubkey.Hex()))
+	if err != nil {
+		return api.ForgetSoloMigrationResponse{}, fmt.Errorf("Could not forget solo migration: %w", err)
+	}
+	var response api.ForgetSoloMigrationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ForgetSoloMigrationResponse{}, fmt.Errorf("Could not decode forget-solo-migration response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ForgetSoloMigrationResponse{}, fmt.Errorf("Could not forget solo migration: %s", response.Error)
+	}
+	return response, nil
+}
@@ -182,3 +182,19 @@ func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
 	}
 	return response, nil
 }
+
+// Get gas price suggestions based on the Execution client's fee history
+func (c *Client) GetFeeHistoryGasPrices() (api.FeeHistoryGasPricesResponse, error) {
+	responseBytes, err := c.callAPI("network fee-history-gas-prices")
+	if err != nil {
+		return api.FeeHistoryGasPricesResponse{}, fmt.Errorf("could not get fee history gas prices: %w", err)
+	}
+	var response api.FeeHistoryGasPricesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.FeeHistoryGasPricesResponse{}, fmt.Errorf("could not decode fee-history-gas-prices response: %w", err)
+	}
+	if response.Error != "" {
+		return api.FeeHistoryGasPricesResponse{}, fmt.Errorf("could not get fee history gas prices: %s", response.Error)
+	}
+	return response, nil
+}
@@ -190,6 +190,22 @@ func (c *Client) SetNodeTimezone(timezoneLocation string) (api.SetNodeTimezoneRe
 	return response, nil
 }
 
+// Set or clear the graffiti and builder gas limit overrides for one of the node's validators
+func (c *Client) SetValidatorSettings(pubkey types.ValidatorPubkey, setGraffiti bool, graffiti string, setGasLimit bool, gasLimit uint64) (api.SetValidatorSettingsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node set-validator-settings %s %t", pubkey.Hex(), setGraffiti), graffiti, fmt.Sprintf("%t", setGasLimit), fmt.Sprint(gasLimit))
+	if err != nil {
+		return api.SetValidatorSettingsResponse{}, fmt.Errorf("Could not set validator settings: %w", err)
+	}
+	var response api.SetValidatorSettingsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetValidatorSettingsResponse{}, fmt.Errorf("Could not decode set validator settings response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetValidatorSettingsResponse{}, fmt.Errorf("Could not set validator settings: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can swap RPL tokens
 func (c *Client) CanNodeSwapRpl(amountWei *big.Int) (api.CanNodeSwapRplResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-swap-rpl %s", amountWei.String()))
@@ -926,6 +942,54 @@ func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
 	return response, nil
 }
 
+// Get the transactions sent by the node account from the transaction journal
+func (c *Client) GetNodeTransactions() (api.NodeTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node transactions")
+	if err != nil {
+		return api.NodeTransactionsResponse{}, fmt.Errorf("Could not get node transactions: %w", err)
+	}
+	var response api.NodeTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTransactionsResponse{}, fmt.Errorf("Could not decode node transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTransactionsResponse{}, fmt.Errorf("Could not get node transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node account's scheduled transactions
+func (c *Client) GetScheduledTransactions() (api.NodeScheduledTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node scheduled-transactions")
+	if err != nil {
+		return api.NodeScheduledTransactionsResponse{}, fmt.Errorf("Could not get scheduled transactions: %w", err)
+	}
+	var response api.NodeScheduledTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeScheduledTransactionsResponse{}, fmt.Errorf("Could not decode scheduled transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeScheduledTransactionsResponse{}, fmt.Errorf("Could not get scheduled transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Cancel a scheduled transaction
+func (c *Client) CancelScheduledTransaction(id uint64) (api.CancelScheduledTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node cancel-scheduled-transaction %d", id))
+	if err != nil {
+		return api.CancelScheduledTransactionResponse{}, fmt.Errorf("Could not cancel scheduled transaction: %w", err)
+	}
+	var response api.CancelScheduledTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CancelScheduledTransactionResponse{}, fmt.Errorf("Could not decode cancel scheduled transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CancelScheduledTransactionResponse{}, fmt.Errorf("Could not cancel scheduled transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a vacant minipool can be created for solo staker migration
 func (c *Client) CanCreateVacantMinipool(amountWei *big.Int, minFee float64, salt *big.Int, pubkey types.ValidatorPubkey) (api.CanCreateVacantMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-create-vacant-minipool %s %f %s %s", amountWei.String(), minFee, salt.String(), pubkey.Hex()))
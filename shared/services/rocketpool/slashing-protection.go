@@ -0,0 +1,88 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Export the slashing protection data of a stopped Validator Client in the EIP-3076 interchange format.
+// The file is written to the validators folder; its path on the host is returned.
+func (c *Client) ExportSlashingProtection(validatorImage string, validatorsPath string, network cfgtypes.Network, toolContainer string) (string, error) {
+
+	tool, err := slashing.GetClientTool(validatorImage)
+	if err != nil {
+		return "", err
+	}
+
+	// Make the output folder
+	exportDir := filepath.Join(validatorsPath, slashing.InterchangeDir)
+	if err := os.MkdirAll(exportDir, 0775); err != nil {
+		return "", fmt.Errorf("could not create slashing protection folder %s: %w", exportDir, err)
+	}
+	fileName := slashing.GetExportFileName(tool.Client, fmt.Sprintf("%s-%s.json", tool.Client, time.Now().Format("20060102-150405")))
+	containerFile := strings.Join([]string{slashing.ValidatorsMountPath, slashing.InterchangeDir, fileName}, "/")
+
+	// Run the export
+	if err := c.runSlashingProtectionTool(toolContainer, validatorsPath, tool.Image, tool.Export(containerFile, slashing.GetClientNetworkName(network))); err != nil {
+		return "", fmt.Errorf("error exporting %s slashing protection data: %w", tool.Client, err)
+	}
+	hostFile := filepath.Join(exportDir, fileName)
+	if _, err := os.Stat(hostFile); err != nil {
+		return "", fmt.Errorf("%s didn't produce slashing protection data at %s: %w", tool.Client, hostFile, err)
+	}
+	return hostFile, nil
+
+}
+
+// Import EIP-3076 slashing protection data into a stopped Validator Client.
+// Files outside of the validators folder are copied into it first so the client's container can read them.
+func (c *Client) ImportSlashingProtection(validatorImage string, validatorsPath string, hostFile string, network cfgtypes.Network, toolContainer string) error {
+
+	tool, err := slashing.GetClientTool(validatorImage)
+	if err != nil {
+		return err
+	}
+
+	// Get the path of the file in the container
+	relativePath, err := filepath.Rel(validatorsPath, hostFile)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		bytes, err := os.ReadFile(hostFile)
+		if err != nil {
+			return fmt.Errorf("could not read slashing protection data from %s: %w", hostFile, err)
+		}
+		importDir := filepath.Join(validatorsPath, slashing.InterchangeDir)
+		if err := os.MkdirAll(importDir, 0775); err != nil {
+			return fmt.Errorf("could not create slashing protection folder %s: %w", importDir, err)
+		}
+		relativePath = filepath.Join(slashing.InterchangeDir, fmt.Sprintf("import-%s.json", time.Now().Format("20060102-150405")))
+		if err := os.WriteFile(filepath.Join(validatorsPath, relativePath), bytes, 0644); err != nil {
+			return fmt.Errorf("could not copy slashing protection data into %s: %w", importDir, err)
+		}
+	}
+	containerFile := slashing.ValidatorsMountPath + "/" + filepath.ToSlash(relativePath)
+
+	// Run the import
+	if err := c.runSlashingProtectionTool(toolContainer, validatorsPath, tool.Image, tool.Import(containerFile, slashing.GetClientNetworkName(network))); err != nil {
+		return fmt.Errorf("error importing slashing protection data into %s: %w", tool.Client, err)
+	}
+	return nil
+
+}
+
+// Run a Validator Client image's slashing protection command against the validators folder
+func (c *Client) runSlashingProtectionTool(container string, validatorsPath string, image string, args []string) error {
+	quotedArgs := make([]string, len(args))
+	for i, arg := range args {
+		quotedArgs[i] = shellescape.Quote(arg)
+	}
+	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:%s %s %s", container, shellescape.Quote(validatorsPath), slashing.ValidatorsMountPath, image, strings.Join(quotedArgs, " "))
+	return c.printOutput(cmd)
+}
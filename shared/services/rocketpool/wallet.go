@@ -3,8 +3,11 @@ package rocketpool
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -41,8 +44,8 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 }
 
 // Initialize wallet
-func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
-	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
+func (c *Client) InitWallet(derivationPath string, passphrase string) (api.InitWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet init --derivation-path", withPassphrase([]string{derivationPath}, passphrase)...)
 	if err != nil {
 		return api.InitWalletResponse{}, fmt.Errorf("Could not initialize wallet: %w", err)
 	}
@@ -57,7 +60,7 @@ func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, erro
 }
 
 // Recover wallet
-func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint, passphrase string) (api.RecoverWalletResponse, error) {
 	command := "wallet recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
@@ -67,7 +70,7 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 	}
 	command += "--derivation-path"
 
-	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	responseBytes, err := c.callAPI(command, withPassphrase([]string{derivationPath}, passphrase, mnemonic)...)
 	if err != nil {
 		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover wallet: %w", err)
 	}
@@ -82,13 +85,13 @@ func (c *Client) RecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, d
 }
 
 // Search and recover wallet
-func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
+func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool, passphrase string) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet search-and-recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
 	}
 
-	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
+	responseBytes, err := c.callAPI(command, withPassphrase(nil, passphrase, mnemonic, address.Hex())...)
 	if err != nil {
 		return api.SearchAndRecoverWalletResponse{}, fmt.Errorf("Could not search and recover wallet: %w", err)
 	}
@@ -103,7 +106,7 @@ func (c *Client) SearchAndRecoverWallet(mnemonic string, address common.Address,
 }
 
 // Recover wallet
-func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint) (api.RecoverWalletResponse, error) {
+func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery bool, derivationPath string, walletIndex uint, passphrase string) (api.RecoverWalletResponse, error) {
 	command := "wallet test-recovery "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
@@ -113,7 +116,7 @@ func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery boo
 	}
 	command += "--derivation-path"
 
-	responseBytes, err := c.callAPI(command, derivationPath, mnemonic)
+	responseBytes, err := c.callAPI(command, withPassphrase([]string{derivationPath}, passphrase, mnemonic)...)
 	if err != nil {
 		return api.RecoverWalletResponse{}, fmt.Errorf("Could not test recover wallet: %w", err)
 	}
@@ -128,13 +131,13 @@ func (c *Client) TestRecoverWallet(mnemonic string, skipValidatorKeyRecovery boo
 }
 
 // Search and recover wallet
-func (c *Client) TestSearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool) (api.SearchAndRecoverWalletResponse, error) {
+func (c *Client) TestSearchAndRecoverWallet(mnemonic string, address common.Address, skipValidatorKeyRecovery bool, passphrase string) (api.SearchAndRecoverWalletResponse, error) {
 	command := "wallet test-search-and-recover "
 	if skipValidatorKeyRecovery {
 		command += "--skip-validator-key-recovery "
 	}
 
-	responseBytes, err := c.callAPI(command, mnemonic, address.Hex())
+	responseBytes, err := c.callAPI(command, withPassphrase(nil, passphrase, mnemonic, address.Hex())...)
 	if err != nil {
 		return api.SearchAndRecoverWalletResponse{}, fmt.Errorf("Could not test search and recover wallet: %w", err)
 	}
@@ -149,8 +152,8 @@ func (c *Client) TestSearchAndRecoverWallet(mnemonic string, address common.Addr
 }
 
 // Rebuild wallet
-func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
-	responseBytes, err := c.callAPI("wallet rebuild")
+func (c *Client) RebuildWallet(passphrase string) (api.RebuildWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet rebuild", withPassphrase(nil, passphrase)...)
 	if err != nil {
 		return api.RebuildWalletResponse{}, fmt.Errorf("Could not rebuild wallet: %w", err)
 	}
@@ -164,6 +167,120 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Split the wallet's seed into Shamir backup shares
+func (c *Client) BackupWallet(shareCount int, threshold int) (api.BackupWalletResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet backup %d %d", shareCount, threshold))
+	if err != nil {
+		return api.BackupWalletResponse{}, fmt.Errorf("Could not back up wallet: %w", err)
+	}
+	var response api.BackupWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BackupWalletResponse{}, fmt.Errorf("Could not decode backup wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BackupWalletResponse{}, fmt.Errorf("Could not back up wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Recover wallet from Shamir backup shares
+func (c *Client) RecoverWalletFromShares(shares []string, skipValidatorKeyRecovery bool) (api.RecoverWalletResponse, error) {
+	command := "wallet recover-from-shares "
+	if skipValidatorKeyRecovery {
+		command += "--skip-validator-key-recovery "
+	}
+
+	responseBytes, err := c.callAPI(command, strings.Join(shares, ","))
+	if err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover wallet from shares: %w", err)
+	}
+	var response api.RecoverWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not decode recover-from-shares wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not recover wallet from shares: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Test recovering wallet from Shamir backup shares
+func (c *Client) TestRecoverWalletFromShares(shares []string, skipValidatorKeyRecovery bool) (api.RecoverWalletResponse, error) {
+	command := "wallet test-recover-from-shares "
+	if skipValidatorKeyRecovery {
+		command += "--skip-validator-key-recovery "
+	}
+
+	responseBytes, err := c.callAPI(command, strings.Join(shares, ","))
+	if err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not test recover wallet from shares: %w", err)
+	}
+	var response api.RecoverWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not decode test-recover-from-shares wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RecoverWalletResponse{}, fmt.Errorf("Could not test recover wallet from shares: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Export validator keys as EIP-2335 keystores
+func (c *Client) ExportValidatorKeys(password string, pubkeys []types.ValidatorPubkey) (api.ExportValidatorKeysResponse, error) {
+	pubkeyStrings := make([]string, len(pubkeys))
+	for i, pubkey := range pubkeys {
+		pubkeyStrings[i] = pubkey.Hex()
+	}
+	responseBytes, err := c.callAPI("wallet export-validator-keys", password, strings.Join(pubkeyStrings, ","))
+	if err != nil {
+		return api.ExportValidatorKeysResponse{}, fmt.Errorf("Could not export validator keys: %w", err)
+	}
+	var response api.ExportValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportValidatorKeysResponse{}, fmt.Errorf("Could not decode export validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportValidatorKeysResponse{}, fmt.Errorf("Could not export validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Audit the wallet's validator keys against the node's minipools
+func (c *Client) AuditValidatorKeys(lookahead uint) (api.AuditValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet audit-keys --lookahead %d", lookahead))
+	if err != nil {
+		return api.AuditValidatorKeysResponse{}, fmt.Errorf("Could not audit validator keys: %w", err)
+	}
+	var response api.AuditValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AuditValidatorKeysResponse{}, fmt.Errorf("Could not decode audit validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AuditValidatorKeysResponse{}, fmt.Errorf("Could not audit validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Re-encrypt the validator keystores and node wallet, optionally changing the node password
+func (c *Client) RotateKeystorePasswords(newPassword string) (api.RotateKeystorePasswordsResponse, error) {
+	var otherArgs []string
+	if newPassword != "" {
+		otherArgs = []string{"--new-password", newPassword}
+	}
+	responseBytes, err := c.callAPI("wallet rotate-keystore-passwords", otherArgs...)
+	if err != nil {
+		return api.RotateKeystorePasswordsResponse{}, fmt.Errorf("Could not rotate keystore passwords: %w", err)
+	}
+	var response api.RotateKeystorePasswordsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RotateKeystorePasswordsResponse{}, fmt.Errorf("Could not decode rotate keystore passwords response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RotateKeystorePasswordsResponse{}, fmt.Errorf("Could not rotate keystore passwords: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -211,3 +328,75 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Use an address without its keys as the node account
+func (c *Client) SetWatchOnlyAddress(address common.Address) (api.SetWatchOnlyAddressResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet set-watch-only %s", address.Hex()))
+	if err != nil {
+		return api.SetWatchOnlyAddressResponse{}, fmt.Errorf("Could not set watch-only address: %w", err)
+	}
+	var response api.SetWatchOnlyAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetWatchOnlyAddressResponse{}, fmt.Errorf("Could not decode set watch-only address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetWatchOnlyAddressResponse{}, fmt.Errorf("Could not set watch-only address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Stop using a watch-only node account
+func (c *Client) ClearWatchOnlyAddress() (api.SetWatchOnlyAddressResponse, error) {
+	responseBytes, err := c.callAPI("wallet clear-watch-only")
+	if err != nil {
+		return api.SetWatchOnlyAddressResponse{}, fmt.Errorf("Could not clear watch-only address: %w", err)
+	}
+	var response api.SetWatchOnlyAddressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetWatchOnlyAddressResponse{}, fmt.Errorf("Could not decode clear watch-only address response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetWatchOnlyAddressResponse{}, fmt.Errorf("Could not clear watch-only address: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Sign an unsigned transaction with the node account
+func (c *Client) SignTransaction(unsignedTx []byte) (api.SignTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet sign-tx %s", hexutil.Encode(unsignedTx)))
+	if err != nil {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %w", err)
+	}
+	var response api.SignTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not decode sign transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SignTransactionResponse{}, fmt.Errorf("Could not sign transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Submit a transaction signed by the node account
+func (c *Client) BroadcastTransaction(signedTx []byte, purpose string) (api.BroadcastTransactionResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet broadcast %s", hexutil.Encode(signedTx)), purpose)
+	if err != nil {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %w", err)
+	}
+	var response api.BroadcastTransactionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not decode broadcast transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastTransactionResponse{}, fmt.Errorf("Could not broadcast transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Add the BIP-39 passphrase flag to a wallet command's arguments if a passphrase is set, followed by its positional arguments
+func withPassphrase(args []string, passphrase string, positionalArgs ...string) []string {
+	if passphrase != "" {
+		args = append(args, "--passphrase", passphrase)
+	}
+	return append(args, positionalArgs...)
+}
@@ -5,8 +5,10 @@ import (
 	"math/big"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -17,12 +19,17 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	kmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	w3skeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -59,6 +66,9 @@ var (
 	initSnapshotDelegation sync.Once
 	initBeaconClient       sync.Once
 	initDocker             sync.Once
+
+	transactionSimulation bool
+	transactionTracing    bool
 )
 
 //
@@ -165,6 +175,22 @@ func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
 
+// Simulate transactions against the pending state whenever their gas is estimated, so reverts can be explained. Unless
+// tracing is disabled, they're also traced so the balance changes they'll make can be reported.
+// Must be called before any services are created.
+func EnableTransactionSimulation(tracing bool) {
+	transactionSimulation = true
+	transactionTracing = tracing
+}
+
+// Get the transaction simulation results recorded since the last time they were taken, and clear them
+func TakeSimulationResults() []*simulation.Result {
+	if ecManager == nil || ecManager.GetSimulator() == nil {
+		return nil
+	}
+	return ecManager.GetSimulator().TakeResults()
+}
+
 //
 // Service instance getters
 //
@@ -183,11 +209,34 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManagerWithProvider(getPasswordProvider(cfg))
 	})
 	return passwordManager
 }
 
+func getPasswordProvider(cfg *config.RocketPoolConfig) passwords.PasswordProvider {
+	switch cfg.Smartnode.PasswordSource.Value.(cfgtypes.PasswordSource) {
+	case cfgtypes.PasswordSource_FileDescriptor:
+		return passwords.NewFileDescriptorProvider(uintptr(cfg.Smartnode.PasswordFileDescriptor.Value.(uint64)))
+	case cfgtypes.PasswordSource_Environment:
+		return passwords.NewEnvironmentProvider(cfg.Smartnode.PasswordEnvVar.Value.(string))
+	case cfgtypes.PasswordSource_SystemdCredential:
+		return passwords.NewSystemdCredentialProvider(cfg.Smartnode.PasswordCredentialName.Value.(string))
+	case cfgtypes.PasswordSource_Command:
+		return passwords.NewCommandProvider(cfg.Smartnode.PasswordCommand.Value.(string))
+	case cfgtypes.PasswordSource_Vault:
+		return passwords.NewVaultProvider(
+			cfg.Smartnode.VaultAddress.Value.(string),
+			cfg.Smartnode.VaultKvMount.Value.(string),
+			cfg.Smartnode.VaultSecretPath.Value.(string),
+			cfg.Smartnode.VaultSecretField.Value.(string),
+			os.ExpandEnv(cfg.Smartnode.VaultTokenPath.Value.(string)),
+		)
+	default:
+		return passwords.NewFileProvider(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+	}
+}
+
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
 	var err error
 	initNodeWallet.Do(func() {
@@ -227,6 +276,71 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
 		nodeWallet.AddKeystore("prysm", prysmKeystore)
 		nodeWallet.AddKeystore("teku", tekuKeystore)
+
+		// Keymanager API for the active Validator Client
+		if cfg.Smartnode.UseKeymanagerApi.Value == true {
+			var cc cfgtypes.ConsensusClient
+			if cfg.IsNativeMode {
+				cc = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+			} else {
+				cc, _ = cfg.GetSelectedConsensusClient()
+			}
+			keymanagerKeystore := kmkeystore.NewKeystore(cfg.Smartnode.KeymanagerApiUrl.Value.(string), os.ExpandEnv(cfg.Smartnode.GetKeymanagerApiTokenPath()), os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()))
+			nodeWallet.SetKeymanager(keymanagerKeystore, string(cc))
+		}
+
+		// Web3Signer remote signer for new validator keys
+		if cfg.EnableWeb3Signer.Value == true {
+			web3SignerKeystore := w3skeystore.NewKeystore(cfg.Web3Signer.GetUrl(), os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()))
+			if keymanagerKeystore := nodeWallet.GetKeymanager(); keymanagerKeystore != nil {
+				web3SignerKeystore.SetValidatorClient(keymanagerKeystore)
+			}
+			nodeWallet.SetRemoteSigner(web3SignerKeystore)
+		}
+
+		// Transaction journal
+		nodeWallet.SetTransactionJournal(transactions.NewJournal(os.ExpandEnv(cfg.Smartnode.GetTransactionJournalPath())))
+		nodeWallet.SetDefaultTransactionPurpose(c.Command.FullName())
+
+		// Nonce manager shared by every process that sends transactions from the node account
+		ec, ecErr := getEthClient(c, cfg)
+		if ecErr == nil {
+			nodeWallet.SetNonceManager(transactions.NewNonceManager(os.ExpandEnv(cfg.Smartnode.GetNonceFilePath()), ec))
+			ec.SetTransactionSendHandler(nodeWallet.HandleTransactionSent)
+		}
+
+		// External signer for the node account
+		if signerUrl := cfg.Smartnode.NodeSignerUrl.Value.(string); signerUrl != "" {
+			var signerAddress common.Address
+			if addressString := cfg.Smartnode.NodeSignerAddress.Value.(string); addressString != "" {
+				if !common.IsHexAddress(addressString) {
+					err = fmt.Errorf("invalid external node signer address '%s'", addressString)
+					return
+				}
+				signerAddress = common.HexToAddress(addressString)
+			}
+			nodeWallet.SetExternalSigner(wallet.NewExternalSigner(signerUrl, signerAddress))
+		}
+
+		// Watch-only node address for building transactions that are signed offline
+		var watchOnlyAddress *common.Address
+		watchOnlyAddress, err = wallet.LoadWatchOnlyAddress(os.ExpandEnv(cfg.Smartnode.GetWatchOnlyAddressPath()))
+		if err != nil {
+			return
+		}
+		if watchOnlyAddress != nil {
+			nodeWallet.SetWatchOnlyAddress(*watchOnlyAddress)
+		}
+		if c.GlobalBool("export-unsigned") {
+			nodeWallet.ExportUnsignedTransactions()
+		}
+
+		// Transaction scheduler for deferrable transactions
+		nodeWallet.SetTransactionScheduler(transactions.NewScheduler(os.ExpandEnv(cfg.Smartnode.GetTransactionSchedulePath())))
+		scheduleDeadline := c.GlobalInt64("schedule-deadline")
+		if scheduleDeadline != 0 {
+			err = nodeWallet.ScheduleTransactions(time.Unix(scheduleDeadline, 0))
+		}
 	})
 	return nodeWallet, err
 }
@@ -244,6 +358,12 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 			if c.GlobalBool("force-fallbacks") {
 				ecManager.primaryReady = false
 			}
+			if transactionSimulation {
+				simulator := ecManager.EnableSimulation()
+				if !transactionTracing {
+					simulator.DisableTracing()
+				}
+			}
 		}
 	})
 	return ecManager, err
@@ -253,6 +373,11 @@ func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClie
 	var err error
 	initRocketPool.Do(func() {
 		rocketPool, err = rocketpool.NewRocketPool(client, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+		if err == nil && ecManager != nil && ecManager.GetSimulator() != nil {
+			ecManager.GetSimulator().SetContractResolver(func(address common.Address) (string, *abi.ABI, error) {
+				return ResolveContract(rocketPool, address)
+			})
+		}
 	})
 	return rocketPool, err
 }
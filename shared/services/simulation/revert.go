@@ -0,0 +1,124 @@
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Selectors of the built-in Solidity revert types
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// Descriptions of the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// An error for a transaction that would revert, with its decoded reason
+type RevertError struct {
+	ContractName string
+	Reason       string
+	Err          error
+}
+
+func (e *RevertError) Error() string {
+	if e.ContractName != "" {
+		return fmt.Sprintf("transaction would revert in %s: %s", e.ContractName, e.Reason)
+	}
+	return fmt.Sprintf("transaction would revert: %s", e.Reason)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// Get the raw revert data attached to an error returned by the Execution client, if there is any
+func GetRevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		bytes, err := hexutil.Decode(data)
+		if err != nil {
+			return nil, false
+		}
+		return bytes, true
+	case []byte:
+		return data, true
+	}
+	return nil, false
+}
+
+// Decode revert data into a human-readable reason.
+// Standard reverts and panics are always decoded; custom errors are decoded using the provided contract ABI, if there is one.
+func DecodeRevertReason(data []byte, contractAbi *abi.ABI) string {
+
+	// Reverts without a reason
+	if len(data) == 0 {
+		return "no reason given"
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("unknown reason (%s)", hexutil.Encode(data))
+	}
+	selector := data[:4]
+
+	// require() and revert() with a message
+	if bytes.Equal(selector, errorSelector) {
+		reason, err := abi.UnpackRevert(data)
+		if err == nil {
+			return reason
+		}
+	}
+
+	// assert() failures and other panics
+	if bytes.Equal(selector, panicSelector) && len(data) >= 36 {
+		code := new(big.Int).SetBytes(data[4:36])
+		if code.IsUint64() {
+			if reason, exists := panicReasons[code.Uint64()]; exists {
+				return fmt.Sprintf("panic: %s (code 0x%x)", reason, code.Uint64())
+			}
+		}
+		return fmt.Sprintf("panic (code 0x%x)", code)
+	}
+
+	// Custom errors
+	if contractAbi != nil {
+		for _, customError := range contractAbi.Errors {
+			if !bytes.Equal(customError.ID[:4], selector) {
+				continue
+			}
+			values, err := customError.Inputs.Unpack(data[4:])
+			if err != nil {
+				break
+			}
+			args := make([]string, len(values))
+			for i, value := range values {
+				args[i] = fmt.Sprint(value)
+			}
+			return fmt.Sprintf("%s(%s)", customError.Name, strings.Join(args, ", "))
+		}
+	}
+
+	return fmt.Sprintf("unknown reason (%s)", hexutil.Encode(data))
+
+}
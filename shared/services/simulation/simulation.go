@@ -0,0 +1,372 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Config
+const (
+	EthAsset string = "ETH"
+
+	ethDecimals uint8 = 18
+)
+
+// Topic of the ERC20 Transfer(address,address,uint256) event
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// The Execution client functions required for simulations
+type SimulationClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// Looks up the name and ABI of a contract, returning an empty name if it isn't known
+type ContractResolver func(address common.Address) (string, *abi.ABI, error)
+
+// A net change in an address's balance of ETH or a token
+type BalanceChange struct {
+	Address  common.Address  `json:"address"`
+	Label    string          `json:"label"`
+	Asset    string          `json:"asset"`
+	Token    *common.Address `json:"token,omitempty"`
+	Decimals uint8           `json:"decimals"`
+	Amount   *big.Int        `json:"amount"`
+}
+
+// The result of simulating a transaction
+type Result struct {
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to"`
+	ContractName   string          `json:"contractName"`
+	Block          string          `json:"block"`
+	Reverted       bool            `json:"reverted"`
+	RevertReason   string          `json:"revertReason,omitempty"`
+	BalanceChanges []BalanceChange `json:"balanceChanges"`
+	Traced         bool            `json:"traced"`
+	TraceFailed    bool            `json:"traceFailed"`
+}
+
+// A call frame produced by the callTracer
+type callFrame struct {
+	Type   string         `json:"type"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *hexutil.Big   `json:"value"`
+	Output hexutil.Bytes  `json:"output"`
+	Error  string         `json:"error"`
+	Calls  []callFrame    `json:"calls"`
+	Logs   []callLog      `json:"logs"`
+}
+
+// A log emitted by a call frame
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// The name and ABI of a contract
+type contractInfo struct {
+	name string
+	abi  *abi.ABI
+}
+
+// Details of an ERC20 token
+type tokenInfo struct {
+	symbol   string
+	decimals uint8
+}
+
+// Simulates transactions against the pending state before they're submitted
+type Simulator struct {
+	client    SimulationClient
+	resolver  ContractResolver
+	contracts map[common.Address]contractInfo
+	tokens    map[common.Address]tokenInfo
+	tracing   bool
+	results   []*Result
+	lock      sync.Mutex
+}
+
+// Create a new simulator
+func NewSimulator(client SimulationClient) *Simulator {
+	return &Simulator{
+		client:    client,
+		contracts: map[common.Address]contractInfo{},
+		tokens:    map[common.Address]tokenInfo{},
+		tracing:   true,
+	}
+}
+
+// Set the function used to look up contract names and ABIs for decoding custom errors and labelling balance changes
+func (s *Simulator) SetContractResolver(resolver ContractResolver) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resolver = resolver
+}
+
+// Stop tracing simulated calls, so they're only checked for reverts. Tracing is expensive and not every Execution
+// client supports it.
+func (s *Simulator) DisableTracing() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tracing = false
+}
+
+// Check if simulated calls are traced
+func (s *Simulator) IsTracing() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.tracing
+}
+
+// Record the result of a simulation so it can be returned with the response to the current request
+func (s *Simulator) Record(result *Result) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.results = append(s.results, result)
+}
+
+// Get the simulation results recorded since the last time they were taken, and clear them
+func (s *Simulator) TakeResults() []*Result {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	results := s.results
+	s.results = nil
+	return results
+}
+
+// Simulate a transaction against the pending state.
+// If tracing hasn't been disabled and the Execution client supports it, the result will include the balance changes
+// the transaction would cause.
+func (s *Simulator) Simulate(ctx context.Context, call ethereum.CallMsg) (*Result, error) {
+
+	result := &Result{
+		From:           call.From,
+		To:             call.To,
+		BalanceChanges: []BalanceChange{},
+	}
+	var contractAbi *abi.ABI
+	if call.To != nil {
+		result.ContractName, contractAbi = s.resolveContract(*call.To)
+	}
+
+	// Trace the call to get the state changes; not every client can trace on top of the pending block, so fall back to the latest one
+	var frame *callFrame
+	var err error
+	if s.IsTracing() {
+		for _, block := range []string{"pending", "latest"} {
+			frame, err = s.traceCall(ctx, call, block)
+			if err == nil {
+				result.Block = block
+				break
+			}
+		}
+	}
+
+	if frame == nil {
+		// Tracing isn't enabled or supported, so just check if the call reverts
+		result.TraceFailed = (err != nil)
+		result.Block = "pending"
+		var output hexutil.Bytes
+		err = s.client.CallContext(ctx, &output, "eth_call", toCallArg(call), result.Block)
+		if err != nil {
+			data, isRevert := GetRevertData(err)
+			if !isRevert && !strings.Contains(err.Error(), "revert") {
+				return nil, fmt.Errorf("error simulating transaction: %w", err)
+			}
+			result.Reverted = true
+			result.RevertReason = DecodeRevertReason(data, contractAbi)
+		}
+		return result, nil
+	}
+
+	// Process the trace
+	result.Traced = true
+	if frame.Error != "" {
+		result.Reverted = true
+		result.RevertReason = DecodeRevertReason(frame.Output, contractAbi)
+		return result, nil
+	}
+	result.BalanceChanges = s.getBalanceChanges(ctx, frame, call.From)
+	return result, nil
+
+}
+
+// Run the call tracer on a call at the given block
+func (s *Simulator) traceCall(ctx context.Context, call ethereum.CallMsg, block string) (*callFrame, error) {
+	var frame callFrame
+	config := map[string]interface{}{
+		"tracer": "callTracer",
+		"tracerConfig": map[string]interface{}{
+			"withLog": true,
+		},
+	}
+	if err := s.client.CallContext(ctx, &frame, "debug_traceCall", toCallArg(call), block, config); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// Get the net balance changes caused by a traced call
+func (s *Simulator) getBalanceChanges(ctx context.Context, frame *callFrame, from common.Address) []BalanceChange {
+
+	type balanceKey struct {
+		address common.Address
+		asset   common.Address
+	}
+	amounts := map[balanceKey]*big.Int{}
+	add := func(address common.Address, asset common.Address, amount *big.Int) {
+		key := balanceKey{address: address, asset: asset}
+		if _, exists := amounts[key]; !exists {
+			amounts[key] = big.NewInt(0)
+		}
+		amounts[key].Add(amounts[key], amount)
+	}
+
+	// Walk the call tree, ignoring any frames that reverted along with their children
+	var walk func(frame *callFrame)
+	walk = func(frame *callFrame) {
+		if frame.Error != "" {
+			return
+		}
+		switch strings.ToUpper(frame.Type) {
+		case "CALL", "CREATE", "CREATE2", "SELFDESTRUCT":
+			if frame.Value != nil && frame.Value.ToInt().Sign() > 0 {
+				value := frame.Value.ToInt()
+				add(frame.From, common.Address{}, new(big.Int).Neg(value))
+				add(frame.To, common.Address{}, value)
+			}
+		}
+		for _, log := range frame.Logs {
+			if len(log.Topics) != 3 || log.Topics[0] != transferTopic || len(log.Data) != 32 {
+				continue
+			}
+			amount := new(big.Int).SetBytes(log.Data)
+			add(common.BytesToAddress(log.Topics[1].Bytes()), log.Address, new(big.Int).Neg(amount))
+			add(common.BytesToAddress(log.Topics[2].Bytes()), log.Address, amount)
+		}
+		for i := range frame.Calls {
+			walk(&frame.Calls[i])
+		}
+	}
+	walk(frame)
+
+	// Build the list of changes
+	changes := []BalanceChange{}
+	for key, amount := range amounts {
+		// Mints and burns show up as transfers from and to the zero address, which aren't real balances
+		if amount.Sign() == 0 || key.address == (common.Address{}) {
+			continue
+		}
+		change := BalanceChange{
+			Address:  key.address,
+			Asset:    EthAsset,
+			Decimals: ethDecimals,
+			Amount:   amount,
+		}
+		if key.asset != (common.Address{}) {
+			token := key.asset
+			info := s.getTokenInfo(ctx, token)
+			change.Token = &token
+			change.Asset = info.symbol
+			change.Decimals = info.decimals
+		}
+		if key.address == from {
+			change.Label = "node account"
+		} else {
+			change.Label, _ = s.resolveContract(key.address)
+		}
+		changes = append(changes, change)
+	}
+
+	// Show the sender first, then sort by address and asset so the output is stable
+	sort.Slice(changes, func(i, j int) bool {
+		if (changes[i].Address == from) != (changes[j].Address == from) {
+			return changes[i].Address == from
+		}
+		if changes[i].Address != changes[j].Address {
+			return changes[i].Address.Hex() < changes[j].Address.Hex()
+		}
+		return changes[i].Asset < changes[j].Asset
+	})
+	return changes
+
+}
+
+// Get the name and ABI of a contract
+func (s *Simulator) resolveContract(address common.Address) (string, *abi.ABI) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.resolver == nil {
+		return "", nil
+	}
+	if info, exists := s.contracts[address]; exists {
+		return info.name, info.abi
+	}
+	name, contractAbi, err := s.resolver(address)
+	if err != nil {
+		return "", nil
+	}
+	s.contracts[address] = contractInfo{name: name, abi: contractAbi}
+	return name, contractAbi
+}
+
+// Get the symbol and decimals of an ERC20 token
+func (s *Simulator) getTokenInfo(ctx context.Context, token common.Address) tokenInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if info, exists := s.tokens[token]; exists {
+		return info
+	}
+
+	info := tokenInfo{
+		symbol:   token.Hex(),
+		decimals: ethDecimals,
+	}
+	stringType, _ := abi.NewType("string", "", nil)
+	uint8Type, _ := abi.NewType("uint8", "", nil)
+	if output, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: crypto.Keccak256([]byte("symbol()"))[:4]}, nil); err == nil {
+		if values, err := (abi.Arguments{{Type: stringType}}).Unpack(output); err == nil && len(values) == 1 {
+			info.symbol = values[0].(string)
+		}
+	}
+	if output, err := s.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: crypto.Keccak256([]byte("decimals()"))[:4]}, nil); err == nil {
+		if values, err := (abi.Arguments{{Type: uint8Type}}).Unpack(output); err == nil && len(values) == 1 {
+			info.decimals = values[0].(uint8)
+		}
+	}
+	s.tokens[token] = info
+	return info
+}
+
+// Convert a call message into the argument format used by eth_call and debug_traceCall
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	return arg
+}
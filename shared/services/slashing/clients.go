@@ -0,0 +1,128 @@
+package slashing
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Config
+const (
+	// The folder that the validators directory is mounted to in the Validator Client containers
+	ValidatorsMountPath string = "/validators"
+
+	// The subfolder of the validators directory that interchange files are written to
+	InterchangeDir string = "slashing-protection"
+)
+
+// The image name and tag components of a Docker image string
+var imageRegex = regexp.MustCompile(`^(?P<name>[^:@]*)(?P<tag>[:@].*)?$`)
+
+// Runs a Validator Client's own slashing protection export and import commands against its data directory.
+// The commands rely on the entrypoint of the client's Docker image.
+type ClientTool struct {
+	Client cfgtypes.ConsensusClient
+	Image  string
+	Export func(file string, network string) []string
+	Import func(file string, network string) []string
+}
+
+// Get the slashing protection tool for the Validator Client running the given Docker image
+func GetClientTool(validatorImage string) (*ClientTool, error) {
+
+	matches := imageRegex.FindStringSubmatch(validatorImage)
+	if matches == nil {
+		return nil, fmt.Errorf("couldn't parse the Docker image string [%s]", validatorImage)
+	}
+	name := path.Base(matches[imageRegex.SubexpIndex("name")])
+	tag := matches[imageRegex.SubexpIndex("tag")]
+
+	switch {
+	case strings.Contains(name, "lighthouse"):
+		return &ClientTool{
+			Client: cfgtypes.ConsensusClient_Lighthouse,
+			Image:  validatorImage,
+			Export: func(file string, network string) []string {
+				return []string{"lighthouse", "account", "validator", "slashing-protection", "export", file, "--network", network, "--datadir", ValidatorsMountPath + "/lighthouse"}
+			},
+			Import: func(file string, network string) []string {
+				return []string{"lighthouse", "account", "validator", "slashing-protection", "import", file, "--network", network, "--datadir", ValidatorsMountPath + "/lighthouse"}
+			},
+		}, nil
+
+	case strings.Contains(name, "lodestar"):
+		return &ClientTool{
+			Client: cfgtypes.ConsensusClient_Lodestar,
+			Image:  validatorImage,
+			Export: func(file string, network string) []string {
+				return []string{"validator", "slashing-protection", "export", "--network", network, "--dataDir", ValidatorsMountPath + "/lodestar", "--file", file}
+			},
+			Import: func(file string, network string) []string {
+				return []string{"validator", "slashing-protection", "import", "--network", network, "--dataDir", ValidatorsMountPath + "/lodestar", "--file", file}
+			},
+		}, nil
+
+	case strings.Contains(name, "nimbus-validator-client"):
+		// The Validator Client image doesn't include the slashing DB tool, so use the matching Beacon Node image
+		return &ClientTool{
+			Client: cfgtypes.ConsensusClient_Nimbus,
+			Image:  strings.Replace(validatorImage, "nimbus-validator-client", "nimbus-eth2", 1),
+			Export: func(file string, network string) []string {
+				return []string{"slashingdb", "export", file, "--data-dir=" + ValidatorsMountPath + "/nimbus"}
+			},
+			Import: func(file string, network string) []string {
+				return []string{"slashingdb", "import", file, "--data-dir=" + ValidatorsMountPath + "/nimbus"}
+			},
+		}, nil
+
+	case strings.Contains(name, "prysm"):
+		return &ClientTool{
+			Client: cfgtypes.ConsensusClient_Prysm,
+			Image:  validatorImage,
+			Export: func(file string, network string) []string {
+				// Prysm always names its export slashing_protection.json, so the file name is determined by the export folder
+				return []string{"slashing-protection-history", "export", "--accept-terms-of-use", "--datadir=" + ValidatorsMountPath + "/prysm-non-hd/direct", "--slashing-protection-export-dir=" + path.Dir(file)}
+			},
+			Import: func(file string, network string) []string {
+				return []string{"slashing-protection-history", "import", "--accept-terms-of-use", "--datadir=" + ValidatorsMountPath + "/prysm-non-hd/direct", "--slashing-protection-json-file=" + file}
+			},
+		}, nil
+
+	case strings.Contains(name, "teku"):
+		return &ClientTool{
+			Client: cfgtypes.ConsensusClient_Teku,
+			Image:  validatorImage,
+			Export: func(file string, network string) []string {
+				return []string{"slashing-protection", "export", "--data-path=" + ValidatorsMountPath + "/teku", "--to=" + file}
+			},
+			Import: func(file string, network string) []string {
+				return []string{"slashing-protection", "import", "--data-path=" + ValidatorsMountPath + "/teku", "--from=" + file}
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("migrating slashing protection data isn't supported for the Validator Client image [%s%s]", name, tag)
+
+}
+
+// Get the name the clients use for a Smartnode network
+func GetClientNetworkName(network cfgtypes.Network) string {
+	switch network {
+	case cfgtypes.Network_Devnet:
+		// The devnet runs on the Prater beacon chain
+		return string(cfgtypes.Network_Prater)
+	default:
+		return string(network)
+	}
+}
+
+// Get the name of the file Prysm writes its export to, which can't be chosen
+func GetExportFileName(client cfgtypes.ConsensusClient, requestedName string) string {
+	if client == cfgtypes.ConsensusClient_Prysm {
+		return "slashing_protection.json"
+	}
+	return requestedName
+}
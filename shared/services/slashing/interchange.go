@@ -0,0 +1,150 @@
+package slashing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Config
+const (
+	InterchangeFormatVersion string = "5"
+)
+
+// The genesis validators root of each network, which interchange files are bound to
+var genesisValidatorsRoots = map[cfgtypes.Network]common.Hash{
+	cfgtypes.Network_Mainnet: common.HexToHash("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"),
+	cfgtypes.Network_Prater:  common.HexToHash("0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"),
+	cfgtypes.Network_Devnet:  common.HexToHash("0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb"),
+}
+
+// EIP-3076 slashing protection interchange data
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []ValidatorHistory  `json:"data"`
+}
+
+// Interchange metadata
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    common.Hash `json:"genesis_validators_root"`
+}
+
+// The signing history of a single validator
+type ValidatorHistory struct {
+	Pubkey             types.ValidatorPubkey `json:"pubkey"`
+	SignedBlocks       []SignedBlock         `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation   `json:"signed_attestations"`
+}
+
+// A block proposal signed by a validator
+type SignedBlock struct {
+	Slot        Uint64         `json:"slot"`
+	SigningRoot *hexutil.Bytes `json:"signing_root,omitempty"`
+}
+
+// An attestation signed by a validator
+type SignedAttestation struct {
+	SourceEpoch Uint64         `json:"source_epoch"`
+	TargetEpoch Uint64         `json:"target_epoch"`
+	SigningRoot *hexutil.Bytes `json:"signing_root,omitempty"`
+}
+
+// An integer encoded as a decimal string, as the interchange format requires
+type Uint64 uint64
+
+func (i Uint64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprint(uint64(i)))
+}
+func (i *Uint64) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	var parsed uint64
+	if _, err := fmt.Sscan(value, &parsed); err != nil {
+		return fmt.Errorf("invalid integer '%s': %w", value, err)
+	}
+	*i = Uint64(parsed)
+	return nil
+}
+
+// Load interchange data from a file
+func LoadInterchange(path string) (*Interchange, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read slashing protection data from %s: %w", path, err)
+	}
+	var interchange Interchange
+	if err := json.Unmarshal(bytes, &interchange); err != nil {
+		return nil, fmt.Errorf("could not decode slashing protection data from %s: %w", path, err)
+	}
+	return &interchange, nil
+}
+
+// Save interchange data to a file
+func (interchange *Interchange) Save(path string) error {
+	bytes, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode slashing protection data: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("could not write slashing protection data to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Check that the interchange data is in a supported format and belongs to the given network
+func (interchange *Interchange) Validate(network cfgtypes.Network) error {
+	if version := strings.TrimSpace(interchange.Metadata.InterchangeFormatVersion); version != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version '%s' (expected %s)", version, InterchangeFormatVersion)
+	}
+	expectedRoot, exists := genesisValidatorsRoots[network]
+	if exists && interchange.Metadata.GenesisValidatorsRoot != expectedRoot {
+		return fmt.Errorf("the slashing protection data is for a different chain (genesis validators root %s, but %s uses %s)", interchange.Metadata.GenesisValidatorsRoot.Hex(), network, expectedRoot.Hex())
+	}
+	return nil
+}
+
+// Check that the interchange data holds the signing history of every one of the given validators, so a new client that
+// imports it won't be able to sign anything they've already signed
+func (interchange *Interchange) CheckValidators(pubkeys []types.ValidatorPubkey) error {
+	histories := map[types.ValidatorPubkey]bool{}
+	for _, history := range interchange.Data {
+		histories[history.Pubkey] = true
+	}
+	missing := []string{}
+	for _, pubkey := range pubkeys {
+		if !histories[pubkey] {
+			missing = append(missing, pubkey.Hex())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the slashing protection data is missing %d of the node's %d validator(s): %s", len(missing), len(pubkeys), strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Get the latest slot and target epoch signed by a validator, which the new client must never sign at or below
+func (history *ValidatorHistory) GetHighWatermarks() (uint64, uint64) {
+	var slot uint64
+	var epoch uint64
+	for _, block := range history.SignedBlocks {
+		if uint64(block.Slot) > slot {
+			slot = uint64(block.Slot)
+		}
+	}
+	for _, attestation := range history.SignedAttestations {
+		if uint64(attestation.TargetEpoch) > epoch {
+			epoch = uint64(attestation.TargetEpoch)
+		}
+	}
+	return slot, epoch
+}
@@ -0,0 +1,267 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// A minipool selector is a comma-separated list of conditions that a minipool must all meet, such as
+// `status=staking,bond=16,balance>=32.1`. Each condition compares a field with a value using one of
+// =, !=, >, >=, < or <=; equality conditions can list alternatives separated by |, such as `status=staking|withdrawable`.
+//
+// The supported fields are:
+//
+//	status            the minipool's status (initialized, prelaunch, staking, withdrawable or dissolved)
+//	beacon            the validator's status on the Beacon Chain (such as active_ongoing), or none if it doesn't exist yet
+//	bond              the node's deposit in ETH
+//	balance           the validator's balance on the Beacon Chain in ETH
+//	contract-balance  the minipool contract's balance in ETH
+//	fee               the node's commission in percent
+//	version           the minipool's delegate version
+//	index             the validator's index
+//	finalised         whether the minipool has been finalised (true or false)
+//	vacant            whether the minipool is a vacant solo migration (true or false)
+//	address           the minipool's address
+
+// Comparison operators, ordered so the longer ones are matched first
+var selectorOperators = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// The kinds of values a selector field can have
+type selectorFieldKind int
+
+const (
+	selectorFieldKind_String selectorFieldKind = iota
+	selectorFieldKind_Number
+	selectorFieldKind_Bool
+)
+
+// The fields a selector can filter on
+var selectorFields = map[string]selectorFieldKind{
+	"status":           selectorFieldKind_String,
+	"beacon":           selectorFieldKind_String,
+	"bond":             selectorFieldKind_Number,
+	"balance":          selectorFieldKind_Number,
+	"contract-balance": selectorFieldKind_Number,
+	"fee":              selectorFieldKind_Number,
+	"version":          selectorFieldKind_Number,
+	"index":            selectorFieldKind_Number,
+	"finalised":        selectorFieldKind_Bool,
+	"vacant":           selectorFieldKind_Bool,
+	"address":          selectorFieldKind_String,
+}
+
+// A single condition in a minipool selector
+type selectorCondition struct {
+	field    string
+	operator string
+	values   []string
+	numbers  []float64
+}
+
+// A parsed minipool selector
+type MinipoolSelector struct {
+	conditions []selectorCondition
+}
+
+// Parse a minipool selector
+func ParseMinipoolSelector(selector string) (*MinipoolSelector, error) {
+	result := &MinipoolSelector{}
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		// Split the term into its field, operator and value
+		var condition selectorCondition
+		for _, operator := range selectorOperators {
+			index := strings.Index(term, operator)
+			if index > 0 {
+				condition.field = strings.ToLower(strings.TrimSpace(term[:index]))
+				condition.operator = operator
+				for _, value := range strings.Split(term[index+len(operator):], "|") {
+					condition.values = append(condition.values, strings.ToLower(strings.TrimSpace(value)))
+				}
+				break
+			}
+		}
+		if condition.operator == "" {
+			return nil, fmt.Errorf("condition '%s' must be in the form field<operator>value, where the operator is one of %s", term, strings.Join(selectorOperators, ", "))
+		}
+
+		// Check the condition against its field
+		kind, exists := selectorFields[condition.field]
+		if !exists {
+			return nil, fmt.Errorf("unknown field '%s' in condition '%s'; valid fields are %s", condition.field, term, strings.Join(getSelectorFieldNames(), ", "))
+		}
+		if len(condition.values) > 1 && condition.operator != "=" && condition.operator != "!=" {
+			return nil, fmt.Errorf("condition '%s' can only list alternative values with = or !=", term)
+		}
+		for _, value := range condition.values {
+			if value == "" {
+				return nil, fmt.Errorf("condition '%s' is missing a value", term)
+			}
+			switch kind {
+			case selectorFieldKind_Number:
+				number, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("condition '%s' requires a number, but '%s' is not one", term, value)
+				}
+				condition.numbers = append(condition.numbers, number)
+			case selectorFieldKind_Bool:
+				if _, err := strconv.ParseBool(value); err != nil {
+					return nil, fmt.Errorf("condition '%s' requires true or false, but '%s' is neither", term, value)
+				}
+				if condition.operator != "=" && condition.operator != "!=" {
+					return nil, fmt.Errorf("condition '%s' can only use = or !=", term)
+				}
+			case selectorFieldKind_String:
+				if condition.operator != "=" && condition.operator != "!=" {
+					return nil, fmt.Errorf("condition '%s' can only use = or !=", term)
+				}
+			}
+		}
+		result.conditions = append(result.conditions, condition)
+	}
+
+	if len(result.conditions) == 0 {
+		return nil, fmt.Errorf("the selector doesn't contain any conditions")
+	}
+	return result, nil
+}
+
+// Check if a minipool and its validator meet all of the selector's conditions
+func (s *MinipoolSelector) Matches(mpd *rpstate.NativeMinipoolDetails, validator beacon.ValidatorStatus) bool {
+	for _, condition := range s.conditions {
+		if !condition.matches(mpd, validator) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the addresses of the node's minipools that match the selector
+func (s *NetworkState) SelectMinipools(nodeAddress common.Address, selector *MinipoolSelector) []common.Address {
+	addresses := []common.Address{}
+	for _, mpd := range s.MinipoolDetailsByNode[nodeAddress] {
+		if selector.Matches(mpd, s.ValidatorDetails[mpd.Pubkey]) {
+			addresses = append(addresses, mpd.MinipoolAddress)
+		}
+	}
+	return addresses
+}
+
+// Check if a minipool meets the condition
+func (c *selectorCondition) matches(mpd *rpstate.NativeMinipoolDetails, validator beacon.ValidatorStatus) bool {
+	switch selectorFields[c.field] {
+	case selectorFieldKind_Number:
+		var value float64
+		switch c.field {
+		case "bond":
+			value = eth.WeiToEth(mpd.NodeDepositBalance)
+		case "balance":
+			value = float64(validator.Balance) / 1e9
+		case "contract-balance":
+			value = eth.WeiToEth(mpd.Balance)
+		case "fee":
+			value = eth.WeiToEth(big.NewInt(0).Mul(mpd.NodeFee, big.NewInt(100)))
+		case "version":
+			value = float64(mpd.Version)
+		case "index":
+			if !validator.Exists {
+				return false
+			}
+			value = float64(validator.Index)
+		}
+		return c.compareNumber(value)
+
+	case selectorFieldKind_Bool:
+		var value bool
+		switch c.field {
+		case "finalised":
+			value = mpd.Finalised
+		case "vacant":
+			value = mpd.IsVacant
+		}
+		return c.compareString(strconv.FormatBool(value))
+
+	default:
+		var value string
+		switch c.field {
+		case "status":
+			value = strings.ToLower(mpd.Status.String())
+		case "beacon":
+			value = "none"
+			if validator.Exists {
+				value = strings.ToLower(string(validator.Status))
+			}
+		case "address":
+			value = strings.ToLower(mpd.MinipoolAddress.Hex())
+		}
+		return c.compareString(value)
+	}
+}
+
+// Compare a string value with the condition's values
+func (c *selectorCondition) compareString(value string) bool {
+	matched := false
+	for _, candidate := range c.values {
+		if candidate == value {
+			matched = true
+			break
+		}
+		if parsed, err := strconv.ParseBool(candidate); err == nil && strconv.FormatBool(parsed) == value {
+			matched = true
+			break
+		}
+	}
+	if c.operator == "!=" {
+		return !matched
+	}
+	return matched
+}
+
+// Compare a number with the condition's values
+func (c *selectorCondition) compareNumber(value float64) bool {
+	switch c.operator {
+	case "=", "!=":
+		matched := false
+		for _, candidate := range c.numbers {
+			if value == candidate {
+				matched = true
+				break
+			}
+		}
+		if c.operator == "!=" {
+			return !matched
+		}
+		return matched
+	case ">":
+		return value > c.numbers[0]
+	case ">=":
+		return value >= c.numbers[0]
+	case "<":
+		return value < c.numbers[0]
+	case "<=":
+		return value <= c.numbers[0]
+	}
+	return false
+}
+
+// Get the names of the fields a selector can use
+func getSelectorFieldNames() []string {
+	names := make([]string, 0, len(selectorFields))
+	for name := range selectorFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,304 @@
+package transactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Config
+const (
+	FileMode           = 0600
+	DirMode            = 0700
+	MaxJournalEntries  = 1000
+	JournalFileVersion = 1
+)
+
+// The status of a journaled transaction
+type TransactionStatus string
+
+const (
+	TransactionStatus_Pending   TransactionStatus = "pending"
+	TransactionStatus_Confirmed TransactionStatus = "confirmed"
+	TransactionStatus_Failed    TransactionStatus = "failed"
+	TransactionStatus_Dropped   TransactionStatus = "dropped"
+)
+
+// The errors Execution clients return when they're sent a transaction that's already in their mempool
+var alreadyKnownErrors = []string{
+	"already known",              // Geth, Erigon
+	"alreadyknown",               // Nethermind
+	"known transaction",          // Besu and older Geth versions
+	"transaction already exists", // Reth
+}
+
+// A transaction sent by the node account, along with everything required to rebroadcast or replace it
+type TransactionRecord struct {
+	Purpose           string            `json:"purpose"`
+	From              common.Address    `json:"from"`
+	To                *common.Address   `json:"to"`
+	Nonce             uint64            `json:"nonce"`
+	Value             *big.Int          `json:"value"`
+	Data              hexutil.Bytes     `json:"data"`
+	GasLimit          uint64            `json:"gasLimit"`
+	MaxFee            *big.Int          `json:"maxFee"`
+	MaxPriorityFee    *big.Int          `json:"maxPriorityFee"`
+	Hash              common.Hash       `json:"hash"`
+	PreviousHashes    []common.Hash     `json:"previousHashes,omitempty"`
+	Status            TransactionStatus `json:"status"`
+	SubmittedTime     time.Time         `json:"submittedTime"`
+	LastBroadcastTime time.Time         `json:"lastBroadcastTime"`
+	ConfirmedTime     time.Time         `json:"confirmedTime"`
+	BlockNumber       uint64            `json:"blockNumber,omitempty"`
+	GasUsed           uint64            `json:"gasUsed,omitempty"`
+	Rebroadcasts      uint              `json:"rebroadcasts"`
+	Replacements      uint              `json:"replacements"`
+}
+
+// Check if the record is still waiting to be included in a block
+func (r *TransactionRecord) IsPending() bool {
+	return r.Status == TransactionStatus_Pending
+}
+
+// Check if the given hash belongs to this record, including hashes of transactions it replaced
+func (r *TransactionRecord) HasHash(hash common.Hash) bool {
+	if r.Hash == hash {
+		return true
+	}
+	for _, previousHash := range r.PreviousHashes {
+		if previousHash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// The serialized journal file
+type journalFile struct {
+	Version      uint                 `json:"version"`
+	Transactions []*TransactionRecord `json:"transactions"`
+}
+
+// Persistent, process-safe journal of the transactions sent by the node account
+type Journal struct {
+	path string
+}
+
+// Create a new transaction journal backed by the file at the given path
+func NewJournal(path string) *Journal {
+	return &Journal{
+		path: path,
+	}
+}
+
+// Record a signed transaction that is about to be submitted.
+// If a pending transaction with the same sender and nonce already exists, this is treated as a replacement of it.
+func (j *Journal) RecordTransaction(tx *types.Transaction, from common.Address, purpose string) error {
+	return j.Update(func(records []*TransactionRecord) ([]*TransactionRecord, error) {
+		now := time.Now()
+
+		// Handle replacements of existing pending transactions
+		for _, record := range records {
+			if record.From != from || record.Nonce != tx.Nonce() || !record.IsPending() {
+				continue
+			}
+			if record.Hash == tx.Hash() {
+				record.Rebroadcasts++
+				record.LastBroadcastTime = now
+				return records, nil
+			}
+			record.PreviousHashes = append(record.PreviousHashes, record.Hash)
+			record.Hash = tx.Hash()
+			record.To = tx.To()
+			record.Value = tx.Value()
+			record.Data = tx.Data()
+			record.GasLimit = tx.Gas()
+			record.MaxFee = tx.GasFeeCap()
+			record.MaxPriorityFee = tx.GasTipCap()
+			record.Replacements++
+			record.LastBroadcastTime = now
+			if purpose != "" {
+				record.Purpose = purpose
+			}
+			return records, nil
+		}
+
+		// Add a new record
+		records = append(records, &TransactionRecord{
+			Purpose:           purpose,
+			From:              from,
+			To:                tx.To(),
+			Nonce:             tx.Nonce(),
+			Value:             tx.Value(),
+			Data:              tx.Data(),
+			GasLimit:          tx.Gas(),
+			MaxFee:            tx.GasFeeCap(),
+			MaxPriorityFee:    tx.GasTipCap(),
+			Hash:              tx.Hash(),
+			Status:            TransactionStatus_Pending,
+			SubmittedTime:     now,
+			LastBroadcastTime: now,
+		})
+		return records, nil
+	})
+}
+
+// Get all of the transactions in the journal, sorted from oldest to newest
+func (j *Journal) GetTransactions() ([]*TransactionRecord, error) {
+	if _, err := os.Stat(j.path); os.IsNotExist(err) {
+		return []*TransactionRecord{}, nil
+	}
+	unlock, err := lockFile(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return j.load()
+}
+
+// Get the transactions from the given address that haven't been included in a block yet
+func (j *Journal) GetPendingTransactions(from common.Address) ([]*TransactionRecord, error) {
+	records, err := j.GetTransactions()
+	if err != nil {
+		return nil, err
+	}
+	pending := []*TransactionRecord{}
+	for _, record := range records {
+		if record.From == from && record.IsPending() {
+			pending = append(pending, record)
+		}
+	}
+	return pending, nil
+}
+
+// Atomically read, modify and save the journal
+func (j *Journal) Update(updater func(records []*TransactionRecord) ([]*TransactionRecord, error)) error {
+
+	// Make sure the folder exists
+	if err := os.MkdirAll(filepath.Dir(j.path), DirMode); err != nil {
+		return fmt.Errorf("error creating transaction journal folder: %w", err)
+	}
+
+	// Lock the journal
+	unlock, err := lockFile(j.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Load and update the records
+	records, err := j.load()
+	if err != nil {
+		return err
+	}
+	records, err = updater(records)
+	if err != nil {
+		return err
+	}
+	return j.save(records)
+
+}
+
+// Load the journal from disk
+func (j *Journal) load() ([]*TransactionRecord, error) {
+	bytes, err := os.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return []*TransactionRecord{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading transaction journal: %w", err)
+	}
+
+	var file journalFile
+	if err := json.Unmarshal(bytes, &file); err != nil {
+		return nil, fmt.Errorf("error deserializing transaction journal: %w", err)
+	}
+	if file.Transactions == nil {
+		file.Transactions = []*TransactionRecord{}
+	}
+	return file.Transactions, nil
+}
+
+// Save the journal to disk, trimming the oldest finished transactions if it has grown too large
+func (j *Journal) save(records []*TransactionRecord) error {
+	sort.SliceStable(records, func(i, k int) bool {
+		return records[i].SubmittedTime.Before(records[k].SubmittedTime)
+	})
+
+	excess := len(records) - MaxJournalEntries
+	if excess > 0 {
+		trimmed := make([]*TransactionRecord, 0, MaxJournalEntries)
+		for _, record := range records {
+			if excess > 0 && !record.IsPending() {
+				excess--
+				continue
+			}
+			trimmed = append(trimmed, record)
+		}
+		records = trimmed
+	}
+
+	bytes, err := json.Marshal(journalFile{
+		Version:      JournalFileVersion,
+		Transactions: records,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing transaction journal: %w", err)
+	}
+
+	// Write to a temp file and move it into place so readers never see a partial journal
+	tempPath := j.path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing transaction journal: %w", err)
+	}
+	if err := os.Rename(tempPath, j.path); err != nil {
+		return fmt.Errorf("error saving transaction journal: %w", err)
+	}
+	return nil
+}
+
+// Set the final status of the pending transaction from the given address with the given nonce.
+// If a receipt is provided, the record is updated with the hash and block of the transaction that was actually included.
+func (j *Journal) SetTransactionStatus(from common.Address, nonce uint64, status TransactionStatus, receipt *types.Receipt) error {
+	return j.Update(func(records []*TransactionRecord) ([]*TransactionRecord, error) {
+		for _, record := range records {
+			if record.From != from || record.Nonce != nonce || !record.IsPending() {
+				continue
+			}
+			record.Status = status
+			if receipt != nil {
+				if record.Hash != receipt.TxHash {
+					record.PreviousHashes = append(record.PreviousHashes, record.Hash)
+					record.Hash = receipt.TxHash
+				}
+				record.BlockNumber = receipt.BlockNumber.Uint64()
+				record.GasUsed = receipt.GasUsed
+				record.ConfirmedTime = time.Now()
+			}
+			return records, nil
+		}
+		return records, nil
+	})
+}
+
+// Check if an error from sending a transaction means the Execution client already has it, so it was effectively sent
+func IsAlreadyKnownError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, knownError := range alreadyKnownErrors {
+		if strings.Contains(message, knownError) {
+			return true
+		}
+	}
+	return false
+}
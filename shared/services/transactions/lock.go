@@ -0,0 +1,55 @@
+package transactions
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Config
+const (
+	lockFileSuffix   string = ".lock"
+	lockRetryDelay          = 50 * time.Millisecond
+	lockTimeout             = 30 * time.Second
+	lockStaleTimeout        = 2 * time.Minute
+)
+
+// Acquire an exclusive lock on a state file outside of this package that is shared between processes.
+// Returns a function that releases the lock.
+func LockFile(path string) (func(), error) {
+	return lockFile(path)
+}
+
+// Acquire an exclusive lock on a file that is shared between the API, node and watchtower processes.
+// Returns a function that releases the lock.
+func lockFile(path string) (func(), error) {
+
+	lockPath := path + lockFileSuffix
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, FileMode)
+		if err == nil {
+			fmt.Fprintf(file, "%d", os.Getpid())
+			file.Close()
+			return func() {
+				os.Remove(lockPath)
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("error creating lock file %s: %w", lockPath, err)
+		}
+
+		// Break locks left behind by processes that were killed while holding them
+		info, err := os.Stat(lockPath)
+		if err == nil && time.Since(info.ModTime()) > lockStaleTimeout {
+			os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock file %s", lockPath)
+		}
+		time.Sleep(lockRetryDelay)
+	}
+
+}
@@ -0,0 +1,215 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config
+const (
+	NonceFileVersion = 1
+
+	// How long a nonce stays reserved without showing up in the Execution client's mempool.
+	// After this, the transaction it was reserved for is assumed to have been dropped (or never sent) and the nonce is reused.
+	NonceReservationTimeout = 5 * time.Minute
+)
+
+// The client functions required to determine which nonces are in use
+type NonceClient interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// A nonce that has been handed out to a transaction sender
+type nonceReservation struct {
+	Nonce uint64    `json:"nonce"`
+	Time  time.Time `json:"time"`
+}
+
+// The serialized nonce file
+type nonceFile struct {
+	Version      uint                                  `json:"version"`
+	Reservations map[common.Address][]nonceReservation `json:"reservations"`
+}
+
+// Allocates nonces for the node account across every process that sends transactions with it
+// (the node daemon, the watchtower and the API), so they never hand out the same nonce twice.
+type NonceManager struct {
+	path   string
+	client NonceClient
+}
+
+// Create a new nonce manager backed by the file at the given path
+func NewNonceManager(path string, client NonceClient) *NonceManager {
+	return &NonceManager{
+		path:   path,
+		client: client,
+	}
+}
+
+// Reserve and return the lowest nonce for the given address that isn't being used by another transaction.
+// This will fill gaps left by transactions that were dropped from the mempool or never submitted.
+func (m *NonceManager) GetNextNonce(from common.Address) (uint64, error) {
+	var nonce uint64
+	err := m.update(from, func(reservations []nonceReservation, latestNonce uint64, pendingNonce uint64) ([]nonceReservation, error) {
+
+		// Everything below the pending nonce is already mined or in the mempool
+		reserved := map[uint64]bool{}
+		for _, reservation := range reservations {
+			reserved[reservation.Nonce] = true
+		}
+		nonce = pendingNonce
+		for reserved[nonce] {
+			nonce++
+		}
+
+		return append(reservations, nonceReservation{
+			Nonce: nonce,
+			Time:  time.Now(),
+		}), nil
+
+	})
+	return nonce, err
+}
+
+// Reserve a specific nonce, such as one that was manually provided to replace a pending transaction
+func (m *NonceManager) ReserveNonce(from common.Address, nonce uint64) error {
+	return m.update(from, func(reservations []nonceReservation, latestNonce uint64, pendingNonce uint64) ([]nonceReservation, error) {
+		if nonce < latestNonce {
+			return nil, fmt.Errorf("nonce %d has already been included in a block", nonce)
+		}
+		for i, reservation := range reservations {
+			if reservation.Nonce == nonce {
+				reservations[i].Time = time.Now()
+				return reservations, nil
+			}
+		}
+		return append(reservations, nonceReservation{
+			Nonce: nonce,
+			Time:  time.Now(),
+		}), nil
+	})
+}
+
+// Release a nonce that was reserved for a transaction which won't be submitted, so it can be reused immediately
+func (m *NonceManager) ReleaseNonce(from common.Address, nonce uint64) error {
+	return m.update(from, func(reservations []nonceReservation, latestNonce uint64, pendingNonce uint64) ([]nonceReservation, error) {
+		remaining := make([]nonceReservation, 0, len(reservations))
+		for _, reservation := range reservations {
+			if reservation.Nonce != nonce {
+				remaining = append(remaining, reservation)
+			}
+		}
+		return remaining, nil
+	})
+}
+
+// Lock the nonce file, prune stale reservations for the address, run the updater and save the result
+func (m *NonceManager) update(from common.Address, updater func(reservations []nonceReservation, latestNonce uint64, pendingNonce uint64) ([]nonceReservation, error)) error {
+
+	// Make sure the folder exists
+	if err := os.MkdirAll(filepath.Dir(m.path), DirMode); err != nil {
+		return fmt.Errorf("error creating nonce file folder: %w", err)
+	}
+
+	// Lock the nonce file
+	unlock, err := lockFile(m.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Get the nonces known by the client
+	latestNonce, err := m.client.NonceAt(context.Background(), from, nil)
+	if err != nil {
+		return fmt.Errorf("error getting latest nonce for %s: %w", from.Hex(), err)
+	}
+	pendingNonce, err := m.client.PendingNonceAt(context.Background(), from)
+	if err != nil {
+		return fmt.Errorf("error getting pending nonce for %s: %w", from.Hex(), err)
+	}
+	if pendingNonce < latestNonce {
+		pendingNonce = latestNonce
+	}
+
+	// Load the reservations
+	file, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	// Remove reservations that have been mined, and expired ones that never made it into the mempool
+	reservations := []nonceReservation{}
+	for _, reservation := range file.Reservations[from] {
+		if reservation.Nonce < latestNonce {
+			continue
+		}
+		if reservation.Nonce >= pendingNonce && time.Since(reservation.Time) > NonceReservationTimeout {
+			continue
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	// Update and save the reservations
+	reservations, err = updater(reservations, latestNonce, pendingNonce)
+	if err != nil {
+		return err
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].Nonce < reservations[j].Nonce
+	})
+	if len(reservations) == 0 {
+		delete(file.Reservations, from)
+	} else {
+		file.Reservations[from] = reservations
+	}
+	return m.save(file)
+
+}
+
+// Load the nonce file from disk
+func (m *NonceManager) load() (*nonceFile, error) {
+	file := &nonceFile{
+		Reservations: map[common.Address][]nonceReservation{},
+	}
+	bytes, err := os.ReadFile(m.path)
+	if os.IsNotExist(err) {
+		return file, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading nonce file: %w", err)
+	}
+
+	if err := json.Unmarshal(bytes, file); err != nil {
+		return nil, fmt.Errorf("error deserializing nonce file: %w", err)
+	}
+	if file.Reservations == nil {
+		file.Reservations = map[common.Address][]nonceReservation{}
+	}
+	return file, nil
+}
+
+// Save the nonce file to disk
+func (m *NonceManager) save(file *nonceFile) error {
+	file.Version = NonceFileVersion
+	bytes, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("error serializing nonce file: %w", err)
+	}
+
+	tempPath := m.path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing nonce file: %w", err)
+	}
+	if err := os.Rename(tempPath, m.path); err != nil {
+		return fmt.Errorf("error saving nonce file: %w", err)
+	}
+	return nil
+}
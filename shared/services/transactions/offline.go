@@ -0,0 +1,163 @@
+package transactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// A transaction exported so it can be signed on an offline machine and broadcast from the node afterwards.
+// The decoded fields are included so the transaction can be reviewed before signing; they're checked against the encoded
+// transaction whenever it's loaded.
+type OfflineTransaction struct {
+	Purpose        string          `json:"purpose"`
+	ChainID        *big.Int        `json:"chainId"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to"`
+	Nonce          uint64          `json:"nonce"`
+	Value          *big.Int        `json:"value"`
+	Data           hexutil.Bytes   `json:"data"`
+	GasLimit       uint64          `json:"gasLimit"`
+	MaxFee         *big.Int        `json:"maxFee"`
+	MaxPriorityFee *big.Int        `json:"maxPriorityFee"`
+	UnsignedTx     hexutil.Bytes   `json:"unsignedTx"`
+	SignedTx       hexutil.Bytes   `json:"signedTx,omitempty"`
+}
+
+// Unsigned transactions exported while handling the current API call, so they can be returned alongside its response
+var (
+	exportedTransactions []*OfflineTransaction
+	exportLock           sync.Mutex
+)
+
+// Create an offline transaction from an unsigned transaction
+func NewOfflineTransaction(tx *types.Transaction, from common.Address, purpose string) (*OfflineTransaction, error) {
+	unsignedTx, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error encoding transaction: %w", err)
+	}
+	return &OfflineTransaction{
+		Purpose:        purpose,
+		ChainID:        tx.ChainId(),
+		From:           from,
+		To:             tx.To(),
+		Nonce:          tx.Nonce(),
+		Value:          tx.Value(),
+		Data:           tx.Data(),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		UnsignedTx:     unsignedTx,
+	}, nil
+}
+
+// Load an offline transaction from a file
+func LoadOfflineTransaction(path string) (*OfflineTransaction, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading transaction file %s: %w", path, err)
+	}
+	var offlineTx OfflineTransaction
+	if err := json.Unmarshal(bytes, &offlineTx); err != nil {
+		return nil, fmt.Errorf("error decoding transaction file %s: %w", path, err)
+	}
+	if _, err := offlineTx.GetUnsignedTransaction(); err != nil {
+		return nil, fmt.Errorf("transaction file %s is invalid: %w", path, err)
+	}
+	return &offlineTx, nil
+}
+
+// Save the offline transaction to a file
+func (t *OfflineTransaction) Save(path string) error {
+	bytes, err := json.MarshalIndent(t, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error encoding transaction: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing transaction file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Decode the unsigned transaction, making sure it matches the fields shown for review
+func (t *OfflineTransaction) GetUnsignedTransaction() (*types.Transaction, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(t.UnsignedTx); err != nil {
+		return nil, fmt.Errorf("error decoding unsigned transaction: %w", err)
+	}
+	if !t.matches(&tx) {
+		return nil, fmt.Errorf("the unsigned transaction doesn't match its details")
+	}
+	return &tx, nil
+}
+
+// Decode the signed transaction, making sure it's the unsigned transaction signed by the expected account
+func (t *OfflineTransaction) GetSignedTransaction() (*types.Transaction, error) {
+	if len(t.SignedTx) == 0 {
+		return nil, fmt.Errorf("the transaction hasn't been signed yet")
+	}
+	unsignedTx, err := t.GetUnsignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(t.SignedTx); err != nil {
+		return nil, fmt.Errorf("error decoding signed transaction: %w", err)
+	}
+	signer := types.NewLondonSigner(unsignedTx.ChainId())
+	if signer.Hash(&tx) != signer.Hash(unsignedTx) {
+		return nil, fmt.Errorf("the signed transaction doesn't match the unsigned transaction")
+	}
+	sender, err := types.Sender(signer, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering the signer of the transaction: %w", err)
+	}
+	if sender != t.From {
+		return nil, fmt.Errorf("the transaction was signed by %s instead of %s", sender.Hex(), t.From.Hex())
+	}
+	return &tx, nil
+}
+
+// Check if a transaction matches the offline transaction's details
+func (t *OfflineTransaction) matches(tx *types.Transaction) bool {
+	if (t.To == nil) != (tx.To() == nil) || (t.To != nil && *t.To != *tx.To()) {
+		return false
+	}
+	return bigEquals(t.ChainID, tx.ChainId()) &&
+		t.Nonce == tx.Nonce() &&
+		bigEquals(t.Value, tx.Value()) &&
+		string(t.Data) == string(tx.Data()) &&
+		t.GasLimit == tx.Gas() &&
+		bigEquals(t.MaxFee, tx.GasFeeCap()) &&
+		bigEquals(t.MaxPriorityFee, tx.GasTipCap())
+}
+
+// Compare two optional big integers
+func bigEquals(a *big.Int, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// Record an exported transaction
+func RecordExportedTransaction(tx *OfflineTransaction) {
+	exportLock.Lock()
+	defer exportLock.Unlock()
+	exportedTransactions = append(exportedTransactions, tx)
+}
+
+// Get the recorded exported transactions
+func GetExportedTransactions() []*OfflineTransaction {
+	exportLock.Lock()
+	defer exportLock.Unlock()
+	txs := make([]*OfflineTransaction, len(exportedTransactions))
+	copy(txs, exportedTransactions)
+	return txs
+}
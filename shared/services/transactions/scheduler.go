@@ -0,0 +1,269 @@
+package transactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Config
+const (
+	ScheduleFileVersion = 1
+
+	// How long finished entries are kept in the schedule for reference
+	ScheduleRetentionPeriod = 7 * 24 * time.Hour
+)
+
+// The status of a scheduled transaction
+type ScheduledTransactionStatus string
+
+const (
+	ScheduledTransactionStatus_Waiting   ScheduledTransactionStatus = "waiting"
+	ScheduledTransactionStatus_Submitted ScheduledTransactionStatus = "submitted"
+	ScheduledTransactionStatus_Failed    ScheduledTransactionStatus = "failed"
+	ScheduledTransactionStatus_Cancelled ScheduledTransactionStatus = "cancelled"
+)
+
+// A deferrable transaction that will be submitted once the network's base fee drops low enough, or its deadline passes
+type ScheduledTransaction struct {
+	ID             uint64                     `json:"id"`
+	Purpose        string                     `json:"purpose"`
+	From           common.Address             `json:"from"`
+	To             *common.Address            `json:"to"`
+	Value          *big.Int                   `json:"value"`
+	Data           hexutil.Bytes              `json:"data"`
+	GasLimit       uint64                     `json:"gasLimit"`
+	MaxFee         *big.Int                   `json:"maxFee"`
+	MaxPriorityFee *big.Int                   `json:"maxPriorityFee"`
+	Deadline       time.Time                  `json:"deadline"`
+	CreatedTime    time.Time                  `json:"createdTime"`
+	Status         ScheduledTransactionStatus `json:"status"`
+	Hash           common.Hash                `json:"hash,omitempty"`
+	SubmittedTime  time.Time                  `json:"submittedTime"`
+	Error          string                     `json:"error,omitempty"`
+}
+
+// Check if the transaction is still waiting to be submitted
+func (t *ScheduledTransaction) IsWaiting() bool {
+	return t.Status == ScheduledTransactionStatus_Waiting
+}
+
+// Check if the transaction's deadline has passed, so it must be submitted regardless of the network fees
+func (t *ScheduledTransaction) IsDue() bool {
+	return !t.Deadline.IsZero() && time.Now().After(t.Deadline)
+}
+
+// Check if the transaction can be submitted with the given base fee without exceeding its max fee
+func (t *ScheduledTransaction) CanSubmit(baseFee *big.Int) bool {
+	if t.MaxFee == nil {
+		return true
+	}
+	required := new(big.Int).Set(baseFee)
+	if t.MaxPriorityFee != nil {
+		required.Add(required, t.MaxPriorityFee)
+	}
+	return required.Cmp(t.MaxFee) <= 0
+}
+
+// The serialized schedule file
+type scheduleFile struct {
+	Version      uint                    `json:"version"`
+	NextID       uint64                  `json:"nextId"`
+	Transactions []*ScheduledTransaction `json:"transactions"`
+}
+
+// Persistent, process-safe queue of deferrable transactions.
+// The API adds transactions to it, and the node daemon submits them when the network's base fee allows.
+type Scheduler struct {
+	path string
+}
+
+// Create a new transaction scheduler backed by the file at the given path
+func NewScheduler(path string) *Scheduler {
+	return &Scheduler{
+		path: path,
+	}
+}
+
+// Add a transaction to the schedule. Its nonce is ignored; a new one will be assigned when it's submitted.
+func (s *Scheduler) ScheduleTransaction(tx *types.Transaction, from common.Address, purpose string, deadline time.Time) (*ScheduledTransaction, error) {
+	var scheduled *ScheduledTransaction
+	err := s.update(func(file *scheduleFile) error {
+		file.NextID++
+		scheduled = &ScheduledTransaction{
+			ID:             file.NextID,
+			Purpose:        purpose,
+			From:           from,
+			To:             tx.To(),
+			Value:          tx.Value(),
+			Data:           tx.Data(),
+			GasLimit:       tx.Gas(),
+			MaxFee:         tx.GasFeeCap(),
+			MaxPriorityFee: tx.GasTipCap(),
+			Deadline:       deadline,
+			CreatedTime:    time.Now(),
+			Status:         ScheduledTransactionStatus_Waiting,
+		}
+		file.Transactions = append(file.Transactions, scheduled)
+		return nil
+	})
+	return scheduled, err
+}
+
+// Get all of the transactions in the schedule, sorted from oldest to newest
+func (s *Scheduler) GetTransactions() ([]*ScheduledTransaction, error) {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return []*ScheduledTransaction{}, nil
+	}
+	unlock, err := lockFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	file, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return file.Transactions, nil
+}
+
+// Get the transactions from the given address that are still waiting to be submitted
+func (s *Scheduler) GetWaitingTransactions(from common.Address) ([]*ScheduledTransaction, error) {
+	scheduled, err := s.GetTransactions()
+	if err != nil {
+		return nil, err
+	}
+	waiting := []*ScheduledTransaction{}
+	for _, tx := range scheduled {
+		if tx.From == from && tx.IsWaiting() {
+			waiting = append(waiting, tx)
+		}
+	}
+	return waiting, nil
+}
+
+// Mark a scheduled transaction as submitted
+func (s *Scheduler) SetSubmitted(id uint64, hash common.Hash) error {
+	return s.setStatus(id, ScheduledTransactionStatus_Submitted, func(tx *ScheduledTransaction) {
+		tx.Hash = hash
+		tx.SubmittedTime = time.Now()
+	})
+}
+
+// Mark a scheduled transaction as failed, so it won't be submitted
+func (s *Scheduler) SetFailed(id uint64, reason error) error {
+	return s.setStatus(id, ScheduledTransactionStatus_Failed, func(tx *ScheduledTransaction) {
+		tx.Error = reason.Error()
+	})
+}
+
+// Cancel a scheduled transaction that hasn't been submitted yet
+func (s *Scheduler) Cancel(id uint64) error {
+	return s.setStatus(id, ScheduledTransactionStatus_Cancelled, nil)
+}
+
+// Change the status of a waiting transaction
+func (s *Scheduler) setStatus(id uint64, status ScheduledTransactionStatus, modifier func(tx *ScheduledTransaction)) error {
+	return s.update(func(file *scheduleFile) error {
+		for _, tx := range file.Transactions {
+			if tx.ID != id {
+				continue
+			}
+			if !tx.IsWaiting() {
+				return fmt.Errorf("scheduled transaction %d is not waiting (status: %s)", id, tx.Status)
+			}
+			tx.Status = status
+			if modifier != nil {
+				modifier(tx)
+			}
+			return nil
+		}
+		return fmt.Errorf("scheduled transaction %d does not exist", id)
+	})
+}
+
+// Atomically read, modify and save the schedule
+func (s *Scheduler) update(updater func(file *scheduleFile) error) error {
+
+	// Make sure the folder exists
+	if err := os.MkdirAll(filepath.Dir(s.path), DirMode); err != nil {
+		return fmt.Errorf("error creating transaction schedule folder: %w", err)
+	}
+
+	// Lock the schedule
+	unlock, err := lockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Load and update the schedule
+	file, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := updater(file); err != nil {
+		return err
+	}
+	return s.save(file)
+
+}
+
+// Load the schedule from disk
+func (s *Scheduler) load() (*scheduleFile, error) {
+	file := &scheduleFile{
+		Transactions: []*ScheduledTransaction{},
+	}
+	bytes, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return file, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading transaction schedule: %w", err)
+	}
+
+	if err := json.Unmarshal(bytes, file); err != nil {
+		return nil, fmt.Errorf("error deserializing transaction schedule: %w", err)
+	}
+	if file.Transactions == nil {
+		file.Transactions = []*ScheduledTransaction{}
+	}
+	return file, nil
+}
+
+// Save the schedule to disk, removing finished entries once they're past the retention period
+func (s *Scheduler) save(file *scheduleFile) error {
+	retained := make([]*ScheduledTransaction, 0, len(file.Transactions))
+	for _, tx := range file.Transactions {
+		if !tx.IsWaiting() && time.Since(tx.CreatedTime) > ScheduleRetentionPeriod {
+			continue
+		}
+		retained = append(retained, tx)
+	}
+	sort.SliceStable(retained, func(i, j int) bool {
+		return retained[i].ID < retained[j].ID
+	})
+	file.Transactions = retained
+	file.Version = ScheduleFileVersion
+
+	bytes, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("error serializing transaction schedule: %w", err)
+	}
+
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, FileMode); err != nil {
+		return fmt.Errorf("error writing transaction schedule: %w", err)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		return fmt.Errorf("error saving transaction schedule: %w", err)
+	}
+	return nil
+}
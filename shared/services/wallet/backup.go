@@ -0,0 +1,131 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
+)
+
+// The secret split into backup shares is the wallet's seed, followed by what's needed to derive the node account from it:
+//
+//	seed (64) | wallet index (4) | derivation path | checksum (4)
+//
+// The checksum is the first 4 bytes of the SHA-256 hash of everything before it, so a bad reconstruction is caught before
+// a wallet is recovered from it. The seed is already derived from the mnemonic and BIP-39 passphrase, so the passphrase
+// isn't needed to recover from the shares.
+const (
+	backupSeedLength     int = 64
+	backupIndexLength    int = 4
+	backupChecksumLength int = 4
+)
+
+// Split the wallet's seed into Shamir backup shares, any `threshold` of which can recover the wallet
+func (w *Wallet) CreateBackupShares(shareCount int, threshold int) ([]string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+	if len(w.seed) != backupSeedLength {
+		return nil, fmt.Errorf("Unexpected wallet seed length %d", len(w.seed))
+	}
+
+	// Create the secret
+	secret := make([]byte, 0, backupSeedLength+backupIndexLength+len(w.ws.DerivationPath)+backupChecksumLength)
+	secret = append(secret, w.seed...)
+	secret = binary.BigEndian.AppendUint32(secret, uint32(w.ws.WalletIndex))
+	secret = append(secret, []byte(w.ws.DerivationPath)...)
+	secret = append(secret, backupChecksum(secret)...)
+
+	// Split it
+	shares, err := shamir.Split(secret, shareCount, threshold)
+	for i := range secret {
+		secret[i] = 0
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not split wallet seed: %w", err)
+	}
+	shareStrings := make([]string, len(shares))
+	for i, share := range shares {
+		shareStrings[i] = share.String()
+	}
+	return shareStrings, nil
+
+}
+
+// Recover a wallet from a set of backup shares
+func (w *Wallet) RecoverFromShares(shares []string) error {
+
+	// Check wallet is not initialized
+	if w.IsInitialized() {
+		return errors.New("Wallet is already initialized")
+	}
+
+	// Combine the shares
+	seed, derivationPath, walletIndex, err := combineBackupShares(shares)
+	if err != nil {
+		return err
+	}
+
+	// Initialize wallet store
+	return w.initializeStoreFromSeed(derivationPath, walletIndex, seed, "")
+
+}
+
+// Recover a wallet from a set of backup shares - only used for testing shares
+func (w *Wallet) TestRecoveryFromShares(shares []string) error {
+
+	// Combine the shares
+	seed, derivationPath, walletIndex, err := combineBackupShares(shares)
+	if err != nil {
+		return err
+	}
+
+	// Create the unencrypted wallet store
+	return w.testRecoveryFromSeed(derivationPath, walletIndex, seed)
+
+}
+
+// Recover the seed, derivation path and wallet index from a set of backup shares
+func combineBackupShares(shareStrings []string) ([]byte, string, uint, error) {
+
+	// Parse the shares
+	shares := make([]shamir.Share, len(shareStrings))
+	for i, shareString := range shareStrings {
+		share, err := shamir.ParseShare(shareString)
+		if err != nil {
+			return nil, "", 0, fmt.Errorf("Invalid backup share %d: %w", i+1, err)
+		}
+		shares[i] = share
+	}
+
+	// Combine them
+	secret, err := shamir.Combine(shares)
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("Could not combine backup shares: %w", err)
+	}
+	if len(secret) < backupSeedLength+backupIndexLength+backupChecksumLength {
+		return nil, "", 0, errors.New("The backup shares don't contain a wallet seed")
+	}
+	body := secret[:len(secret)-backupChecksumLength]
+	if !bytes.Equal(backupChecksum(body), secret[len(body):]) {
+		return nil, "", 0, errors.New("The backup shares don't combine into a valid wallet seed")
+	}
+
+	// Decode the secret
+	seed := body[:backupSeedLength]
+	walletIndex := uint(binary.BigEndian.Uint32(body[backupSeedLength : backupSeedLength+backupIndexLength]))
+	derivationPath := string(body[backupSeedLength+backupIndexLength:])
+	return seed, derivationPath, walletIndex, nil
+
+}
+
+// Get the checksum of a backup secret
+func backupChecksum(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return hash[:backupChecksumLength]
+}
@@ -0,0 +1,253 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// JSON-RPC methods, with the Clef methods first and the generic node methods used as fallbacks
+const (
+	accountListMethod       string = "account_list"
+	accountSignTxMethod     string = "account_signTransaction"
+	accountSignDataMethod   string = "account_signData"
+	ethAccountsMethod       string = "eth_accounts"
+	ethSignTxMethod         string = "eth_signTransaction"
+	personalSignMethod      string = "personal_sign"
+	textPlainContentType    string = "text/plain"
+	methodNotFoundErrorCode int    = -32601
+	externalSignerUrlScheme string = "extapi"
+)
+
+// Transaction arguments for account_signTransaction and eth_signTransaction
+type signTransactionArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                hexutil.Big       `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 hexutil.Bytes     `json:"data"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+}
+
+// The result of account_signTransaction and eth_signTransaction
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// A Clef-compatible external signer that holds the node account's key
+type ExternalSigner struct {
+	url     string
+	address common.Address
+	client  *rpc.Client
+	lock    sync.Mutex
+}
+
+// Create a new external signer client. If the address is empty, the signer must have exactly one account.
+func NewExternalSigner(url string, address common.Address) *ExternalSigner {
+	return &ExternalSigner{
+		url:     url,
+		address: address,
+	}
+}
+
+// Get the URL of the external signer
+func (s *ExternalSigner) GetUrl() string {
+	return s.url
+}
+
+// Get the node account held by the external signer
+func (s *ExternalSigner) GetAddress() (common.Address, error) {
+	s.lock.Lock()
+	address := s.address
+	s.lock.Unlock()
+	if address != (common.Address{}) {
+		return address, nil
+	}
+
+	// Get the signer's accounts
+	var addresses []common.Address
+	if err := s.call(&addresses, accountListMethod, ethAccountsMethod); err != nil {
+		return common.Address{}, fmt.Errorf("error getting accounts from the external signer: %w", err)
+	}
+	switch len(addresses) {
+	case 0:
+		return common.Address{}, errors.New("the external signer doesn't have any accounts")
+	case 1:
+		s.lock.Lock()
+		s.address = addresses[0]
+		s.lock.Unlock()
+		return addresses[0], nil
+	default:
+		return common.Address{}, fmt.Errorf("the external signer has %d accounts; please set the External Node Signer Address in the Smartnode settings", len(addresses))
+	}
+}
+
+// Get the node account as a go-ethereum account
+func (s *ExternalSigner) GetAccount() (accounts.Account, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	return accounts.Account{
+		Address: address,
+		URL: accounts.URL{
+			Scheme: externalSignerUrlScheme,
+			Path:   s.url,
+		},
+	}, nil
+}
+
+// Create a transactor that has the external signer sign its transactions
+func (s *ExternalSigner) NewTransactor(chainID *big.Int) (*bind.TransactOpts, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &bind.TransactOpts{
+		From: address,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTransaction(tx, chainID)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+// Have the external signer sign a transaction from the node account
+func (s *ExternalSigner) SignTransaction(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type() != types.DynamicFeeTxType {
+		return nil, fmt.Errorf("unsupported transaction type %d for the external signer", tx.Type())
+	}
+
+	// Sign the transaction
+	accessList := tx.AccessList()
+	args := signTransactionArgs{
+		From:                 address,
+		To:                   tx.To(),
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                hexutil.Big(*tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Data:                 tx.Data(),
+		AccessList:           &accessList,
+		ChainID:              (*hexutil.Big)(chainID),
+	}
+	var result signTransactionResult
+	if err := s.call(&result, accountSignTxMethod, ethSignTxMethod, args); err != nil {
+		return nil, fmt.Errorf("error signing transaction with the external signer: %w", err)
+	}
+
+	// Make sure the signer signed the requested transaction with the node account
+	var signedTx types.Transaction
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("error decoding transaction signed by the external signer: %w", err)
+	}
+	signer := types.NewLondonSigner(chainID)
+	if signer.Hash(&signedTx) != signer.Hash(tx) {
+		return nil, errors.New("the external signer signed a different transaction than the one requested")
+	}
+	sender, err := types.Sender(signer, &signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering the signer of the transaction: %w", err)
+	}
+	if sender != address {
+		return nil, fmt.Errorf("the external signer signed the transaction with %s instead of %s", sender.Hex(), address.Hex())
+	}
+	return &signedTx, nil
+}
+
+// Have the external signer sign a text message with the node account, following EIP-191
+func (s *ExternalSigner) SignText(message []byte) ([]byte, error) {
+	address, err := s.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the message; account_signData and personal_sign take their parameters in different orders
+	var signature hexutil.Bytes
+	err = s.callWithFallback(&signature,
+		accountSignDataMethod, []interface{}{textPlainContentType, address, hexutil.Bytes(message)},
+		personalSignMethod, []interface{}{hexutil.Bytes(message), address},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error signing message with the external signer: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("the external signer returned a signature with %d bytes instead of %d", len(signature), crypto.SignatureLength)
+	}
+
+	// Make sure the signature is from the node account
+	recoverable := make([]byte, crypto.SignatureLength)
+	copy(recoverable, signature)
+	if recoverable[crypto.RecoveryIDOffset] >= 27 {
+		recoverable[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash(message), recoverable)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering the signer of the message: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != address {
+		return nil, fmt.Errorf("the external signer signed the message with %s instead of %s", signer.Hex(), address.Hex())
+	}
+
+	// Use the 27/28 'v' convention, like the node wallet
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		signature[crypto.RecoveryIDOffset] += 27
+	}
+	return signature, nil
+}
+
+// Call a method on the external signer, falling back to another method with the same parameters if it isn't supported
+func (s *ExternalSigner) call(result interface{}, method string, fallbackMethod string, args ...interface{}) error {
+	return s.callWithFallback(result, method, args, fallbackMethod, args)
+}
+
+// Call a method on the external signer, falling back to another method if it isn't supported
+func (s *ExternalSigner) callWithFallback(result interface{}, method string, args []interface{}, fallbackMethod string, fallbackArgs []interface{}) error {
+	client, err := s.getClient()
+	if err != nil {
+		return err
+	}
+	err = client.CallContext(context.Background(), result, method, args...)
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode {
+		err = client.CallContext(context.Background(), result, fallbackMethod, fallbackArgs...)
+	}
+	return err
+}
+
+// Get the JSON-RPC client, connecting to the external signer if required
+func (s *ExternalSigner) getClient() (*rpc.Client, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+	client, err := rpc.DialContext(context.Background(), s.url)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to the external signer at %s: %w", s.url, err)
+	}
+	s.client = client
+	return client, nil
+}
@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Names of the keystores that aren't stored on disk
+const (
+	KeymanagerKeystoreName   string = "keymanager"
+	RemoteSignerKeystoreName string = "web3signer"
+)
+
+// Get the pubkeys of the validator keys held by each of the wallet's keystores, including the Validator Client's
+// Keymanager API and the remote signer if they're enabled.
+// Keystores that couldn't be read are returned separately with their errors, so the rest can still be used.
+func (w *Wallet) GetStoredValidatorPubkeys() (map[string][]types.ValidatorPubkey, map[string]error) {
+
+	pubkeys := map[string][]types.ValidatorPubkey{}
+	errs := map[string]error{}
+
+	// Keystores on disk
+	for name, ks := range w.keystores {
+		listable, ok := ks.(keystore.ListableKeystore)
+		if !ok {
+			continue
+		}
+		keystorePubkeys, err := listable.ListValidatorPubkeys()
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		pubkeys[name] = keystorePubkeys
+	}
+
+	// Keys loaded into the Validator Client through its Keymanager API
+	if w.keymanager != nil {
+		keys, err := w.keymanager.ListValidatorKeys()
+		if err != nil {
+			errs[KeymanagerKeystoreName] = err
+		} else {
+			keymanagerPubkeys := make([]types.ValidatorPubkey, len(keys))
+			for i, key := range keys {
+				keymanagerPubkeys[i] = key.Pubkey
+			}
+			pubkeys[KeymanagerKeystoreName] = keymanagerPubkeys
+		}
+	}
+
+	// Keys held by the remote signer
+	if w.remoteSigner != nil {
+		remotePubkeys, err := w.remoteSigner.ListValidatorKeys()
+		if err != nil {
+			errs[RemoteSignerKeystoreName] = err
+		} else {
+			pubkeys[RemoteSignerKeystoreName] = remotePubkeys
+		}
+	}
+
+	return pubkeys, errs
+
+}
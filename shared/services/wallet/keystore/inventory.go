@@ -0,0 +1,30 @@
+package keystore
+
+import (
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// A keystore that can report which validator keys it holds
+type ListableKeystore interface {
+	Keystore
+
+	// Get the pubkeys of every validator key in the keystore
+	ListValidatorPubkeys() ([]types.ValidatorPubkey, error)
+}
+
+// Get the pubkey from the name of a keystore file or folder, which is the 0x-prefixed pubkey followed by the suffix.
+// Returns false if the name isn't in that form.
+func PubkeyFromFileName(name string, suffix string) (types.ValidatorPubkey, bool) {
+	if !strings.HasPrefix(name, "0x") || !strings.HasSuffix(name, suffix) {
+		return types.ValidatorPubkey{}, false
+	}
+	pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(strings.TrimSuffix(name, suffix)))
+	if err != nil {
+		return types.ValidatorPubkey{}, false
+	}
+	return pubkey, true
+}
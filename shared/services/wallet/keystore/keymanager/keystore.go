@@ -0,0 +1,367 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	KeystoresPath         = "/eth/v1/keystores"
+	RemoteKeysPath        = "/eth/v1/remotekeys"
+	SlashingProtectionDir = "slashing-protection"
+	RequestTimeout        = 30 * time.Second
+	DirMode               = 0770
+	FileMode              = 0640
+)
+
+// Import statuses
+const (
+	ImportStatus_Imported  = "imported"
+	ImportStatus_Duplicate = "duplicate"
+	ImportStatus_Error     = "error"
+)
+
+// Delete statuses
+const (
+	DeleteStatus_Deleted   = "deleted"
+	DeleteStatus_NotActive = "not_active"
+	DeleteStatus_NotFound  = "not_found"
+	DeleteStatus_Error     = "error"
+)
+
+// Keystore that loads and removes validator keys through the Validator Client's standard Keymanager API,
+// so it doesn't need to be restarted
+type Keystore struct {
+	apiUrl       string
+	tokenPath    string
+	keychainPath string
+	encryptor    *eth2ks.Encryptor
+	client       *http.Client
+}
+
+// A key loaded by the Validator Client
+type ValidatorKeyInfo struct {
+	Pubkey         types.ValidatorPubkey `json:"validating_pubkey"`
+	DerivationPath string                `json:"derivation_path"`
+	ReadOnly       bool                  `json:"readonly"`
+}
+
+// The result of importing or deleting a single key
+type KeyResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// EIP-2335 keystore
+type encryptedKeystore struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Version uint                   `json:"version"`
+	UUID    uuid.UUID              `json:"uuid"`
+	Path    string                 `json:"path"`
+	Pubkey  string                 `json:"pubkey"`
+}
+
+// API requests and responses
+type listKeystoresResponse struct {
+	Data []ValidatorKeyInfo `json:"data"`
+}
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type importKeystoresResponse struct {
+	Data []KeyResult `json:"data"`
+}
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+type deleteKeystoresResponse struct {
+	Data               []KeyResult `json:"data"`
+	SlashingProtection string      `json:"slashing_protection"`
+}
+type remoteKey struct {
+	Pubkey string `json:"pubkey"`
+	Url    string `json:"url"`
+}
+type importRemoteKeysRequest struct {
+	RemoteKeys []remoteKey `json:"remote_keys"`
+}
+type listRemoteKeysResponse struct {
+	Data []remoteKey `json:"data"`
+}
+
+// Create a new Keymanager API keystore.
+// Slashing protection data returned by the Validator Client when keys are removed is saved in the keychain path.
+// The token path may be blank for APIs that don't require authentication, such as Web3Signer's.
+func NewKeystore(apiUrl string, tokenPath string, keychainPath string) *Keystore {
+	return &Keystore{
+		apiUrl:       strings.TrimSuffix(apiUrl, "/"),
+		tokenPath:    tokenPath,
+		keychainPath: keychainPath,
+		encryptor:    eth2ks.New(eth2ks.WithCipher("scrypt")),
+		client: &http.Client{
+			Timeout: RequestTimeout,
+		},
+	}
+}
+
+// Get the keystore directory, which holds the slashing protection data of removed keys
+func (ks *Keystore) GetKeystoreDir() string {
+	return filepath.Join(ks.keychainPath, SlashingProtectionDir)
+}
+
+// Import a validator key into the Validator Client, including its slashing protection data if the key was
+// previously removed from it
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+	slashingProtection, err := ks.loadSlashingProtection(pubkey)
+	if err != nil {
+		return err
+	}
+	return ks.ImportValidatorKey(key, derivationPath, slashingProtection)
+}
+
+// Private keys can't be retrieved from the Validator Client, so this always reports the key as missing
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
+
+// Import a validator key into the Validator Client along with its EIP-3076 slashing protection data, which may be empty
+func (ks *Keystore) ImportValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string, slashingProtection []byte) error {
+
+	// Get validator pubkey
+	pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+
+	// Create a new password
+	password, err := keystore.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptedKey, err := ks.encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+	keystoreBytes, err := json.Marshal(encryptedKeystore{
+		Crypto:  encryptedKey,
+		Version: ks.encryptor.Version(),
+		UUID:    uuid.New(),
+		Path:    derivationPath,
+		Pubkey:  hexutil.RemovePrefix(pubkey.Hex()),
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode validator key: %w", err)
+	}
+
+	// Import it
+	request := importKeystoresRequest{
+		Keystores:          []string{string(keystoreBytes)},
+		Passwords:          []string{password},
+		SlashingProtection: string(slashingProtection),
+	}
+	var response importKeystoresResponse
+	if err := ks.sendRequest(http.MethodPost, KeystoresPath, request, &response); err != nil {
+		return fmt.Errorf("error importing validator key %s: %w", pubkey.Hex(), err)
+	}
+	if len(response.Data) != 1 {
+		return fmt.Errorf("error importing validator key %s: expected 1 result but the Validator Client returned %d", pubkey.Hex(), len(response.Data))
+	}
+	switch response.Data[0].Status {
+	case ImportStatus_Imported, ImportStatus_Duplicate:
+		return nil
+	default:
+		return fmt.Errorf("the Validator Client couldn't import validator key %s: %s", pubkey.Hex(), response.Data[0].Message)
+	}
+
+}
+
+// Get the keys loaded by the Validator Client
+func (ks *Keystore) ListValidatorKeys() ([]ValidatorKeyInfo, error) {
+	var response listKeystoresResponse
+	if err := ks.sendRequest(http.MethodGet, KeystoresPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("error listing validator keys: %w", err)
+	}
+	return response.Data, nil
+}
+
+// Remove keys from the Validator Client.
+// The slashing protection data it returns is saved so it can be restored if the keys are imported again.
+func (ks *Keystore) DeleteValidatorKeys(pubkeys []types.ValidatorPubkey) ([]KeyResult, error) {
+
+	// Delete the keys
+	request := deleteKeystoresRequest{
+		Pubkeys: make([]string, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.Pubkeys[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	var response deleteKeystoresResponse
+	if err := ks.sendRequest(http.MethodDelete, KeystoresPath, request, &response); err != nil {
+		return nil, fmt.Errorf("error deleting validator keys: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, fmt.Errorf("error deleting validator keys: expected %d results but the Validator Client returned %d", len(pubkeys), len(response.Data))
+	}
+
+	// Save the slashing protection data
+	if response.SlashingProtection != "" {
+		for i, pubkey := range pubkeys {
+			switch response.Data[i].Status {
+			case DeleteStatus_Deleted, DeleteStatus_NotActive:
+				if err := ks.saveSlashingProtection(pubkey, []byte(response.SlashingProtection)); err != nil {
+					return response.Data, err
+				}
+			}
+		}
+	}
+
+	return response.Data, nil
+
+}
+
+// Register a key held by a remote signer with the Validator Client, so it requests signatures for it from the signer's URL
+func (ks *Keystore) ImportRemoteKey(pubkey types.ValidatorPubkey, signerUrl string) error {
+	request := importRemoteKeysRequest{
+		RemoteKeys: []remoteKey{{
+			Pubkey: hexutil.AddPrefix(pubkey.Hex()),
+			Url:    signerUrl,
+		}},
+	}
+	var response importKeystoresResponse
+	if err := ks.sendRequest(http.MethodPost, RemoteKeysPath, request, &response); err != nil {
+		return fmt.Errorf("error registering remote key %s: %w", pubkey.Hex(), err)
+	}
+	if len(response.Data) != 1 {
+		return fmt.Errorf("error registering remote key %s: expected 1 result but the Validator Client returned %d", pubkey.Hex(), len(response.Data))
+	}
+	switch response.Data[0].Status {
+	case ImportStatus_Imported, ImportStatus_Duplicate:
+		return nil
+	default:
+		return fmt.Errorf("the Validator Client couldn't register remote key %s: %s", pubkey.Hex(), response.Data[0].Message)
+	}
+}
+
+// Get the keys held by remote signers that the Validator Client is using
+func (ks *Keystore) ListRemoteKeys() ([]types.ValidatorPubkey, error) {
+	var response listRemoteKeysResponse
+	if err := ks.sendRequest(http.MethodGet, RemoteKeysPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("error listing remote keys: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(response.Data))
+	for _, key := range response.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(key.Pubkey))
+		if err != nil {
+			return nil, fmt.Errorf("the Validator Client returned an invalid remote key '%s': %w", key.Pubkey, err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
+// Send a request to the Keymanager API
+func (ks *Keystore) sendRequest(method string, path string, body interface{}, response interface{}) error {
+
+	// Read the auth token
+	var token []byte
+	if ks.tokenPath != "" {
+		var err error
+		token, err = os.ReadFile(ks.tokenPath)
+		if err != nil {
+			return fmt.Errorf("could not read the Keymanager API token from %s: %w", ks.tokenPath, err)
+		}
+	}
+
+	// Create the request
+	var reader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("could not encode request: %w", err)
+		}
+		reader = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequest(method, ks.apiUrl+path, reader)
+	if err != nil {
+		return err
+	}
+	if token != nil {
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	// Send it
+	httpResponse, err := ks.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("could not reach the Keymanager API at %s: %w", ks.apiUrl, err)
+	}
+	defer httpResponse.Body.Close()
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	}
+	switch httpResponse.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+	default:
+		return fmt.Errorf("the Keymanager API returned status %d: %s", httpResponse.StatusCode, strings.TrimSpace(string(responseBytes)))
+	}
+
+	// Decode the response
+	if response == nil || len(responseBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(responseBytes, response); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
+	}
+	return nil
+
+}
+
+// Get the path of the saved slashing protection data for a key
+func (ks *Keystore) getSlashingProtectionPath(pubkey types.ValidatorPubkey) string {
+	return filepath.Join(ks.GetKeystoreDir(), hexutil.AddPrefix(pubkey.Hex())+".json")
+}
+
+// Load the saved slashing protection data for a key, if there is any
+func (ks *Keystore) loadSlashingProtection(pubkey types.ValidatorPubkey) ([]byte, error) {
+	bytes, err := os.ReadFile(ks.getSlashingProtectionPath(pubkey))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read the slashing protection data for validator %s: %w", pubkey.Hex(), err)
+	}
+	return bytes, nil
+}
+
+// Save the slashing protection data for a removed key
+func (ks *Keystore) saveSlashingProtection(pubkey types.ValidatorPubkey, data []byte) error {
+	path := ks.getSlashingProtectionPath(pubkey)
+	if err := os.MkdirAll(filepath.Dir(path), DirMode); err != nil {
+		return fmt.Errorf("could not create slashing protection folder: %w", err)
+	}
+	if err := os.WriteFile(path, data, FileMode); err != nil {
+		return fmt.Errorf("could not save the slashing protection data for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package keymanager
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// API requests and responses
+type feeRecipientData struct {
+	EthAddress common.Address `json:"ethaddress"`
+}
+type feeRecipientResponse struct {
+	Data feeRecipientData `json:"data"`
+}
+type graffitiData struct {
+	Graffiti string `json:"graffiti"`
+}
+type graffitiResponse struct {
+	Data graffitiData `json:"data"`
+}
+type gasLimitData struct {
+	GasLimit string `json:"gas_limit"`
+}
+type gasLimitResponse struct {
+	Data gasLimitData `json:"data"`
+}
+
+// Get the fee recipient the Validator Client uses for a validator
+func (ks *Keystore) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	var response feeRecipientResponse
+	if err := ks.sendRequest(http.MethodGet, getValidatorPath(pubkey, "feerecipient"), nil, &response); err != nil {
+		return common.Address{}, fmt.Errorf("error getting the fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return response.Data.EthAddress, nil
+}
+
+// Set the fee recipient the Validator Client uses for a validator
+func (ks *Keystore) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	if err := ks.sendRequest(http.MethodPost, getValidatorPath(pubkey, "feerecipient"), feeRecipientData{EthAddress: feeRecipient}, nil); err != nil {
+		return fmt.Errorf("error setting the fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the graffiti the Validator Client uses for a validator
+func (ks *Keystore) GetGraffiti(pubkey types.ValidatorPubkey) (string, error) {
+	var response graffitiResponse
+	if err := ks.sendRequest(http.MethodGet, getValidatorPath(pubkey, "graffiti"), nil, &response); err != nil {
+		return "", fmt.Errorf("error getting the graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	return response.Data.Graffiti, nil
+}
+
+// Set the graffiti the Validator Client uses for a validator
+func (ks *Keystore) SetGraffiti(pubkey types.ValidatorPubkey, graffiti string) error {
+	if err := ks.sendRequest(http.MethodPost, getValidatorPath(pubkey, "graffiti"), graffitiData{Graffiti: graffiti}, nil); err != nil {
+		return fmt.Errorf("error setting the graffiti for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the gas limit the Validator Client registers with builders for a validator
+func (ks *Keystore) GetGasLimit(pubkey types.ValidatorPubkey) (uint64, error) {
+	var response gasLimitResponse
+	if err := ks.sendRequest(http.MethodGet, getValidatorPath(pubkey, "gas_limit"), nil, &response); err != nil {
+		return 0, fmt.Errorf("error getting the gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	gasLimit, err := strconv.ParseUint(response.Data.GasLimit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("the Validator Client returned an invalid gas limit '%s' for validator %s: %w", response.Data.GasLimit, pubkey.Hex(), err)
+	}
+	return gasLimit, nil
+}
+
+// Set the gas limit the Validator Client registers with builders for a validator
+func (ks *Keystore) SetGasLimit(pubkey types.ValidatorPubkey, gasLimit uint64) error {
+	if err := ks.sendRequest(http.MethodPost, getValidatorPath(pubkey, "gas_limit"), gasLimitData{GasLimit: strconv.FormatUint(gasLimit, 10)}, nil); err != nil {
+		return fmt.Errorf("error setting the gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the path of a per-validator Keymanager API endpoint
+func getValidatorPath(pubkey types.ValidatorPubkey, setting string) string {
+	return fmt.Sprintf("/eth/v1/validator/%s/%s", hexutil.AddPrefix(pubkey.Hex()), setting)
+}
@@ -182,3 +182,88 @@ func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.B
 	return privateKey, nil
 
 }
+
+// Re-encrypt every validator key with a new random password, returning the file updates without applying them
+func (ks *Keystore) PrepareRotation() ([]keystore.FileUpdate, error) {
+
+	// Get the validator key folders
+	validatorsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir)
+	entries, err := os.ReadDir(validatorsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't read the Lodestar validator keys folder: %w", err)
+	}
+
+	updates := []keystore.FileUpdate{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		// Read the keystore and its secret
+		keyFilePath := filepath.Join(validatorsPath, entry.Name(), KeyFileName)
+		secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, entry.Name())
+		keyBytes, err := os.ReadFile(keyFilePath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't read the Lodestar keystore %s: %w", keyFilePath, err)
+		}
+		secretBytes, err := os.ReadFile(secretFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the Lodestar secret for %s: %w", entry.Name(), err)
+		}
+		var keyStore validatorKey
+		if err := json.Unmarshal(keyBytes, &keyStore); err != nil {
+			return nil, fmt.Errorf("error deserializing Lodestar keystore %s: %w", keyFilePath, err)
+		}
+
+		// Re-encrypt the key
+		crypto, password, err := keystore.ReencryptKey(ks.encryptor, keyStore.Crypto, string(secretBytes))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't re-encrypt the Lodestar keystore for %s: %w", entry.Name(), err)
+		}
+		keyStore.Crypto = crypto
+		keyBytes, err = json.Marshal(keyStore)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode validator key: %w", err)
+		}
+		updates = append(updates,
+			keystore.FileUpdate{Path: secretFilePath, Contents: []byte(password), Mode: FileMode},
+			keystore.FileUpdate{Path: keyFilePath, Contents: keyBytes, Mode: FileMode},
+		)
+	}
+	return updates, nil
+
+}
+
+// Get the pubkeys of every validator key in the keystore
+func (ks *Keystore) ListValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+
+	// Get the validator key folders
+	validatorsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir)
+	entries, err := os.ReadDir(validatorsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't read the Lodestar validator keys folder: %w", err)
+	}
+
+	pubkeys := []types.ValidatorPubkey{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pubkey, ok := keystore.PubkeyFromFileName(entry.Name(), "")
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(validatorsPath, entry.Name(), KeyFileName)); err != nil {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+
+}
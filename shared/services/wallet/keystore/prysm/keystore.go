@@ -267,3 +267,64 @@ func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.B
 	return nil, nil
 
 }
+
+// Re-encrypt the account store with a new random password, returning the file updates without applying them
+func (ks *Keystore) PrepareRotation() ([]rpkeystore.FileUpdate, error) {
+
+	// Read the keystore and its password
+	keystoreFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName)
+	passwordFilePath := filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystorePasswordFileName)
+	ksBytes, err := os.ReadFile(keystoreFilePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read validator keystore: %w", err)
+	}
+	passwordBytes, err := os.ReadFile(passwordFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading account password file: %w", err)
+	}
+	keystore := &validatorKeystore{}
+	if err = json.Unmarshal(ksBytes, keystore); err != nil {
+		return nil, fmt.Errorf("Could not decode validator keystore: %w", err)
+	}
+
+	// Re-encrypt the account store
+	crypto, password, err := rpkeystore.ReencryptKey(ks.encryptor, keystore.Crypto, string(passwordBytes))
+	if err != nil {
+		return nil, fmt.Errorf("Could not re-encrypt validator account store: %w", err)
+	}
+	keystore.Crypto = crypto
+	ksBytes, err = json.Marshal(keystore)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode validator keystore: %w", err)
+	}
+	return []rpkeystore.FileUpdate{
+		{Path: passwordFilePath, Contents: []byte(password), Mode: FileMode},
+		{Path: keystoreFilePath, Contents: ksBytes, Mode: FileMode},
+	}, nil
+
+}
+
+// Get the pubkeys of every validator key in the keystore
+func (ks *Keystore) ListValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+
+	// Don't create the account store if it doesn't exist yet
+	_, err := os.Stat(filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	// Initialize the account store
+	err = ks.initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	pubkeys := make([]types.ValidatorPubkey, 0, len(ks.as.PublicKeys))
+	for _, pubkey := range ks.as.PublicKeys {
+		pubkeys = append(pubkeys, types.BytesToValidatorPubkey(pubkey))
+	}
+	return pubkeys, nil
+
+}
@@ -0,0 +1,160 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// The suffix of the temporary files written while applying file updates
+const updateTempSuffix string = ".rotate-tmp"
+
+// A pending write to a keystore file. Rotations prepare every update in memory before changing any files, so a failure
+// partway through can be rolled back.
+type FileUpdate struct {
+	Path     string
+	Contents []byte
+	Mode     os.FileMode
+}
+
+// A keystore that can re-encrypt its keys with new passwords
+type RotatableKeystore interface {
+	Keystore
+
+	// Re-encrypt every key in the keystore with a new random password, returning the file updates without applying them
+	PrepareRotation() ([]FileUpdate, error)
+}
+
+// The original state of a file that's been updated
+type originalFile struct {
+	path     string
+	exists   bool
+	contents []byte
+	mode     os.FileMode
+}
+
+// Decrypt an encrypted key with its current password and encrypt it again with a new random password
+func ReencryptKey(encryptor *eth2ks.Encryptor, crypto map[string]interface{}, password string) (map[string]interface{}, string, error) {
+	key, err := encryptor.Decrypt(crypto, password)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not decrypt key: %w", err)
+	}
+	newPassword, err := GenerateRandomPassword()
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not generate random password: %w", err)
+	}
+	newCrypto, err := encryptor.Encrypt(key, newPassword)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not encrypt key: %w", err)
+	}
+	return newCrypto, newPassword, nil
+}
+
+// Apply a set of file updates all at once. Every new file is written to a temporary file first, and then they're all
+// renamed into place; if anything fails, the files that were already replaced are restored.
+// On success, returns a function that restores all of the original files.
+func ApplyFileUpdates(updates []FileUpdate) (func() error, error) {
+
+	// Save the original files
+	originals := make([]originalFile, len(updates))
+	for i, update := range updates {
+		original := originalFile{
+			path: update.Path,
+		}
+		info, err := os.Stat(update.Path)
+		if err == nil {
+			original.exists = true
+			original.mode = info.Mode().Perm()
+			original.contents, err = os.ReadFile(update.Path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("Could not read %s: %w", update.Path, err)
+		}
+		originals[i] = original
+	}
+
+	// Write the temporary files
+	for i, update := range updates {
+		if err := writeTempFile(update.Path, update.Contents, update.Mode); err != nil {
+			removeTempFiles(updates[:i+1])
+			return nil, err
+		}
+	}
+
+	// Move them into place
+	for i, update := range updates {
+		if err := os.Rename(update.Path+updateTempSuffix, update.Path); err != nil {
+			removeTempFiles(updates[i:])
+			err = fmt.Errorf("Could not replace %s: %w", update.Path, err)
+			if restoreErr := restoreFiles(originals[:i]); restoreErr != nil {
+				return nil, fmt.Errorf("%w; restoring the original files also failed: %s", err, restoreErr.Error())
+			}
+			return nil, err
+		}
+	}
+
+	return func() error {
+		return restoreFiles(originals)
+	}, nil
+
+}
+
+// Write the temporary file for an update, making sure it's flushed to disk
+func writeTempFile(path string, contents []byte, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("Could not create folder for %s: %w", path, err)
+	}
+	file, err := os.OpenFile(path+updateTempSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("Could not create temporary file for %s: %w", path, err)
+	}
+	_, err = file.Write(contents)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Could not write temporary file for %s: %w", path, err)
+	}
+	return nil
+}
+
+// Remove the temporary files of a set of updates
+func removeTempFiles(updates []FileUpdate) {
+	for _, update := range updates {
+		_ = os.Remove(update.Path + updateTempSuffix)
+	}
+}
+
+// Restore a set of files to their original state, in reverse order
+func restoreFiles(originals []originalFile) error {
+	errs := []string{}
+	for i := len(originals) - 1; i >= 0; i-- {
+		original := originals[i]
+		var err error
+		if original.exists {
+			err = writeTempFile(original.path, original.contents, original.mode)
+			if err == nil {
+				err = os.Rename(original.path+updateTempSuffix, original.path)
+			}
+		} else {
+			err = os.Remove(original.path)
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", original.path, err.Error()))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -180,3 +181,86 @@ func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.B
 	return privateKey, nil
 
 }
+
+// Re-encrypt every validator key with a new random password, returning the file updates without applying them
+func (ks *Keystore) PrepareRotation() ([]keystore.FileUpdate, error) {
+
+	// Get the validator key files
+	validatorsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir)
+	entries, err := os.ReadDir(validatorsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't read the Teku validator keys folder: %w", err)
+	}
+
+	updates := []keystore.FileUpdate{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+
+		// Read the keystore and its secret
+		keyFilePath := filepath.Join(validatorsPath, entry.Name())
+		secretFilePath := filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, name+".txt")
+		keyBytes, err := os.ReadFile(keyFilePath)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("couldn't read the Teku keystore %s: %w", keyFilePath, err)
+		}
+		secretBytes, err := os.ReadFile(secretFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read the Teku secret for %s: %w", name, err)
+		}
+		var keyStore validatorKey
+		if err := json.Unmarshal(keyBytes, &keyStore); err != nil {
+			return nil, fmt.Errorf("error deserializing Teku keystore %s: %w", keyFilePath, err)
+		}
+
+		// Re-encrypt the key
+		crypto, password, err := keystore.ReencryptKey(ks.encryptor, keyStore.Crypto, string(secretBytes))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't re-encrypt the Teku keystore for %s: %w", name, err)
+		}
+		keyStore.Crypto = crypto
+		keyBytes, err = json.Marshal(keyStore)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encode validator key: %w", err)
+		}
+		updates = append(updates,
+			keystore.FileUpdate{Path: secretFilePath, Contents: []byte(password), Mode: FileMode},
+			keystore.FileUpdate{Path: keyFilePath, Contents: keyBytes, Mode: FileMode},
+		)
+	}
+	return updates, nil
+
+}
+
+// Get the pubkeys of every validator key in the keystore
+func (ks *Keystore) ListValidatorPubkeys() ([]types.ValidatorPubkey, error) {
+
+	// Get the validator key files
+	validatorsPath := filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir)
+	entries, err := os.ReadDir(validatorsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("couldn't read the Teku validator keys folder: %w", err)
+	}
+
+	pubkeys := []types.ValidatorPubkey{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		pubkey, ok := keystore.PubkeyFromFileName(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+
+}
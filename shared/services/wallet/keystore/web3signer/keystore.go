@@ -0,0 +1,86 @@
+package web3signer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
+)
+
+// Keystore that holds validator keys in a Web3Signer instance, which the Validator Client uses as a remote signer.
+// Keys are imported through Web3Signer's key manager API, which must be enabled with `--key-manager-api-enabled`.
+type Keystore struct {
+	signerUrl string
+	signer    *keymanager.Keystore
+	vc        *keymanager.Keystore
+}
+
+// Create a new Web3Signer keystore
+func NewKeystore(signerUrl string, keychainPath string) *Keystore {
+	signerUrl = strings.TrimSuffix(signerUrl, "/")
+	return &Keystore{
+		signerUrl: signerUrl,
+		signer:    keymanager.NewKeystore(signerUrl, "", keychainPath),
+	}
+}
+
+// Set the Keymanager API of the Validator Client, which new keys will be registered with as remote keys.
+// Without it, the Validator Client has to be configured to fetch the list of keys from Web3Signer on its own.
+func (ks *Keystore) SetValidatorClient(vc *keymanager.Keystore) {
+	ks.vc = vc
+}
+
+// Check if new keys are registered with the Validator Client directly, so it doesn't need to be restarted to use them
+func (ks *Keystore) IsRegisteredWithValidatorClient() bool {
+	return ks.vc != nil
+}
+
+// Get the URL of the Web3Signer instance
+func (ks *Keystore) GetUrl() string {
+	return ks.signerUrl
+}
+
+// Web3Signer doesn't keep anything on the local disk
+func (ks *Keystore) GetKeystoreDir() string {
+	return ""
+}
+
+// Import a validator key into Web3Signer and register it with the Validator Client if possible
+func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error {
+
+	// Import the key into Web3Signer
+	if err := ks.signer.StoreValidatorKey(key, derivationPath); err != nil {
+		return fmt.Errorf("error importing validator key into Web3Signer at %s: %w", ks.signerUrl, err)
+	}
+
+	// Point the Validator Client at it
+	if ks.vc != nil {
+		pubkey := types.BytesToValidatorPubkey(key.PublicKey().Marshal())
+		if err := ks.vc.ImportRemoteKey(pubkey, ks.signerUrl); err != nil {
+			return err
+		}
+	}
+	return nil
+
+}
+
+// Private keys can't be retrieved from Web3Signer, so this always reports the key as missing
+func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	return nil, nil
+}
+
+// Get the keys held by Web3Signer
+func (ks *Keystore) ListValidatorKeys() ([]types.ValidatorPubkey, error) {
+	keys, err := ks.signer.ListValidatorKeys()
+	if err != nil {
+		return nil, fmt.Errorf("error listing the keys in Web3Signer at %s: %w", ks.signerUrl, err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(keys))
+	for i, key := range keys {
+		pubkeys[i] = key.Pubkey
+	}
+	return pubkeys, nil
+}
@@ -6,16 +6,31 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 )
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// Use the external signer's account if there is one
+	if w.externalSigner != nil {
+		return w.externalSigner.GetAccount()
+	}
+
+	// Use the watch-only address if there are no keys
+	if w.IsWatchOnly() {
+		return accounts.Account{Address: *w.watchOnlyAddress}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
@@ -47,25 +62,236 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
+	return w.GetNodeAccountTransactorWithPurpose(w.txPurpose)
+}
 
-	// Check wallet is initialized
-	if !w.IsInitialized() {
-		return nil, errors.New("Wallet is not initialized")
+// Get a transactor for the node account whose transactions are recorded in the journal with the given purpose
+func (w *Wallet) GetNodeAccountTransactorWithPurpose(purpose string) (*bind.TransactOpts, error) {
+
+	// Export unsigned transactions instead of submitting them if requested
+	if w.exportUnsigned {
+		return w.getExportingTransactor(purpose)
+	}
+	if w.IsWatchOnly() {
+		return nil, errors.New("The node wallet is watch-only, so its transactions must be exported and signed offline")
 	}
 
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
+	// Create the transactor, signing with the external signer if there is one
+	var transactor *bind.TransactOpts
+	if w.externalSigner != nil {
+		var err error
+		transactor, err = w.externalSigner.NewTransactor(w.chainID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Check wallet is initialized
+		if !w.IsInitialized() {
+			return nil, errors.New("Wallet is not initialized")
+		}
+
+		// Get private key
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		transactor, err = bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Create & return transactor
-	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	// Set it up & return it
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	if !w.scheduleDeadline.IsZero() {
+		transactor.NoSend = true
+		w.addSchedulingSigner(transactor, purpose)
+	} else if w.journal != nil || w.nonceManager != nil {
+		w.addTransactionSigner(transactor, purpose)
+	}
+	return transactor, nil
+
+}
+
+// Wrap a transactor's signer so every transaction it signs for submission gets its nonce from the nonce manager.
+// The transaction is recorded in the journal by HandleTransactionSent once it has actually been sent.
+func (w *Wallet) addTransactionSigner(transactor *bind.TransactOpts, purpose string) {
+	signer := transactor.Signer
+	nonceManager := w.nonceManager
+	transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if transactor.NoSend {
+			return signer(address, tx)
+		}
+
+		// Assign the nonce
+		reservedNonce := false
+		if nonceManager != nil {
+			if transactor.Nonce != nil {
+				// Respect manually provided nonces, but make sure nothing else uses them
+				if err := nonceManager.ReserveNonce(address, tx.Nonce()); err != nil {
+					fmt.Fprintf(os.Stderr, "WARNING: couldn't reserve nonce %d with the nonce manager: %s\n", tx.Nonce(), err.Error())
+				} else {
+					reservedNonce = true
+				}
+			} else {
+				nonce, err := nonceManager.GetNextNonce(address)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "WARNING: couldn't get a nonce from the nonce manager, using %d from the Execution client instead: %s\n", tx.Nonce(), err.Error())
+				} else {
+					reservedNonce = true
+					if nonce != tx.Nonce() {
+						tx, err = withNonce(tx, nonce)
+						if err != nil {
+							w.releaseNonce(address, nonce)
+							return nil, err
+						}
+					}
+				}
+			}
+		}
+
+		// Sign the transaction and hold onto it until it's sent
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			if reservedNonce {
+				w.releaseNonce(address, tx.Nonce())
+			}
+			return nil, err
+		}
+		w.unsentTxsLock.Lock()
+		w.unsentTxs[signedTx.Hash()] = unsentTransaction{
+			from:          address,
+			purpose:       purpose,
+			reservedNonce: reservedNonce,
+		}
+		w.unsentTxsLock.Unlock()
+		return signedTx, nil
+	}
+}
+
+// Handle the result of sending a transaction to the Execution client. If it was signed by one of the wallet's
+// transactors, it's recorded in the journal when it was sent, or its nonce is released when it wasn't.
+// Transactions signed elsewhere are ignored.
+func (w *Wallet) HandleTransactionSent(tx *types.Transaction, sendErr error) {
+
+	// Get the transaction's details
+	w.unsentTxsLock.Lock()
+	unsent, exists := w.unsentTxs[tx.Hash()]
+	delete(w.unsentTxs, tx.Hash())
+	w.unsentTxsLock.Unlock()
+	if !exists {
+		return
+	}
+	if sendErr != nil && !transactions.IsAlreadyKnownError(sendErr) {
+		if unsent.reservedNonce {
+			w.releaseNonce(unsent.from, tx.Nonce())
+		}
+		return
+	}
+
+	// Record it in the journal
+	if w.journal != nil {
+		if err := w.journal.RecordTransaction(tx, unsent.from, unsent.purpose); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: couldn't record transaction %s in the transaction journal: %s\n", tx.Hash().Hex(), err.Error())
+		}
+	}
+
+}
+
+// Wrap a transactor's signer so every transaction it signs is added to the scheduler instead of being submitted
+func (w *Wallet) addSchedulingSigner(transactor *bind.TransactOpts, purpose string) {
+	signer := transactor.Signer
+	scheduler := w.scheduler
+	deadline := w.scheduleDeadline
+	transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := scheduler.ScheduleTransaction(signedTx, address, purpose, deadline); err != nil {
+			return nil, fmt.Errorf("error scheduling transaction: %w", err)
+		}
+		return signedTx, nil
+	}
+}
+
+// Release a nonce reserved for a transaction that won't be sent, so later transactions don't wait behind the gap
+func (w *Wallet) releaseNonce(from common.Address, nonce uint64) {
+	if err := w.nonceManager.ReleaseNonce(from, nonce); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't release nonce %d with the nonce manager: %s\n", nonce, err.Error())
+	}
+}
+
+// A transaction that was signed for submission by one of the wallet's transactors
+type unsentTransaction struct {
+	from          common.Address
+	purpose       string
+	reservedNonce bool
+}
+
+// Create a copy of an unsigned transaction with a different nonce
+func withNonce(tx *types.Transaction, nonce uint64) (*types.Transaction, error) {
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: tx.GasPrice(),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}), nil
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasPrice:   tx.GasPrice(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+}
+
+// Sign a transaction with the node account's private key
+func (w *Wallet) SignNodeTransaction(tx *types.Transaction) (*types.Transaction, error) {
+
+	// Use the external signer if there is one
+	if w.externalSigner != nil {
+		return w.externalSigner.SignTransaction(tx, w.chainID)
+	}
+
+	// Get private key
+	privateKey, _, err := w.getNodePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the transaction
+	signedTx, err := types.SignTx(tx, types.NewLondonSigner(w.chainID), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error signing TX: %w", err)
+	}
+	return signedTx, nil
 
 }
 
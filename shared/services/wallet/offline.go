@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
+)
+
+// Load the watch-only node address from a file, or nil if there isn't one
+func LoadWatchOnlyAddress(path string) (*common.Address, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("Could not read watch-only address file: %w", err)
+	}
+	addressString := strings.TrimSpace(string(bytes))
+	if !common.IsHexAddress(addressString) {
+		return nil, fmt.Errorf("Invalid watch-only address '%s' in %s", addressString, path)
+	}
+	address := common.HexToAddress(addressString)
+	return &address, nil
+}
+
+// Save the watch-only node address to a file, or delete the file if the address is nil
+func SaveWatchOnlyAddress(path string, address *common.Address) error {
+	if address == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Could not delete watch-only address file: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(address.Hex()), FileMode); err != nil {
+		return fmt.Errorf("Could not write watch-only address file: %w", err)
+	}
+	return nil
+}
+
+// Use an address without any keys as the node account, so transactions for it can be built and exported for offline signing
+func (w *Wallet) SetWatchOnlyAddress(address common.Address) {
+	w.watchOnlyAddress = &address
+}
+
+// Check if the wallet only knows the node account's address, without any of its keys
+func (w *Wallet) IsWatchOnly() bool {
+	return !w.IsInitialized() && w.watchOnlyAddress != nil && w.externalSigner == nil
+}
+
+// Export unsigned transactions created by subsequent transactors instead of signing and submitting them
+func (w *Wallet) ExportUnsignedTransactions() {
+	w.exportUnsigned = true
+}
+
+// Get a transactor that records unsigned transactions for export instead of submitting them
+func (w *Wallet) getExportingTransactor(purpose string) (*bind.TransactOpts, error) {
+
+	// Get the node account
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the transactor
+	transactor := &bind.TransactOpts{
+		From:      account.Address,
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+		NoSend:    true,
+	}
+	transactor.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != account.Address {
+			return nil, bind.ErrNotAuthorized
+		}
+		if tx.Type() != types.DynamicFeeTxType {
+			return nil, fmt.Errorf("unsupported transaction type %d for offline signing", tx.Type())
+		}
+
+		// None of the exported transactions are in the mempool yet, so give each one after the first the next nonce
+		nonce := tx.Nonce()
+		if transactor.Nonce == nil && w.nextExportNonce != nil && *w.nextExportNonce > nonce {
+			nonce = *w.nextExportNonce
+		}
+		nextNonce := nonce + 1
+		w.nextExportNonce = &nextNonce
+
+		// The chain ID is normally added while signing, so add it here instead
+		exportTx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:    w.chainID,
+			Nonce:      nonce,
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+
+		// Record it for export
+		offlineTx, err := transactions.NewOfflineTransaction(exportTx, address, purpose)
+		if err != nil {
+			return nil, err
+		}
+		transactions.RecordExportedTransaction(offlineTx)
+		return exportTx, nil
+	}
+	return transactor, nil
+
+}
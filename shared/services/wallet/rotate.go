@@ -0,0 +1,177 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// The suffix of the file a new node password is saved to while it's being changed
+const pendingPasswordSuffix string = ".new-password"
+
+// Re-encrypt every validator keystore with new random passwords and the node wallet store with the node password.
+// If a new node password is provided, it replaces the current one. Everything is prepared before any files are changed,
+// and if anything fails, every file and the node password are restored.
+// Returns the names of the keystores that were rotated, and the names of the ones that can't be rotated by the Smartnode.
+func (w *Wallet) RotateKeystorePasswords(newPassword string) ([]string, []string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, nil, errors.New("Wallet is not initialized")
+	}
+
+	// Get the current and new node passwords
+	currentPassword, err := w.pm.GetPassword()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not get wallet password: %w", err)
+	}
+	password := currentPassword
+	if newPassword != "" {
+		if err := w.pm.CheckPassword(newPassword); err != nil {
+			return nil, nil, err
+		}
+		password = newPassword
+	}
+
+	// Prepare the validator keystore updates
+	names := make([]string, 0, len(w.keystores))
+	for name := range w.keystores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rotated := []string{}
+	skipped := []string{}
+	updates := []keystore.FileUpdate{}
+	for _, name := range names {
+		ks, ok := w.keystores[name].(keystore.RotatableKeystore)
+		if !ok {
+			skipped = append(skipped, name)
+			continue
+		}
+		keystoreUpdates, err := ks.PrepareRotation()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Could not prepare the %s keystore: %w", name, err)
+		}
+		if len(keystoreUpdates) > 0 {
+			rotated = append(rotated, name)
+			updates = append(updates, keystoreUpdates...)
+		}
+	}
+
+	// Keys held by the Validator Client's Keymanager API and the remote signer are encrypted by them, not the Smartnode
+	if w.keymanager != nil {
+		skipped = append(skipped, KeymanagerKeystoreName)
+	}
+	if w.remoteSigner != nil {
+		skipped = append(skipped, RemoteSignerKeystoreName)
+	}
+
+	// Prepare the wallet store update
+	ws, err := w.reencryptStore(currentPassword, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	wsBytes, err := json.Marshal(ws)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not encode wallet: %w", err)
+	}
+	updates = append(updates, keystore.FileUpdate{Path: w.walletPath, Contents: wsBytes, Mode: FileMode})
+
+	// A password file is replaced along with the wallet so they always match. Other password sources can only be
+	// changed once the wallet has been replaced, so the new password is saved next to the wallet until then; if the
+	// change is interrupted, the wallet is unlocked with it the next time it's loaded.
+	passwordChanged := (password != currentPassword)
+	passwordFile := w.pm.GetPasswordFilePath()
+	if passwordChanged {
+		if passwordFile != "" {
+			updates = append(updates, keystore.FileUpdate{Path: passwordFile, Contents: []byte(password), Mode: passwords.FileMode})
+		} else if err := os.WriteFile(w.getPendingPasswordPath(), []byte(password), FileMode); err != nil {
+			return nil, nil, fmt.Errorf("Could not save the new node password: %w", err)
+		}
+	}
+
+	// Apply the updates
+	restore, err := keystore.ApplyFileUpdates(updates)
+	if err != nil {
+		w.removePendingPassword()
+		return nil, nil, fmt.Errorf("Could not re-encrypt the keystores, so the original files were restored: %w", err)
+	}
+
+	// Change the node password in its source, restoring the files if that fails
+	if passwordChanged && passwordFile == "" {
+		if err := w.pm.ChangePassword(password); err != nil {
+			err = fmt.Errorf("Could not change the node password, so the original keystores were restored: %w", err)
+			if restoreErr := restore(); restoreErr != nil {
+				return nil, nil, fmt.Errorf("%w\nRestoring the original files also failed, so the node wallet is now encrypted with the new password (saved in %s): %s", err, w.getPendingPasswordPath(), restoreErr.Error())
+			}
+			w.removePendingPassword()
+			return nil, nil, err
+		}
+		w.removePendingPassword()
+	}
+
+	// Use the new wallet store
+	w.ws = ws
+	w.validatorRestartRequired = true
+	return rotated, skipped, nil
+
+}
+
+// Get the path of the file a new node password is saved to while it's being changed
+func (w *Wallet) getPendingPasswordPath() string {
+	return w.walletPath + pendingPasswordSuffix
+}
+
+// Remove the saved new node password once it's no longer needed
+func (w *Wallet) removePendingPassword() {
+	if err := os.Remove(w.getPendingPasswordPath()); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't remove %s: %s\n", w.getPendingPasswordPath(), err.Error())
+	}
+}
+
+// Finish a node password change that was interrupted after the wallet was re-encrypted, using the saved new password.
+// Returns the decrypted seed, or nil if there wasn't an interrupted change.
+func (w *Wallet) recoverPendingPassword() []byte {
+	password, err := os.ReadFile(w.getPendingPasswordPath())
+	if err != nil {
+		return nil
+	}
+	seed, err := w.encryptor.Decrypt(w.ws.Crypto, string(password))
+	if err != nil {
+		return nil
+	}
+	if err := w.pm.ChangePassword(string(password)); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: the node wallet is encrypted with the new password in %s, but the node password couldn't be changed to it: %s\n", w.getPendingPasswordPath(), err.Error())
+		return seed
+	}
+	w.removePendingPassword()
+	return seed
+}
+
+// Create a copy of the wallet store with the seed and passphrase re-encrypted with a new password
+func (w *Wallet) reencryptStore(currentPassword string, password string) (*walletStore, error) {
+
+	ws := *w.ws
+	var err error
+	ws.Crypto, err = w.encryptor.Encrypt(w.seed, password)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encrypt wallet seed: %w", err)
+	}
+	if w.ws.PassphraseCrypto != nil {
+		passphrase, err := w.encryptor.Decrypt(w.ws.PassphraseCrypto, currentPassword)
+		if err != nil {
+			return nil, fmt.Errorf("Could not decrypt wallet passphrase: %w", err)
+		}
+		ws.PassphraseCrypto, err = w.encryptor.Encrypt(passphrase, password)
+		if err != nil {
+			return nil, fmt.Errorf("Could not encrypt wallet passphrase: %w", err)
+		}
+	}
+	return &ws, nil
+
+}
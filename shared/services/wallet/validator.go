@@ -96,10 +96,40 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 }
 
-// Stores a validator key into all of the wallet's keystores
+// Stores a validator key into all of the wallet's keystores, loading it into the Validator Client through the
+// Keymanager API if it's enabled.
+// If a remote signer is enabled, the key is only stored there and registered with the Validator Client's Keymanager API.
 func (w *Wallet) StoreValidatorKey(key *eth2types.BLSPrivateKey, path string) error {
 
+	// Keep the key off of the local disk if it's going to a remote signer
+	if w.remoteSigner != nil {
+		// The Validator Client's own config doesn't list the remote signer's keys, so it can only learn about them
+		// through its Keymanager API
+		if !w.remoteSigner.IsRegisteredWithValidatorClient() {
+			return errors.New("Web3Signer is enabled but the Keymanager API isn't, so your Validator Client would never load this key; please enable the Keymanager API in the Smartnode settings")
+		}
+		return w.remoteSigner.StoreValidatorKey(key, path)
+	}
+
+	// Load the key into the Validator Client directly if possible
+	managedClient := ""
+	if w.keymanager != nil {
+		if err := w.keymanager.StoreValidatorKey(key, path); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: couldn't load the validator key with the Keymanager API, falling back to the %s keystore: %s\n", w.keymanagerClient, err.Error())
+			w.validatorRestartRequired = true
+		} else {
+			managedClient = w.keymanagerClient
+		}
+	} else {
+		w.validatorRestartRequired = true
+	}
+
 	for name := range w.keystores {
+		// The Validator Client manages its own copy of keys imported through the Keymanager API
+		if name == managedClient {
+			continue
+		}
+
 		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
 		if err := w.keystores[name].StoreValidatorKey(key, path); err != nil {
 			return fmt.Errorf("Could not store %s validator key: %w", name, err)
@@ -111,7 +141,8 @@ func (w *Wallet) StoreValidatorKey(key *eth2types.BLSPrivateKey, path string) er
 
 }
 
-// Loads a validator key from the wallet's keystores
+// Loads a validator key from the wallet's keystores.
+// Keys that aren't stored locally, such as those held by a remote signer, are derived from the wallet's mnemonic instead.
 func (w *Wallet) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
 	errors := []string{}
@@ -126,13 +157,64 @@ func (w *Wallet) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSP
 		}
 	}
 
+	// Fall back to deriving the key from the mnemonic
+	if key, err := w.deriveValidatorKey(pubkey); err != nil {
+		errors = append(errors, err.Error())
+	} else if key != nil {
+		return key, nil
+	}
+
 	if len(errors) > 0 {
 		// If there were errors, return them
 		return nil, fmt.Errorf("encountered the following errors while trying to load the key for validator %s:\n%s", pubkey.Hex(), strings.Join(errors, "\n"))
 	} else {
 		// If there were no errors, the key just didn't exist
-		return nil, fmt.Errorf("couldn't find the key for validator %s in any of the wallet's keystores", pubkey.Hex())
+		return nil, fmt.Errorf("couldn't find the key for validator %s in any of the wallet's keystores or derive it from the wallet's mnemonic", pubkey.Hex())
+	}
+
+}
+
+// Derives a validator key from the mnemonic by searching the keys the wallet has created, returning nil if it isn't one of them
+func (w *Wallet) deriveValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, nil
+	}
+
+	// Search the most recent keys first
+	for index := w.ws.NextAccount; index > 0; index-- {
+		key, _, err := w.getValidatorPrivateKey(index - 1)
+		if err != nil {
+			return nil, fmt.Errorf("error deriving validator key %d: %w", index-1, err)
+		}
+		if bytes.Equal(pubkey.Bytes(), key.PublicKey().Marshal()) {
+			return key, nil
+		}
+	}
+	return nil, nil
+
+}
+
+// Get the derivation path of a validator key created by the wallet, or a blank string if it wasn't derived from the wallet's seed
+func (w *Wallet) GetValidatorKeyPath(pubkey types.ValidatorPubkey) (string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return "", errors.New("Wallet is not initialized")
+	}
+
+	// Search the keys the wallet has created
+	for index := uint(0); index < w.ws.NextAccount; index++ {
+		key, path, err := w.getValidatorPrivateKey(index)
+		if err != nil {
+			return "", fmt.Errorf("error deriving validator key %d: %w", index, err)
+		}
+		if bytes.Equal(pubkey.Bytes(), key.PublicKey().Marshal()) {
+			return path, nil
+		}
 	}
+	return "", nil
 
 }
 
@@ -141,6 +223,9 @@ func (w *Wallet) DeleteValidatorStores() error {
 
 	for name := range w.keystores {
 		keystorePath := w.keystores[name].GetKeystoreDir()
+		if keystorePath == "" {
+			continue
+		}
 		err := os.RemoveAll(keystorePath)
 		if err != nil {
 			return fmt.Errorf("error deleting validator directory for %s: %w", name, err)
@@ -209,11 +294,8 @@ func (w *Wallet) SaveValidatorKey(key ValidatorKey) error {
 	}
 
 	// Update keystores
-	for name := range w.keystores {
-		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
-		if err := w.keystores[name].StoreValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
-			return fmt.Errorf("could not store validator key %s in %s keystore: %w", key.PublicKey.Hex(), name, err)
-		}
+	if err := w.StoreValidatorKey(key.PrivateKey, key.DerivationPath); err != nil {
+		return fmt.Errorf("could not store validator key %s: %w", key.PublicKey.Hex(), err)
 	}
 
 	// Return
@@ -255,11 +337,8 @@ func (w *Wallet) RecoverValidatorKey(pubkey rptypes.ValidatorPubkey, startIndex
 	}
 
 	// Update keystores
-	for name := range w.keystores {
-		// Update the keystore in the wallet - using an iterator variable only runs it on the local copy
-		if err := w.keystores[name].StoreValidatorKey(validatorKey, derivationPath); err != nil {
-			return 0, fmt.Errorf("Could not store %s validator key: %w", name, err)
-		}
+	if err := w.StoreValidatorKey(validatorKey, derivationPath); err != nil {
+		return 0, err
 	}
 
 	// Return
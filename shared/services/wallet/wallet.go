@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
@@ -19,7 +22,10 @@ import (
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/web3signer"
 )
 
 // Config
@@ -57,10 +63,41 @@ type Wallet struct {
 	// Keystores
 	keystores map[string]keystore.Keystore
 
+	// Keymanager API backend for the active Validator Client
+	keymanager               *keymanager.Keystore
+	keymanagerClient         string
+	validatorRestartRequired bool
+
+	// Remote signer that holds new validator keys instead of the local keystores
+	remoteSigner *web3signer.Keystore
+
 	// Desired gas price & limit from config
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Transaction journal
+	journal   *transactions.Journal
+	txPurpose string
+
+	// Transactions that have been signed for submission but not sent yet, by hash
+	unsentTxs     map[common.Hash]unsentTransaction
+	unsentTxsLock sync.Mutex
+
+	// Shared nonce allocator
+	nonceManager *transactions.NonceManager
+
+	// Deferred transaction scheduling
+	scheduler        *transactions.Scheduler
+	scheduleDeadline time.Time
+
+	// External signer that holds the node account's key instead of the wallet
+	externalSigner *ExternalSigner
+
+	// Offline signing
+	watchOnlyAddress *common.Address
+	exportUnsigned   bool
+	nextExportNonce  *uint64
 }
 
 // Encrypted wallet store
@@ -72,6 +109,9 @@ type walletStore struct {
 	DerivationPath string                 `json:"derivationPath,omitempty"`
 	WalletIndex    uint                   `json:"walletIndex,omitempty"`
 	NextAccount    uint                   `json:"next_account"`
+
+	// The BIP-39 passphrase the seed was derived with, encrypted like the seed
+	PassphraseCrypto map[string]interface{} `json:"passphraseCrypto,omitempty"`
 }
 
 // Create new wallet
@@ -85,6 +125,7 @@ func NewWallet(walletPath string, chainId uint, maxFee *big.Int, maxPriorityFee
 		chainID:        big.NewInt(int64(chainId)),
 		validatorKeys:  map[uint]*eth2types.BLSPrivateKey{},
 		keystores:      map[string]keystore.Keystore{},
+		unsentTxs:      map[common.Hash]unsentTransaction{},
 		maxFee:         maxFee,
 		maxPriorityFee: maxPriorityFee,
 		gasLimit:       gasLimit,
@@ -111,6 +152,89 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Load validator keys into the active Validator Client through its Keymanager API instead of the file-based keystore
+// with the given name. The file-based keystore is still used if the Keymanager API can't be reached.
+func (w *Wallet) SetKeymanager(ks *keymanager.Keystore, clientName string) {
+	w.keymanager = ks
+	w.keymanagerClient = clientName
+}
+
+// Get the Keymanager API backend, or nil if it isn't enabled
+func (w *Wallet) GetKeymanager() *keymanager.Keystore {
+	return w.keymanager
+}
+
+// Store new validator keys in a Web3Signer instance instead of the wallet's keystores.
+// Keys that are already in the keystores can still be loaded from them.
+func (w *Wallet) SetRemoteSigner(ks *web3signer.Keystore) {
+	w.remoteSigner = ks
+}
+
+// Get the remote signer, or nil if it isn't enabled
+func (w *Wallet) GetRemoteSigner() *web3signer.Keystore {
+	return w.remoteSigner
+}
+
+// Have an external signer sign everything for the node account instead of the wallet's node key
+func (w *Wallet) SetExternalSigner(signer *ExternalSigner) {
+	w.externalSigner = signer
+}
+
+// Get the external signer for the node account, or nil if it isn't enabled
+func (w *Wallet) GetExternalSigner() *ExternalSigner {
+	return w.externalSigner
+}
+
+// Check if any validator keys were stored that the Validator Client will only load after a restart
+func (w *Wallet) IsValidatorRestartRequired() bool {
+	return w.validatorRestartRequired
+}
+
+// Set the journal that records every transaction sent by the node account
+func (w *Wallet) SetTransactionJournal(journal *transactions.Journal) {
+	w.journal = journal
+}
+
+// Get the journal that records every transaction sent by the node account
+func (w *Wallet) GetTransactionJournal() *transactions.Journal {
+	return w.journal
+}
+
+// Set the purpose recorded in the journal for transactions that aren't given one of their own, such as the command being run
+func (w *Wallet) SetDefaultTransactionPurpose(purpose string) {
+	w.txPurpose = purpose
+}
+
+// Set the nonce manager that allocates nonces for transactions sent by the node account
+func (w *Wallet) SetNonceManager(nonceManager *transactions.NonceManager) {
+	w.nonceManager = nonceManager
+}
+
+// Get the nonce manager that allocates nonces for transactions sent by the node account
+func (w *Wallet) GetNonceManager() *transactions.NonceManager {
+	return w.nonceManager
+}
+
+// Set the scheduler that holds deferred transactions until the network fees are low enough
+func (w *Wallet) SetTransactionScheduler(scheduler *transactions.Scheduler) {
+	w.scheduler = scheduler
+}
+
+// Get the scheduler that holds deferred transactions until the network fees are low enough
+func (w *Wallet) GetTransactionScheduler() *transactions.Scheduler {
+	return w.scheduler
+}
+
+// Add transactions created by subsequent transactors to the scheduler instead of submitting them.
+// They will be submitted by the node daemon once the base fee is low enough for their max fee, or once the deadline passes.
+func (w *Wallet) ScheduleTransactions(deadline time.Time) error {
+	if w.scheduler == nil {
+		return errors.New("the transaction scheduler is not available")
+	}
+	w.scheduleDeadline = deadline
+	return nil
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -124,6 +248,37 @@ func (w *Wallet) GetInitialized() (bool, error) {
 	return w.loadStore()
 }
 
+// Check if the wallet's seed was derived with a BIP-39 passphrase
+func (w *Wallet) HasPassphrase() bool {
+	return w.ws != nil && w.ws.PassphraseCrypto != nil
+}
+
+// Get the BIP-39 passphrase the wallet's seed was derived with, or an empty string if it doesn't have one
+func (w *Wallet) GetPassphrase() (string, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return "", errors.New("Wallet is not initialized")
+	}
+	if !w.HasPassphrase() {
+		return "", nil
+	}
+
+	// Get wallet password
+	password, err := w.pm.GetPassword()
+	if err != nil {
+		return "", fmt.Errorf("Could not get wallet password: %w", err)
+	}
+
+	// Decrypt passphrase
+	passphrase, err := w.encryptor.Decrypt(w.ws.PassphraseCrypto, password)
+	if err != nil {
+		return "", fmt.Errorf("Could not decrypt wallet passphrase: %w", err)
+	}
+	return string(passphrase), nil
+
+}
+
 // Serialize the wallet to a JSON string
 func (w *Wallet) String() (string, error) {
 
@@ -144,7 +299,7 @@ func (w *Wallet) String() (string, error) {
 }
 
 // Initialize the wallet from a random seed
-func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, error) {
+func (w *Wallet) Initialize(derivationPath string, walletIndex uint, passphrase string) (string, error) {
 
 	// Check wallet is not initialized
 	if w.IsInitialized() {
@@ -164,7 +319,7 @@ func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, er
 	}
 
 	// Initialize wallet store
-	if err := w.initializeStore(derivationPath, walletIndex, mnemonic); err != nil {
+	if err := w.initializeStore(derivationPath, walletIndex, mnemonic, passphrase); err != nil {
 		return "", err
 	}
 
@@ -174,7 +329,7 @@ func (w *Wallet) Initialize(derivationPath string, walletIndex uint) (string, er
 }
 
 // Recover a wallet from a mnemonic
-func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic string) error {
+func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic string, passphrase string) error {
 
 	// Check wallet is not initialized
 	if w.IsInitialized() {
@@ -187,7 +342,7 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 	}
 
 	// Initialize wallet store
-	if err := w.initializeStore(derivationPath, walletIndex, mnemonic); err != nil {
+	if err := w.initializeStore(derivationPath, walletIndex, mnemonic, passphrase); err != nil {
 		return err
 	}
 
@@ -197,15 +352,23 @@ func (w *Wallet) Recover(derivationPath string, walletIndex uint, mnemonic strin
 }
 
 // Recover a wallet from a mnemonic - only used for testing mnemonics
-func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic string) error {
+func (w *Wallet) TestRecovery(derivationPath string, walletIndex uint, mnemonic string, passphrase string) error {
 
 	// Check mnemonic
 	if !bip39.IsMnemonicValid(mnemonic) {
 		return fmt.Errorf("Invalid mnemonic '%s'", mnemonic)
 	}
 
-	// Generate seed
-	w.seed = bip39.NewSeed(mnemonic, "")
+	// Generate seed & create the unencrypted wallet store
+	return w.testRecoveryFromSeed(derivationPath, walletIndex, bip39.NewSeed(mnemonic, passphrase))
+
+}
+
+// Recover a wallet from a seed without encrypting it - only used for testing recovery
+func (w *Wallet) testRecoveryFromSeed(derivationPath string, walletIndex uint, seed []byte) error {
+
+	// Set seed
+	w.seed = seed
 
 	// Create master key
 	var err error
@@ -272,22 +435,15 @@ func (w *Wallet) Delete() error {
 
 // Signs a serialized TX using the wallet's private key
 func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
-	// Get private key
-	privateKey, _, err := w.getNodePrivateKey()
-	if err != nil {
-		return nil, err
-	}
-
 	tx := types.Transaction{}
-	err = tx.UnmarshalBinary(serializedTx)
+	err := tx.UnmarshalBinary(serializedTx)
 	if err != nil {
 		return nil, fmt.Errorf("Error unmarshalling TX: %w", err)
 	}
 
-	signer := types.NewLondonSigner(w.chainID)
-	signedTx, err := types.SignTx(&tx, signer, privateKey)
+	signedTx, err := w.SignNodeTransaction(&tx)
 	if err != nil {
-		return nil, fmt.Errorf("Error signing TX: %w", err)
+		return nil, err
 	}
 
 	signedData, err := signedTx.MarshalBinary()
@@ -300,6 +456,11 @@ func (w *Wallet) Sign(serializedTx []byte) ([]byte, error) {
 
 // Signs an arbitrary message using the wallet's private key
 func (w *Wallet) SignMessage(message string) ([]byte, error) {
+	// Use the external signer if there is one
+	if w.externalSigner != nil {
+		return w.externalSigner.SignText([]byte(message))
+	}
+
 	// Get the wallet's private key
 	privateKey, _, err := w.getNodePrivateKey()
 	if err != nil {
@@ -349,10 +510,12 @@ func (w *Wallet) loadStore() (bool, error) {
 		return false, fmt.Errorf("Could not get wallet password: %w", err)
 	}
 
-	// Decrypt seed
+	// Decrypt seed, finishing an interrupted password change if there was one
 	w.seed, err = w.encryptor.Decrypt(w.ws.Crypto, password)
 	if err != nil {
-		return false, fmt.Errorf("Could not decrypt wallet seed: %w", err)
+		if w.seed = w.recoverPendingPassword(); w.seed == nil {
+			return false, fmt.Errorf("Could not decrypt wallet seed: %w", err)
+		}
 	}
 
 	// Create master key
@@ -367,10 +530,15 @@ func (w *Wallet) loadStore() (bool, error) {
 }
 
 // Initialize the encrypted wallet store from a mnemonic
-func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string) error {
+func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemonic string, passphrase string) error {
+	return w.initializeStoreFromSeed(derivationPath, walletIndex, bip39.NewSeed(mnemonic, passphrase), passphrase)
+}
+
+// Initialize the encrypted wallet store from a seed, along with the passphrase it was derived with (if known)
+func (w *Wallet) initializeStoreFromSeed(derivationPath string, walletIndex uint, seed []byte, passphrase string) error {
 
-	// Generate seed
-	w.seed = bip39.NewSeed(mnemonic, "")
+	// Set seed
+	w.seed = seed
 
 	// Create master key
 	var err error
@@ -391,15 +559,25 @@ func (w *Wallet) initializeStore(derivationPath string, walletIndex uint, mnemon
 		return fmt.Errorf("Could not encrypt wallet seed: %w", err)
 	}
 
+	// Encrypt passphrase
+	var encryptedPassphrase map[string]interface{}
+	if passphrase != "" {
+		encryptedPassphrase, err = w.encryptor.Encrypt([]byte(passphrase), password)
+		if err != nil {
+			return fmt.Errorf("Could not encrypt wallet passphrase: %w", err)
+		}
+	}
+
 	// Create wallet store
 	w.ws = &walletStore{
-		Crypto:         encryptedSeed,
-		Name:           w.encryptor.Name(),
-		Version:        w.encryptor.Version(),
-		UUID:           uuid.New(),
-		DerivationPath: derivationPath,
-		WalletIndex:    walletIndex,
-		NextAccount:    0,
+		Crypto:           encryptedSeed,
+		Name:             w.encryptor.Name(),
+		Version:          w.encryptor.Version(),
+		UUID:             uuid.New(),
+		DerivationPath:   derivationPath,
+		WalletIndex:      walletIndex,
+		NextAccount:      0,
+		PassphraseCrypto: encryptedPassphrase,
 	}
 
 	// Return
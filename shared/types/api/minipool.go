@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/migrations"
 )
 
 type MinipoolStatusResponse struct {
@@ -102,6 +103,19 @@ type ExitMinipoolResponse struct {
 	Error  string `json:"error"`
 }
 
+type PresignExitResponse struct {
+	Status          string                   `json:"status"`
+	Error           string                   `json:"error"`
+	ValidatorPubkey types.ValidatorPubkey    `json:"validatorPubkey"`
+	ValidatorIndex  uint64                   `json:"validatorIndex"`
+	Epoch           uint64                   `json:"epoch"`
+	Signature       types.ValidatorSignature `json:"signature"`
+}
+type BroadcastExitResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type CanChangeWithdrawalCredentialsResponse struct {
 	Status    string `json:"status"`
 	Error     string `json:"error"`
@@ -112,11 +126,71 @@ type ChangeWithdrawalCredentialsResponse struct {
 	Error  string `json:"error"`
 }
 
-type ImportKeyResponse struct {
+type WithdrawalCredsChange struct {
+	ValidatorIndex     uint64                   `json:"validatorIndex"`
+	ValidatorPubkey    types.ValidatorPubkey    `json:"validatorPubkey"`
+	FromBLSPubkey      types.ValidatorPubkey    `json:"fromBlsPubkey"`
+	ToExecutionAddress common.Address           `json:"toExecutionAddress"`
+	Signature          types.ValidatorSignature `json:"signature"`
+	Error              string                   `json:"error"`
+}
+type SignWithdrawalCredsChangesResponse struct {
+	Status  string                  `json:"status"`
+	Error   string                  `json:"error"`
+	Changes []WithdrawalCredsChange `json:"changes"`
+}
+type CanSubmitWithdrawalCredsChangeResponse struct {
+	Status          string                `json:"status"`
+	Error           string                `json:"error"`
+	CanSubmit       bool                  `json:"canSubmit"`
+	ValidatorPubkey types.ValidatorPubkey `json:"validatorPubkey"`
+}
+type SubmitWithdrawalCredsChangeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
 
+type SelectMinipoolsResponse struct {
+	Status    string           `json:"status"`
+	Error     string           `json:"error"`
+	Minipools []common.Address `json:"minipools"`
+}
+
+type SoloMigrationDetails struct {
+	Pubkey                types.ValidatorPubkey         `json:"pubkey"`
+	MinipoolAddress       common.Address                `json:"minipoolAddress"`
+	ImportKey             bool                          `json:"importKey"`
+	KeyImported           bool                          `json:"keyImported"`
+	Stage                 migrations.SoloMigrationStage `json:"stage"`
+	StageTime             time.Time                     `json:"stageTime"`
+	StartedTime           time.Time                     `json:"startedTime"`
+	ValidatorIndex        uint64                        `json:"validatorIndex"`
+	ValidatorStatus       beacon.ValidatorState         `json:"validatorStatus"`
+	WithdrawalCredentials common.Hash                   `json:"withdrawalCredentials"`
+	CredsDeadline         time.Time                     `json:"credsDeadline"`
+	PromotionTime         time.Time                     `json:"promotionTime"`
+	Blockers              []string                      `json:"blockers"`
+}
+type SoloMigrationsResponse struct {
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error"`
+	Migrations []SoloMigrationDetails `json:"migrations"`
+}
+type StartSoloMigrationResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+type ForgetSoloMigrationResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type ImportKeyResponse struct {
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	RestartRequired bool   `json:"restartRequired"`
+}
+
 type CanProcessWithdrawalResponse struct {
 	Status        string             `json:"status"`
 	Error         string             `json:"error"`
@@ -331,3 +405,101 @@ type ReduceBondAmountResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type BondReductionPlanMinipool struct {
+	Address              common.Address        `json:"address"`
+	CurrentBond          *big.Int              `json:"currentBond"`
+	CurrentFee           float64               `json:"currentFee"`
+	NewFee               float64               `json:"newFee"`
+	BeaconBalance        uint64                `json:"beaconBalance"`
+	BeaconState          beacon.ValidatorState `json:"beaconState"`
+	CanReduce            bool                  `json:"canReduce"`
+	Reasons              []string              `json:"reasons"`
+	Included             bool                  `json:"included"`
+	ExcludedByCollateral bool                  `json:"excludedByCollateral"`
+	Order                int                   `json:"order"`
+	GasInfo              rocketpool.GasInfo    `json:"gasInfo"`
+}
+type PlanBondReductionResponse struct {
+	Status                 string                      `json:"status"`
+	Error                  string                      `json:"error"`
+	BondReductionDisabled  bool                        `json:"bondReductionDisabled"`
+	NewBond                *big.Int                    `json:"newBond"`
+	NetworkNodeFee         float64                     `json:"networkNodeFee"`
+	WindowStart            time.Duration               `json:"windowStart"`
+	WindowLength           time.Duration               `json:"windowLength"`
+	RplPrice               *big.Int                    `json:"rplPrice"`
+	RplStake               *big.Int                    `json:"rplStake"`
+	CurrentBorrowedEth     *big.Int                    `json:"currentBorrowedEth"`
+	NewBorrowedEth         *big.Int                    `json:"newBorrowedEth"`
+	CurrentBondedEth       *big.Int                    `json:"currentBondedEth"`
+	NewBondedEth           *big.Int                    `json:"newBondedEth"`
+	CurrentCollateralRatio float64                     `json:"currentCollateralRatio"`
+	NewCollateralRatio     float64                     `json:"newCollateralRatio"`
+	CurrentEffectiveStake  *big.Int                    `json:"currentEffectiveStake"`
+	NewEffectiveStake      *big.Int                    `json:"newEffectiveStake"`
+	EthMatched             *big.Int                    `json:"ethMatched"`
+	EthMatchedLimit        *big.Int                    `json:"ethMatchedLimit"`
+	PendingMatchAmount     *big.Int                    `json:"pendingMatchAmount"`
+	CurrentCreditBalance   *big.Int                    `json:"currentCreditBalance"`
+	NewCreditBalance       *big.Int                    `json:"newCreditBalance"`
+	Minipools              []BondReductionPlanMinipool `json:"minipools"`
+}
+
+type MinipoolTimelineEvent struct {
+	Time        time.Time         `json:"time"`
+	Source      string            `json:"source"`
+	Contract    string            `json:"contract"`
+	Event       string            `json:"event"`
+	BlockNumber uint64            `json:"blockNumber"`
+	TxHash      common.Hash       `json:"txHash"`
+	LogIndex    uint              `json:"logIndex"`
+	Epoch       uint64            `json:"epoch"`
+	Values      map[string]string `json:"values"`
+}
+type MinipoolTimelineResponse struct {
+	Status          string                  `json:"status"`
+	Error           string                  `json:"error"`
+	MinipoolAddress common.Address          `json:"minipoolAddress"`
+	Pubkey          types.ValidatorPubkey   `json:"pubkey"`
+	StartBlock      uint64                  `json:"startBlock"`
+	EndBlock        uint64                  `json:"endBlock"`
+	Events          []MinipoolTimelineEvent `json:"events"`
+}
+
+type MinipoolIncomeStatement struct {
+	Address                common.Address        `json:"address"`
+	Pubkey                 types.ValidatorPubkey `json:"pubkey"`
+	Active                 bool                  `json:"active"`
+	StartTime              time.Time             `json:"startTime"`
+	EndTime                time.Time             `json:"endTime"`
+	NodeBond               *big.Int              `json:"nodeBond"`
+	NodeFee                float64               `json:"nodeFee"`
+	StartBeaconBalance     *big.Int              `json:"startBeaconBalance"`
+	EndBeaconBalance       *big.Int              `json:"endBeaconBalance"`
+	Withdrawals            *big.Int              `json:"withdrawals"`
+	Distributed            *big.Int              `json:"distributed"`
+	ConsensusRewards       *big.Int              `json:"consensusRewards"`
+	ConsensusNodeShare     *big.Int              `json:"consensusNodeShare"`
+	ConsensusCommission    *big.Int              `json:"consensusCommission"`
+	Penalties              *big.Int              `json:"penalties"`
+	ExecutionRewards       *big.Int              `json:"executionRewards"`
+	ExecutionNodeShare     *big.Int              `json:"executionNodeShare"`
+	ExecutionCommission    *big.Int              `json:"executionCommission"`
+	SmoothingPoolNodeShare *big.Int              `json:"smoothingPoolNodeShare"`
+	SmoothingPoolEstimated bool                  `json:"smoothingPoolEstimated"`
+	TotalNodeShare         *big.Int              `json:"totalNodeShare"`
+}
+type MinipoolIncomeStatementResponse struct {
+	Status                 string                    `json:"status"`
+	Error                  string                    `json:"error"`
+	StartTime              time.Time                 `json:"startTime"`
+	EndTime                time.Time                 `json:"endTime"`
+	StartBlock             uint64                    `json:"startBlock"`
+	EndBlock               uint64                    `json:"endBlock"`
+	FeeDistributorAddress  common.Address            `json:"feeDistributorAddress"`
+	FeeDistributorIncome   *big.Int                  `json:"feeDistributorIncome"`
+	SmoothingPoolIntervals []uint64                  `json:"smoothingPoolIntervals"`
+	MissingIntervals       []uint64                  `json:"missingIntervals"`
+	Minipools              []MinipoolIncomeStatement `json:"minipools"`
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -126,6 +127,14 @@ type SetNodeTimezoneResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type SetValidatorSettingsResponse struct {
+	Status           string  `json:"status"`
+	Error            string  `json:"error"`
+	Graffiti         *string `json:"graffiti"`
+	GasLimit         *uint64 `json:"gasLimit"`
+	KeymanagerActive bool    `json:"keymanagerActive"`
+}
+
 type CanNodeSwapRplResponse struct {
 	Status              string             `json:"status"`
 	Error               string             `json:"error"`
@@ -515,3 +524,20 @@ type NodeEthBalanceResponse struct {
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type NodeTransactionsResponse struct {
+	Status       string                            `json:"status"`
+	Error        string                            `json:"error"`
+	Transactions []*transactions.TransactionRecord `json:"transactions"`
+}
+
+type NodeScheduledTransactionsResponse struct {
+	Status       string                               `json:"status"`
+	Error        string                               `json:"error"`
+	Transactions []*transactions.ScheduledTransaction `json:"transactions"`
+}
+
+type CancelScheduledTransactionResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
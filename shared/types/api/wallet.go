@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -22,6 +23,9 @@ type WalletStatusResponse struct {
 	Error             string         `json:"error"`
 	PasswordSet       bool           `json:"passwordSet"`
 	WalletInitialized bool           `json:"walletInitialized"`
+	HasPassphrase     bool           `json:"hasPassphrase"`
+	WatchOnly         bool           `json:"watchOnly"`
+	ExternalSignerUrl string         `json:"externalSignerUrl"`
 	AccountAddress    common.Address `json:"accountAddress"`
 }
 
@@ -68,6 +72,70 @@ type ExportWalletResponse struct {
 	AccountPrivateKey string `json:"accountPrivateKey"`
 }
 
+type BackupWalletResponse struct {
+	Status    string   `json:"status"`
+	Error     string   `json:"error"`
+	Shares    []string `json:"shares"`
+	Threshold int      `json:"threshold"`
+}
+
+type ExportValidatorKeysResponse struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error"`
+	Keys   []ExportedValidatorKey `json:"keys"`
+}
+type ExportedValidatorKey struct {
+	Pubkey                types.ValidatorPubkey `json:"pubkey"`
+	Keystore              ValidatorKeystore     `json:"keystore"`
+	DerivationPath        string                `json:"derivationPath"`
+	MinipoolAddress       common.Address        `json:"minipoolAddress"`
+	WithdrawalCredentials common.Hash           `json:"withdrawalCredentials"`
+}
+
+// The outcome of auditing a single validator key
+type KeyAuditFinding string
+
+const (
+	KeyAuditFinding_Ok       KeyAuditFinding = "ok"
+	KeyAuditFinding_Missing  KeyAuditFinding = "missing"
+	KeyAuditFinding_Orphaned KeyAuditFinding = "orphaned"
+	KeyAuditFinding_Exited   KeyAuditFinding = "exited"
+	KeyAuditFinding_Unused   KeyAuditFinding = "unused"
+)
+
+type AuditValidatorKeysResponse struct {
+	Status         string              `json:"status"`
+	Error          string              `json:"error"`
+	Keys           []ValidatorKeyAudit `json:"keys"`
+	Keystores      []string            `json:"keystores"`
+	KeystoreErrors map[string]string   `json:"keystoreErrors"`
+	WalletKeyCount uint                `json:"walletKeyCount"`
+	NextSafeIndex  uint                `json:"nextSafeIndex"`
+}
+type ValidatorKeyAudit struct {
+	Pubkey             types.ValidatorPubkey `json:"pubkey"`
+	Finding            KeyAuditFinding       `json:"finding"`
+	HasDerivationIndex bool                  `json:"hasDerivationIndex"`
+	DerivationIndex    uint                  `json:"derivationIndex"`
+	Keystores          []string              `json:"keystores"`
+	HasMinipool        bool                  `json:"hasMinipool"`
+	MinipoolAddress    common.Address        `json:"minipoolAddress"`
+	MinipoolStatus     types.MinipoolStatus  `json:"minipoolStatus"`
+	MinipoolFinalised  bool                  `json:"minipoolFinalised"`
+	ValidatorExists    bool                  `json:"validatorExists"`
+	ValidatorIndex     uint64                `json:"validatorIndex"`
+	ValidatorStatus    string                `json:"validatorStatus"`
+}
+
+type RotateKeystorePasswordsResponse struct {
+	Status           string   `json:"status"`
+	Error            string   `json:"error"`
+	Keystores        []string `json:"keystores"`
+	SkippedKeystores []string `json:"skippedKeystores"`
+	PasswordChanged  bool     `json:"passwordChanged"`
+	RestartRequired  bool     `json:"restartRequired"`
+}
+
 type SetEnsNameResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -88,3 +156,20 @@ type PurgeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type SetWatchOnlyAddressResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type SignTransactionResponse struct {
+	Status   string        `json:"status"`
+	Error    string        `json:"error"`
+	SignedTx hexutil.Bytes `json:"signedTx"`
+}
+
+type BroadcastTransactionResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
@@ -10,6 +10,8 @@ type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type GasOracle string
+type PasswordSource string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -25,6 +27,7 @@ const (
 	ContainerID_Prometheus ContainerID = "prometheus"
 	ContainerID_Exporter   ContainerID = "exporter"
 	ContainerID_MevBoost   ContainerID = "mev-boost"
+	ContainerID_Web3Signer ContainerID = "web3signer"
 )
 
 // Enum to describe which network the system is on
@@ -83,6 +86,24 @@ const (
 	RewardsMode_Generate RewardsMode = "generate"
 )
 
+// Enum to describe the sources of gas price suggestions
+const (
+	GasOracle_Unknown    GasOracle = ""
+	GasOracle_Etherchain GasOracle = "etherchain"
+	GasOracle_FeeHistory GasOracle = "feeHistory"
+)
+
+// Enum to describe where the node password comes from
+const (
+	PasswordSource_Unknown           PasswordSource = ""
+	PasswordSource_File              PasswordSource = "file"
+	PasswordSource_FileDescriptor    PasswordSource = "fd"
+	PasswordSource_Environment       PasswordSource = "env"
+	PasswordSource_SystemdCredential PasswordSource = "systemdCredential"
+	PasswordSource_Command           PasswordSource = "command"
+	PasswordSource_Vault             PasswordSource = "vault"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
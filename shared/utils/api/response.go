@@ -6,9 +6,17 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/transactions"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
+// The keys used to attach transaction simulation results and exported unsigned transactions to an API response
+const (
+	SimulationsKey          string = "simulations"
+	UnsignedTransactionsKey string = "unsignedTransactions"
+)
+
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
@@ -53,6 +61,24 @@ func PrintResponse(response interface{}, responseError error) {
 		return
 	}
 
+	// Attach the results of any transaction simulations
+	if simulations := services.TakeSimulationResults(); len(simulations) > 0 && ef.String() == "" {
+		responseBytes, err = addField(responseBytes, SimulationsKey, simulations)
+		if err != nil {
+			PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+			return
+		}
+	}
+
+	// Attach any unsigned transactions that were exported for offline signing
+	if unsignedTxs := transactions.GetExportedTransactions(); len(unsignedTxs) > 0 && ef.String() == "" {
+		responseBytes, err = addField(responseBytes, UnsignedTransactionsKey, unsignedTxs)
+		if err != nil {
+			PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
+			return
+		}
+	}
+
 	// Print
 	fmt.Println(string(responseBytes))
 
@@ -62,3 +88,17 @@ func PrintResponse(response interface{}, responseError error) {
 func PrintErrorResponse(err error) {
 	PrintResponse(&api.APIResponse{}, err)
 }
+
+// Add a field to an encoded API response
+func addField(responseBytes []byte, key string, value interface{}) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(responseBytes, &fields); err != nil {
+		return nil, err
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields[key] = valueBytes
+	return json.Marshal(fields)
+}
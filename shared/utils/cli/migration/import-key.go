@@ -33,13 +33,19 @@ func ImportKey(c *cli.Context, rp *rocketpool.Client, minipoolAddress common.Add
 
 	// Import the key
 	fmt.Printf("Importing validator key... ")
-	_, err := rp.ImportKey(minipoolAddress, mnemonic)
+	response, err := rp.ImportKey(minipoolAddress, mnemonic)
 	if err != nil {
 		fmt.Printf("error importing validator key: %s\n", err.Error())
 		return false
 	}
 	fmt.Println("done!")
 
+	// The Validator Client already loaded the key if it was imported through the Keymanager API
+	if !response.RestartRequired {
+		fmt.Println("Your Validator Client loaded the key through its Keymanager API, so it doesn't need to be restarted.")
+		return true
+	}
+
 	// Restart the VC if necessary
 	if c.Bool("no-restart") {
 		return true
@@ -32,6 +32,23 @@ func PrintTransactionHashNoCancel(rp *rocketpool.Client, hash common.Hash) {
 
 }
 
+// Print the files the unsigned transactions from the last API call were exported to, if it exported any.
+// Returns true if transactions were exported, in which case there's nothing to wait for.
+func PrintExportedTransactions(rp *rocketpool.Client) bool {
+
+	files := rp.TakeExportedTransactions()
+	if len(files) == 0 {
+		return false
+	}
+	fmt.Println("The transaction was exported for offline signing instead of being submitted:")
+	for _, file := range files {
+		fmt.Printf("\t%s\n", file)
+	}
+	fmt.Println("\nSign it on your offline machine with `rocketpool wallet sign-tx`, then submit the signed transaction from this machine with `rocketpool wallet broadcast`.")
+	return true
+
+}
+
 // Print a warning to the console if the user set a custom nonce, but this operation involves multiple transactions
 func PrintMultiTransactionNonceWarning() {
 
@@ -45,6 +62,12 @@ func PrintMultiTransactionNonceWarning() {
 // Implementation of PrintTransactionHash and PrintTransactionHashNoCancel
 func printTransactionHashImpl(rp *rocketpool.Client, hash common.Hash, finalMessage string) {
 
+	// Exported transactions haven't been submitted, so show where they were saved instead
+	if rp.IsExportedTransaction(hash) {
+		PrintExportedTransactions(rp)
+		return
+	}
+
 	cfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		fmt.Printf("Warning: couldn't read config file so the transaction URL will be unavailable (%s).\n", err)
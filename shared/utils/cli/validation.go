@@ -14,12 +14,15 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/shamir"
 )
 
 // Config
 const (
-	MinDAOMemberIDLength = 3
+	MinDAOMemberIDLength     = 3
+	ValidatorSignatureLength = 96
 )
 
 //
@@ -203,6 +206,18 @@ func ValidateWalletMnemonic(name, value string) (string, error) {
 	return value, nil
 }
 
+// Validate a comma-separated list of wallet backup shares
+func ValidateBackupShares(name, value string) ([]string, error) {
+	shares := strings.Split(value, ",")
+	for i, share := range shares {
+		if _, err := shamir.ParseShare(share); err != nil {
+			return nil, fmt.Errorf("Invalid %s: share %d is invalid: %w", name, i+1, err)
+		}
+		shares[i] = strings.TrimSpace(share)
+	}
+	return shares, nil
+}
+
 // Validate a timezone location
 func ValidateTimezoneLocation(name, value string) (string, error) {
 	if !regexp.MustCompile("^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$").MatchString(value) {
@@ -252,3 +267,90 @@ func ValidatePubkey(name, value string) (types.ValidatorPubkey, error) {
 	}
 	return pubkey, nil
 }
+
+// Validate a comma-separated list of validator pubkeys
+func ValidatePubkeys(name, value string) ([]types.ValidatorPubkey, error) {
+	pubkeys := []types.ValidatorPubkey{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		pubkey, err := ValidatePubkey(name, element)
+		if err != nil {
+			return nil, err
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	if len(pubkeys) == 0 {
+		return nil, fmt.Errorf("Invalid %s '%s': at least one pubkey is required.", name, value)
+	}
+	return pubkeys, nil
+}
+
+// Validate a comma-separated list of addresses
+func ValidateAddresses(name, value string) ([]common.Address, error) {
+	addresses := []common.Address{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		address, err := ValidateAddress(name, element)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("Invalid %s '%s': at least one address is required.", name, value)
+	}
+	return addresses, nil
+}
+
+// Validate a comma-separated list of unsigned integers
+func ValidateUints(name, value string) ([]uint64, error) {
+	values := []uint64{}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+		val, err := ValidateUint(name, element)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("Invalid %s '%s': at least one value is required.", name, value)
+	}
+	return values, nil
+}
+
+// Validate a minipool selector
+func ValidateMinipoolSelector(name, value string) (*state.MinipoolSelector, error) {
+	selector, err := state.ParseMinipoolSelector(value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s': %w", name, value, err)
+	}
+	return selector, nil
+}
+
+// Validate a validator signature
+func ValidateValidatorSignature(name, value string) (types.ValidatorSignature, error) {
+	bytes, err := hex.DecodeString(hexutils.RemovePrefix(value))
+	if err != nil || len(bytes) != ValidatorSignatureLength {
+		return types.ValidatorSignature{}, fmt.Errorf("Invalid %s '%s': it must be a %d-byte hex-encoded signature.", name, value, ValidatorSignatureLength)
+	}
+	return types.BytesToValidatorSignature(bytes), nil
+}
+
+// Validate a hex-encoded byte string
+func ValidateHexBytes(name, value string) ([]byte, error) {
+	bytes, err := hex.DecodeString(hexutils.RemovePrefix(value))
+	if err != nil || len(bytes) == 0 {
+		return nil, fmt.Errorf("Invalid %s '%s': it must be a hex-encoded byte string.", name, value)
+	}
+	return bytes, nil
+}
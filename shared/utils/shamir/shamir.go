@@ -0,0 +1,250 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Shamir's secret sharing over GF(2^8), using the AES field polynomial (x^8 + x^4 + x^3 + x + 1).
+// Each byte of the secret is the constant term of its own random polynomial of degree (threshold - 1), and each share holds
+// every polynomial evaluated at the share's index (1 to 255). Any `threshold` shares recover the secret through Lagrange
+// interpolation at x = 0; fewer reveal nothing about it.
+//
+// Shares are encoded as text in the form `rpshare-XXXX-XXXX-...`, where the groups are the unpadded base32 (RFC 4648)
+// encoding of the following bytes:
+//
+//	version (1) | set ID (4) | threshold (1) | index (1) | share data | checksum (4)
+//
+// The set ID is random, so shares from different splits can't be mixed up, and the checksum is the first 4 bytes of the
+// SHA-256 hash of everything before it, so transcription errors are caught before combining shares.
+
+const (
+	ShareVersion   byte   = 1
+	SharePrefix    string = "rpshare"
+	MaxShares      int    = 255
+	MinThreshold   int    = 2
+	headerLength   int    = 7
+	checksumLength int    = 4
+	groupLength    int    = 4
+)
+
+var shareEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Exponent and logarithm tables for GF(2^8), using 3 as the generator
+var (
+	expTable [510]byte
+	logTable [256]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		expTable[i] = x
+		expTable[i+255] = x
+		logTable[x] = byte(i)
+		x = x ^ xtime(x) // Multiply by 3
+	}
+}
+
+// A single share of a secret
+type Share struct {
+	SetID     uint32
+	Threshold byte
+	Index     byte
+	Data      []byte
+}
+
+// Split a secret into shares, any `threshold` of which can recover it
+func Split(secret []byte, shareCount int, threshold int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("the secret is empty")
+	}
+	if threshold < MinThreshold {
+		return nil, fmt.Errorf("the threshold must be at least %d", MinThreshold)
+	}
+	if shareCount < threshold {
+		return nil, fmt.Errorf("the number of shares (%d) can't be less than the threshold (%d)", shareCount, threshold)
+	}
+	if shareCount > MaxShares {
+		return nil, fmt.Errorf("the number of shares can't be more than %d", MaxShares)
+	}
+
+	// Get a random set ID
+	idBytes := make([]byte, 4)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("error generating share set ID: %w", err)
+	}
+	setID := binary.BigEndian.Uint32(idBytes)
+
+	// Create the shares
+	shares := make([]Share, shareCount)
+	for i := range shares {
+		shares[i] = Share{
+			SetID:     setID,
+			Threshold: byte(threshold),
+			Index:     byte(i + 1),
+			Data:      make([]byte, len(secret)),
+		}
+	}
+
+	// Create a random polynomial for each byte of the secret and evaluate it for each share
+	coefficients := make([]byte, threshold)
+	for i, secretByte := range secret {
+		coefficients[0] = secretByte
+		if _, err := rand.Read(coefficients[1:]); err != nil {
+			return nil, fmt.Errorf("error generating random polynomial: %w", err)
+		}
+		for j := range shares {
+			shares[j].Data[i] = evaluate(coefficients, shares[j].Index)
+		}
+	}
+	for i := range coefficients {
+		coefficients[i] = 0
+	}
+	return shares, nil
+}
+
+// Recover a secret from a set of shares
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no shares were provided")
+	}
+
+	// Make sure the shares belong together
+	first := shares[0]
+	seen := map[byte]bool{}
+	for _, share := range shares {
+		if share.SetID != first.SetID {
+			return nil, fmt.Errorf("share %d belongs to set %08x, but share %d belongs to set %08x", share.Index, share.SetID, first.Index, first.SetID)
+		}
+		if share.Threshold != first.Threshold || len(share.Data) != len(first.Data) {
+			return nil, fmt.Errorf("share %d doesn't match share %d", share.Index, first.Index)
+		}
+		if share.Index == 0 {
+			return nil, errors.New("share index 0 is invalid")
+		}
+		if seen[share.Index] {
+			return nil, fmt.Errorf("share %d was provided more than once", share.Index)
+		}
+		seen[share.Index] = true
+	}
+	threshold := int(first.Threshold)
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("%d shares are required, but only %d were provided", threshold, len(shares))
+	}
+	shares = shares[:threshold]
+
+	// Interpolate each byte of the secret at x = 0
+	secret := make([]byte, len(first.Data))
+	for i := range shares {
+		// Lagrange basis polynomial for this share, evaluated at 0; subtraction is XOR in GF(2^8)
+		basis := byte(1)
+		for j := range shares {
+			if i == j {
+				continue
+			}
+			basis = mul(basis, div(shares[j].Index, shares[i].Index^shares[j].Index))
+		}
+		for k := range secret {
+			secret[k] ^= mul(shares[i].Data[k], basis)
+		}
+	}
+	return secret, nil
+}
+
+// Encode the share as text
+func (s Share) String() string {
+	payload := make([]byte, 0, headerLength+len(s.Data)+checksumLength)
+	payload = append(payload, ShareVersion)
+	payload = binary.BigEndian.AppendUint32(payload, s.SetID)
+	payload = append(payload, s.Threshold, s.Index)
+	payload = append(payload, s.Data...)
+	payload = append(payload, checksum(payload)...)
+	encoded := shareEncoding.EncodeToString(payload)
+
+	groups := []string{SharePrefix}
+	for len(encoded) > groupLength {
+		groups = append(groups, encoded[:groupLength])
+		encoded = encoded[groupLength:]
+	}
+	groups = append(groups, encoded)
+	return strings.Join(groups, "-")
+}
+
+// Decode a share from text, ignoring case, whitespace and group separators
+func ParseShare(text string) (Share, error) {
+	text = strings.ToUpper(strings.Join(strings.Fields(text), ""))
+	text = strings.TrimPrefix(text, strings.ToUpper(SharePrefix))
+	text = strings.ReplaceAll(text, "-", "")
+	payload, err := shareEncoding.DecodeString(text)
+	if err != nil {
+		return Share{}, fmt.Errorf("the share is not valid base32: %w", err)
+	}
+	if len(payload) <= headerLength+checksumLength {
+		return Share{}, errors.New("the share is too short")
+	}
+
+	// Verify the checksum
+	body := payload[:len(payload)-checksumLength]
+	if !bytes.Equal(checksum(body), payload[len(body):]) {
+		return Share{}, errors.New("the share's checksum is invalid; please check it for typos")
+	}
+	if body[0] != ShareVersion {
+		return Share{}, fmt.Errorf("unsupported share version %d", body[0])
+	}
+	share := Share{
+		SetID:     binary.BigEndian.Uint32(body[1:5]),
+		Threshold: body[5],
+		Index:     body[6],
+		Data:      body[headerLength:],
+	}
+	if int(share.Threshold) < MinThreshold || share.Index == 0 {
+		return Share{}, errors.New("the share's header is invalid")
+	}
+	return share, nil
+}
+
+// Get the checksum of a share's contents
+func checksum(data []byte) []byte {
+	hash := sha256.Sum256(data)
+	return hash[:checksumLength]
+}
+
+// Evaluate a polynomial at x using Horner's method
+func evaluate(coefficients []byte, x byte) byte {
+	result := byte(0)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = mul(result, x) ^ coefficients[i]
+	}
+	return result
+}
+
+// Multiply by x in GF(2^8)
+func xtime(a byte) byte {
+	if a&0x80 != 0 {
+		return (a << 1) ^ 0x1b
+	}
+	return a << 1
+}
+
+// Multiply two elements of GF(2^8)
+func mul(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+// Divide two elements of GF(2^8); b must not be 0
+func div(a byte, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+255-int(logTable[b])]
+}
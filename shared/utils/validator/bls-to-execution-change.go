@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Config
+const (
+	blsPubkeyLength    int = 48
+	blsSignatureLength int = 96
+)
+
+// A signed BLS-to-execution change, in the format written by the staking deposit CLI's
+// `generate-bls-to-execution-change` command and accepted by the Beacon API
+type SignedBLSToExecutionChange struct {
+	Message   BLSToExecutionChange          `json:"message"`
+	Signature hexutil.Bytes                 `json:"signature"`
+	Metadata  *BLSToExecutionChangeMetadata `json:"metadata,omitempty"`
+}
+type BLSToExecutionChange struct {
+	ValidatorIndex     uint64         `json:"validator_index,string"`
+	FromBLSPubkey      hexutil.Bytes  `json:"from_bls_pubkey"`
+	ToExecutionAddress common.Address `json:"to_execution_address"`
+}
+type BLSToExecutionChangeMetadata struct {
+	NetworkName           string `json:"network_name"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	DepositCliVersion     string `json:"deposit_cli_version"`
+}
+
+// Load a file of signed BLS-to-execution changes
+func LoadBLSToExecutionChanges(path string) ([]SignedBLSToExecutionChange, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read BLS-to-execution changes from %s: %w", path, err)
+	}
+	var changes []SignedBLSToExecutionChange
+	if err := json.Unmarshal(bytes, &changes); err != nil {
+		return nil, fmt.Errorf("could not decode BLS-to-execution changes from %s: %w", path, err)
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%s doesn't contain any BLS-to-execution changes", path)
+	}
+	for i, change := range changes {
+		if err := change.check(); err != nil {
+			return nil, fmt.Errorf("change %d in %s is invalid: %w", i+1, path, err)
+		}
+	}
+	return changes, nil
+}
+
+// Get the withdrawal pubkey the change is from
+func (change *SignedBLSToExecutionChange) GetFromBLSPubkey() types.ValidatorPubkey {
+	return types.BytesToValidatorPubkey(change.Message.FromBLSPubkey)
+}
+
+// Get the change's signature
+func (change *SignedBLSToExecutionChange) GetSignature() types.ValidatorSignature {
+	return types.BytesToValidatorSignature(change.Signature)
+}
+
+// Check the lengths of the change's fields
+func (change *SignedBLSToExecutionChange) check() error {
+	if len(change.Message.FromBLSPubkey) != blsPubkeyLength {
+		return fmt.Errorf("from_bls_pubkey must be %d bytes", blsPubkeyLength)
+	}
+	if len(change.Signature) != blsSignatureLength {
+		return fmt.Errorf("signature must be %d bytes", blsSignatureLength)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/types/eth2"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	util "github.com/wealdtech/go-eth2-util"
 )
 
 // Get the withdrawal private key for a validator based on its mnemonic, index, and path
@@ -23,15 +25,67 @@ func GetWithdrawalKey(mnemonic string, index uint, validatorKeyPath string) (*et
 
 }
 
+// Get the 0x00 (BLS) withdrawal credentials for a withdrawal pubkey
+func GetBLSWithdrawalCredentials(withdrawalPubkey []byte) common.Hash {
+	withdrawalCreds := common.BytesToHash(util.SHA256(withdrawalPubkey)) // Withdrawal creds use sha256, *not* Keccak
+	withdrawalCreds[0] = 0x00                                            // BLS prefix
+	return withdrawalCreds
+}
+
 // Get a voluntary exit message signature for a given validator key and index
 func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKey, validatorIndex uint64, newWithdrawalAddress common.Address, signatureDomain []byte) (types.ValidatorSignature, error) {
 
-	// Get the withdrawal pubkey
-	withdrawalPubkey := withdrawalKey.PublicKey().Marshal()
-	withdrawalPubkeyBuffer := [48]byte{}
-	copy(withdrawalPubkeyBuffer[:], withdrawalPubkey)
+	// Get signing root
+	withdrawalPubkey := types.BytesToValidatorPubkey(withdrawalKey.PublicKey().Marshal())
+	srHash, err := getWithdrawalCredsChangeSigningRoot(validatorIndex, withdrawalPubkey, newWithdrawalAddress, signatureDomain)
+	if err != nil {
+		return types.ValidatorSignature{}, err
+	}
+
+	// Sign message
+	signature := withdrawalKey.Sign(srHash[:]).Marshal()
+
+	// Return
+	return types.BytesToValidatorSignature(signature), nil
+
+}
+
+// Check that a withdrawal creds change message was signed by the withdrawal key it changes from
+func VerifyWithdrawalCredsChangeSignature(validatorIndex uint64, withdrawalPubkey types.ValidatorPubkey, newWithdrawalAddress common.Address, signatureDomain []byte, signature types.ValidatorSignature) error {
+
+	// Initialize BLS support
+	if err := InitializeBLS(); err != nil {
+		return fmt.Errorf("error initializing BLS library: %w", err)
+	}
+
+	// Get signing root
+	srHash, err := getWithdrawalCredsChangeSigningRoot(validatorIndex, withdrawalPubkey, newWithdrawalAddress, signatureDomain)
+	if err != nil {
+		return err
+	}
+
+	// Verify the signature
+	pubkey, err := eth2types.BLSPublicKeyFromBytes(withdrawalPubkey.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid withdrawal pubkey %s: %w", withdrawalPubkey.Hex(), err)
+	}
+	sig, err := eth2types.BLSSignatureFromBytes(signature.Bytes())
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	if !sig.Verify(srHash[:], pubkey) {
+		return errors.New("the signature doesn't match the message and withdrawal pubkey")
+	}
+	return nil
+
+}
+
+// Get the signing root of a withdrawal creds change message
+func getWithdrawalCredsChangeSigningRoot(validatorIndex uint64, withdrawalPubkey types.ValidatorPubkey, newWithdrawalAddress common.Address, signatureDomain []byte) ([32]byte, error) {
 
 	// Build withdrawal creds change message
+	withdrawalPubkeyBuffer := [48]byte{}
+	copy(withdrawalPubkeyBuffer[:], withdrawalPubkey.Bytes())
 	message := eth2.WithdrawalCredentialsChange{
 		ValidatorIndex:     validatorIndex,
 		FromBLSPubkey:      withdrawalPubkeyBuffer,
@@ -41,7 +95,7 @@ func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKe
 	// Get object root
 	or, err := message.HashTreeRoot()
 	if err != nil {
-		return types.ValidatorSignature{}, err
+		return [32]byte{}, err
 	}
 
 	// Get signing root
@@ -49,16 +103,6 @@ func GetSignedWithdrawalCredsChangeMessage(withdrawalKey *eth2types.BLSPrivateKe
 		ObjectRoot: or[:],
 		Domain:     signatureDomain,
 	}
-
-	srHash, err := sr.HashTreeRoot()
-	if err != nil {
-		return types.ValidatorSignature{}, err
-	}
-
-	// Sign message
-	signature := withdrawalKey.Sign(srHash[:]).Marshal()
-
-	// Return
-	return types.BytesToValidatorSignature(signature), nil
+	return sr.HashTreeRoot()
 
 }
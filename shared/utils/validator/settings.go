@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"gopkg.in/yaml.v2"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Per-validator overrides for the settings the node daemon manages through the Keymanager API.
+// They're stored in validator-settings.yml in the Smartnode's data folder as a map of validator pubkeys to overrides,
+// and are set with `rocketpool node set-validator-settings`:
+//
+//	0xa1b2...:
+//	  graffiti: "my validator"   # at most 32 bytes; the Validator Client's default is used if it's missing
+//	  gasLimit: 36000000         # the builder gas limit; the Smartnode's Builder Gas Limit setting is used if it's missing
+type ValidatorSettings struct {
+	Graffiti *string `yaml:"graffiti,omitempty"`
+	GasLimit *uint64 `yaml:"gasLimit,omitempty"`
+}
+
+// Load the per-validator setting overrides, keyed by validator pubkey.
+// A missing file means there are no overrides.
+func LoadValidatorSettings(path string) (map[types.ValidatorPubkey]ValidatorSettings, error) {
+
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[types.ValidatorPubkey]ValidatorSettings{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read validator settings from %s: %w", path, err)
+	}
+
+	var entries map[string]ValidatorSettings
+	if err := yaml.Unmarshal(bytes, &entries); err != nil {
+		return nil, fmt.Errorf("could not decode validator settings from %s: %w", path, err)
+	}
+
+	settings := make(map[types.ValidatorPubkey]ValidatorSettings, len(entries))
+	for key, entry := range entries {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator pubkey '%s' in %s: %w", key, path, err)
+		}
+		if entry.Graffiti != nil && len(*entry.Graffiti) > 32 {
+			return nil, fmt.Errorf("the graffiti for validator %s in %s is longer than 32 bytes", key, path)
+		}
+		settings[pubkey] = entry
+	}
+	return settings, nil
+
+}
+
+// Save the per-validator setting overrides, dropping validators that don't override anything
+func SaveValidatorSettings(path string, settings map[types.ValidatorPubkey]ValidatorSettings) error {
+
+	entries := map[string]ValidatorSettings{}
+	for pubkey, entry := range settings {
+		if entry.Graffiti == nil && entry.GasLimit == nil {
+			continue
+		}
+		entries[hexutil.AddPrefix(pubkey.Hex())] = entry
+	}
+
+	bytes, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("could not encode validator settings: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("could not write validator settings to %s: %w", path, err)
+	}
+	return nil
+
+}